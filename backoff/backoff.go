@@ -0,0 +1,201 @@
+package backoff
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/jiu-u/gogout/randutils"
+)
+
+// Stop 策略返回 Stop 表示不再重试，funcutils.Retry 和 Iterator 都会据此停止
+const Stop time.Duration = -1
+
+// Strategy 退避策略，Next 返回第 attempt 次（从 1 开始）失败后到下一次尝试之间的等待时间
+// 与 funcutils.BackoffStrategy 的方法相同，因此本包的策略可以直接传给 funcutils.Retry
+type Strategy interface {
+	Next(attempt int) time.Duration
+}
+
+// StrategyFunc 函数形式的 Strategy
+type StrategyFunc func(attempt int) time.Duration
+
+// Next 实现 Strategy
+func (f StrategyFunc) Next(attempt int) time.Duration {
+	return f(attempt)
+}
+
+// Constant 每次等待固定的时间 d
+func Constant(d time.Duration) Strategy {
+	return StrategyFunc(func(int) time.Duration {
+		return d
+	})
+}
+
+// Linear 线性退避：第 n 次失败后等待 initial + (n-1)*increment
+func Linear(initial, increment time.Duration) Strategy {
+	return StrategyFunc(func(attempt int) time.Duration {
+		return saturate(float64(initial) + float64(max(attempt-1, 0))*float64(increment))
+	})
+}
+
+// Exponential 指数退避：第 n 次失败后等待 initial * multiplier^(n-1)
+// multiplier <= 1 时按 2 处理；结果溢出时保持为最大值，通常与 MaxInterval 搭配使用
+func Exponential(initial time.Duration, multiplier float64) Strategy {
+	if multiplier <= 1 {
+		multiplier = 2
+	}
+	return StrategyFunc(func(attempt int) time.Duration {
+		return saturate(float64(initial) * math.Pow(multiplier, float64(max(attempt-1, 0))))
+	})
+}
+
+// MaxInterval 将单次等待时间限制在 maxInterval 以内
+func MaxInterval(s Strategy, maxInterval time.Duration) Strategy {
+	return StrategyFunc(func(attempt int) time.Duration {
+		d := s.Next(attempt)
+		if d == Stop {
+			return Stop
+		}
+		return min(d, maxInterval)
+	})
+}
+
+// MaxAttempts 在第 n 次失败后返回 Stop
+func MaxAttempts(s Strategy, n int) Strategy {
+	return StrategyFunc(func(attempt int) time.Duration {
+		if attempt >= n {
+			return Stop
+		}
+		return s.Next(attempt)
+	})
+}
+
+// MaxElapsed 从第 1 次失败开始计时，累计时间加上下一次等待会超过 maxElapsed 时返回 Stop
+// 返回的策略有状态，attempt 为 1 时重新计时，不要在并发进行的多个重试之间共享
+func MaxElapsed(s Strategy, maxElapsed time.Duration) Strategy {
+	var (
+		mu    sync.Mutex
+		start time.Time
+	)
+	return StrategyFunc(func(attempt int) time.Duration {
+		mu.Lock()
+		if attempt <= 1 || start.IsZero() {
+			start = time.Now()
+		}
+		elapsed := time.Since(start)
+		mu.Unlock()
+
+		d := s.Next(attempt)
+		if d == Stop || elapsed+d > maxElapsed {
+			return Stop
+		}
+		return d
+	})
+}
+
+// FullJitter 全抖动：在 [0, d] 范围内均匀随机选择等待时间，d 为 s 给出的等待时间
+// 可以最大程度地分散大量客户端的重试时间
+func FullJitter(s Strategy) Strategy {
+	return FullJitterFrom(randutils.Default(), s)
+}
+
+// FullJitterFrom 与 FullJitter 相同，使用指定的随机源
+func FullJitterFrom(src randutils.Source, s Strategy) Strategy {
+	return StrategyFunc(func(attempt int) time.Duration {
+		d := s.Next(attempt)
+		if d <= 0 {
+			return d
+		}
+		return time.Duration(randutils.Int64BetweenFrom(src, 0, int64(d)))
+	})
+}
+
+// Jitter 按比例抖动：等待时间在 [d*(1-factor), d*(1+factor)] 范围内均匀随机选择，d 为 s 给出的等待时间
+// factor 会被限制在 [0, 1] 范围内；s 返回 Stop 或非正数时原样返回
+func Jitter(s Strategy, factor float64) Strategy {
+	return JitterFrom(randutils.Default(), s, factor)
+}
+
+// JitterFrom 与 Jitter 相同，使用指定的随机源
+func JitterFrom(src randutils.Source, s Strategy, factor float64) Strategy {
+	factor = min(max(factor, 0), 1)
+	return StrategyFunc(func(attempt int) time.Duration {
+		d := s.Next(attempt)
+		if d <= 0 {
+			return d
+		}
+		return saturate(float64(d) * (1 - factor + 2*factor*randutils.Float64From(src)))
+	})
+}
+
+// Decorrelated 去相关抖动：等待时间在 [base, 上一次等待时间*3] 范围内随机选择，且不超过 maxDelay
+// maxDelay <= 0 表示不限制
+// 返回的策略有状态，attempt 为 1 时重新开始，不要在并发进行的多个重试之间共享
+func Decorrelated(base, maxDelay time.Duration) Strategy {
+	return DecorrelatedFrom(randutils.Default(), base, maxDelay)
+}
+
+// DecorrelatedFrom 与 Decorrelated 相同，使用指定的随机源
+func DecorrelatedFrom(src randutils.Source, base, maxDelay time.Duration) Strategy {
+	var (
+		mu   sync.Mutex
+		prev time.Duration
+	)
+	return StrategyFunc(func(attempt int) time.Duration {
+		mu.Lock()
+		defer mu.Unlock()
+		if attempt <= 1 || prev < base {
+			prev = base
+		}
+		upper := saturate(float64(prev) * 3)
+		d := base
+		if upper > base {
+			d = time.Duration(randutils.Int64BetweenFrom(src, int64(base), int64(upper)))
+		}
+		if maxDelay > 0 {
+			d = min(d, maxDelay)
+		}
+		prev = d
+		return prev
+	})
+}
+
+// Iterator 按顺序产生等待时间，用于自行编写的重试循环
+type Iterator struct {
+	strategy Strategy
+	attempt  int
+}
+
+// NewIterator 创建一个使用策略 s 的迭代器
+func NewIterator(s Strategy) *Iterator {
+	return &Iterator{strategy: s}
+}
+
+// Next 返回下一次重试前的等待时间，策略返回 Stop 时返回 false
+func (it *Iterator) Next() (time.Duration, bool) {
+	it.attempt++
+	d := it.strategy.Next(it.attempt)
+	if d == Stop {
+		return 0, false
+	}
+	return max(d, 0), true
+}
+
+// Attempt 返回已经调用 Next 的次数
+func (it *Iterator) Attempt() int {
+	return it.attempt
+}
+
+// Reset 重置迭代器，下一次 Next 从第 1 次开始
+func (it *Iterator) Reset() {
+	it.attempt = 0
+}
+
+// saturate 将浮点数转换为 time.Duration，超出范围时取最大值
+func saturate(d float64) time.Duration {
+	if d >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return time.Duration(max(d, 0))
+}
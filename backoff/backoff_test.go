@@ -0,0 +1,149 @@
+package backoff
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jiu-u/gogout/randutils"
+)
+
+// delays 返回策略前 n 次的等待时间
+func delays(s Strategy, n int) []time.Duration {
+	result := make([]time.Duration, n)
+	for i := range result {
+		result[i] = s.Next(i + 1)
+	}
+	return result
+}
+
+func TestStrategies(t *testing.T) {
+	ms := time.Millisecond
+	tests := []struct {
+		name string
+		s    Strategy
+		want []time.Duration
+	}{
+		{"Constant", Constant(10 * ms), []time.Duration{10 * ms, 10 * ms, 10 * ms}},
+		{"Linear", Linear(10*ms, 5*ms), []time.Duration{10 * ms, 15 * ms, 20 * ms}},
+		{"Exponential", Exponential(10*ms, 2), []time.Duration{10 * ms, 20 * ms, 40 * ms, 80 * ms}},
+		{"Exponential 非法倍数", Exponential(10*ms, 0.5), []time.Duration{10 * ms, 20 * ms}},
+		{"MaxInterval", MaxInterval(Exponential(10*ms, 3), 50*ms), []time.Duration{10 * ms, 30 * ms, 50 * ms, 50 * ms}},
+		{"MaxAttempts", MaxAttempts(Constant(ms), 3), []time.Duration{ms, ms, Stop, Stop}},
+		{"MaxInterval 保留 Stop", MaxInterval(MaxAttempts(Constant(ms), 2), time.Second), []time.Duration{ms, Stop}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := delays(tt.s, len(tt.want))
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Next(%d) = %v, 期望 %v", i+1, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+
+	if got := Exponential(time.Second, 10).Next(100); got != math.MaxInt64 {
+		t.Errorf("溢出时 Next() = %v, 期望 %v", got, time.Duration(math.MaxInt64))
+	}
+}
+
+func TestJitter(t *testing.T) {
+	full := FullJitter(Constant(100 * time.Millisecond))
+	for i := range 100 {
+		if d := full.Next(i + 1); d < 0 || d > 100*time.Millisecond {
+			t.Fatalf("FullJitter Next() = %v, 超出 [0, 100ms]", d)
+		}
+	}
+
+	scaled := Jitter(Constant(time.Second), 0.5)
+	for i := range 100 {
+		if d := scaled.Next(i + 1); d < 500*time.Millisecond || d > 1500*time.Millisecond {
+			t.Fatalf("Jitter Next() = %v, 超出 [500ms, 1.5s]", d)
+		}
+	}
+	if d := Jitter(MaxAttempts(Constant(time.Second), 1), 0.5).Next(1); d != Stop {
+		t.Errorf("Jitter Next() = %v, 期望 Stop", d)
+	}
+
+	base, maxDelay := 10*time.Millisecond, 200*time.Millisecond
+	dec := Decorrelated(base, maxDelay)
+	prev := base
+	for attempt := 1; attempt <= 50; attempt++ {
+		d := dec.Next(attempt)
+		if d < base || d > maxDelay || d > 3*prev {
+			t.Fatalf("Decorrelated Next(%d) = %v, 上一次 %v, 超出范围", attempt, d, prev)
+		}
+		prev = d
+	}
+	if d := dec.Next(1); d < base || d > 3*base {
+		t.Errorf("重新开始后 Next(1) = %v, 期望在 [%v, %v] 范围内", d, base, 3*base)
+	}
+
+	for _, maxDelay := range []time.Duration{0, -time.Second} {
+		unbounded := Decorrelated(base, maxDelay)
+		for attempt := 1; attempt <= 10; attempt++ {
+			if d := unbounded.Next(attempt); d < base {
+				t.Fatalf("Decorrelated(maxDelay = %v) Next(%d) = %v, 期望不小于 %v", maxDelay, attempt, d, base)
+			}
+		}
+	}
+
+	if d := FullJitter(Constant(math.MaxInt64)).Next(1); d < 0 {
+		t.Errorf("FullJitter Next() = %v, 期望非负数", d)
+	}
+}
+
+func TestJitterFrom(t *testing.T) {
+	strategies := map[string]func(src randutils.Source) Strategy{
+		"FullJitterFrom":   func(src randutils.Source) Strategy { return FullJitterFrom(src, Constant(time.Second)) },
+		"JitterFrom":       func(src randutils.Source) Strategy { return JitterFrom(src, Constant(time.Second), 0.5) },
+		"DecorrelatedFrom": func(src randutils.Source) Strategy { return DecorrelatedFrom(src, time.Millisecond, time.Second) },
+	}
+	for name, newStrategy := range strategies {
+		t.Run(name, func(t *testing.T) {
+			a, b := newStrategy(randutils.NewSource(42)), newStrategy(randutils.NewSource(42))
+			if da, db := delays(a, 10), delays(b, 10); !slices.Equal(da, db) {
+				t.Errorf("相同种子的等待时间不同: %v, %v", da, db)
+			}
+		})
+	}
+}
+
+func TestMaxElapsed(t *testing.T) {
+	s := MaxElapsed(Constant(10*time.Millisecond), 25*time.Millisecond)
+	if d := s.Next(1); d != 10*time.Millisecond {
+		t.Errorf("Next(1) = %v, 期望 %v", d, 10*time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if d := s.Next(2); d != Stop {
+		t.Errorf("超时后 Next(2) = %v, 期望 Stop", d)
+	}
+	if d := s.Next(1); d != 10*time.Millisecond {
+		t.Errorf("重新开始后 Next(1) = %v, 期望 %v", d, 10*time.Millisecond)
+	}
+}
+
+func TestIterator(t *testing.T) {
+	it := NewIterator(MaxAttempts(Linear(time.Second, time.Second), 3))
+	var got []time.Duration
+	for {
+		d, ok := it.Next()
+		if !ok {
+			break
+		}
+		got = append(got, d)
+	}
+	want := []time.Duration{time.Second, 2 * time.Second}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("Iterator 产生 %v, 期望 %v", got, want)
+	}
+	if it.Attempt() != 3 {
+		t.Errorf("Attempt() = %v, 期望 %v", it.Attempt(), 3)
+	}
+	it.Reset()
+	if d, ok := it.Next(); !ok || d != time.Second {
+		t.Errorf("Reset() 后 Next() = %v, %v, 期望 %v, true", d, ok, time.Second)
+	}
+}
@@ -0,0 +1,37 @@
+package cache
+
+import "github.com/jiu-u/gogout/timeutils"
+
+// config 缓存的通用配置
+type config[K comparable, V any] struct {
+	onEvict func(key K, value V)
+	clock   timeutils.Clock
+}
+
+// Option 缓存的可选配置项
+type Option[K comparable, V any] func(*config[K, V])
+
+// WithOnEvict 设置元素因容量不足或过期被淘汰时的回调，主动 Remove 不会触发
+func WithOnEvict[K comparable, V any](fn func(key K, value V)) Option[K, V] {
+	return func(c *config[K, V]) {
+		c.onEvict = fn
+	}
+}
+
+// WithClock 设置判断过期使用的时钟，默认为 timeutils.RealClock，测试中可以使用 timeutils.FakeClock
+// 只对 TTL 缓存有效
+func WithClock[K comparable, V any](clock timeutils.Clock) Option[K, V] {
+	return func(c *config[K, V]) {
+		c.clock = clock
+	}
+}
+
+// newConfig 应用所有配置项
+func newConfig[K comparable, V any](opts []Option[K, V]) config[K, V] {
+	var cfg config[K, V]
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	cfg.clock = timeutils.OrRealClock(cfg.clock)
+	return cfg
+}
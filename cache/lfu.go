@@ -0,0 +1,154 @@
+package cache
+
+import "github.com/jiu-u/gogout/collections"
+
+// lfuEntry LFU 中的一个键值对及其访问次数
+type lfuEntry[K comparable, V any] struct {
+	key   K
+	value V
+	freq  int
+}
+
+// LFU 最不经常使用缓存，容量满时淘汰访问次数最少的元素，次数相同时淘汰最久未访问的
+// 使用按访问次数分组的链表实现，Get 和 Put 均为 O(1)；非并发安全
+type LFU[K comparable, V any] struct {
+	capacity int
+	items    map[K]*collections.Element[lfuEntry[K, V]]
+	freqs    map[int]*collections.List[lfuEntry[K, V]] // 访问次数 -> 该次数的元素，头部为最近访问
+	minFreq  int
+	cfg      config[K, V]
+}
+
+// NewLFU 创建一个容量为 capacity 的 LFU 缓存，capacity <= 0 时按 1 处理
+func NewLFU[K comparable, V any](capacity int, opts ...Option[K, V]) *LFU[K, V] {
+	return &LFU[K, V]{
+		capacity: max(capacity, 1),
+		items:    make(map[K]*collections.Element[lfuEntry[K, V]]),
+		freqs:    make(map[int]*collections.List[lfuEntry[K, V]]),
+		cfg:      newConfig(opts),
+	}
+}
+
+// Get 返回 key 对应的值并增加其访问次数
+func (c *LFU[K, V]) Get(key K) (V, bool) {
+	e, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.touch(e)
+	return e.Value.value, true
+}
+
+// Peek 返回 key 对应的值，不增加访问次数
+func (c *LFU[K, V]) Peek(key K) (V, bool) {
+	e, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return e.Value.value, true
+}
+
+// Contains 判断 key 是否存在，不增加访问次数
+func (c *LFU[K, V]) Contains(key K) bool {
+	_, ok := c.items[key]
+	return ok
+}
+
+// Put 写入键值对，已存在的 key 会更新值并增加访问次数
+// 因容量不足淘汰了其他元素时返回 true
+func (c *LFU[K, V]) Put(key K, value V) bool {
+	if e, ok := c.items[key]; ok {
+		e.Value.value = value
+		c.touch(e)
+		return false
+	}
+
+	evicted := false
+	var victim lfuEntry[K, V]
+	if len(c.items) >= c.capacity {
+		list := c.freqs[c.minFreq]
+		victim = c.unlink(list.Back())
+		evicted = true
+	}
+	c.items[key] = c.list(1).PushFront(lfuEntry[K, V]{key: key, value: value, freq: 1})
+	c.minFreq = 1
+
+	if evicted && c.cfg.onEvict != nil {
+		c.cfg.onEvict(victim.key, victim.value)
+	}
+	return evicted
+}
+
+// Remove 删除 key，key 存在时返回 true
+func (c *LFU[K, V]) Remove(key K) bool {
+	e, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	c.unlink(e)
+	return true
+}
+
+// Frequency 返回 key 的访问次数，key 不存在时返回 0
+func (c *LFU[K, V]) Frequency(key K) int {
+	if e, ok := c.items[key]; ok {
+		return e.Value.freq
+	}
+	return 0
+}
+
+// Len 返回缓存中的元素数量
+func (c *LFU[K, V]) Len() int {
+	return len(c.items)
+}
+
+// Cap 返回缓存的容量
+func (c *LFU[K, V]) Cap() int {
+	return c.capacity
+}
+
+// Purge 清空缓存，不触发淘汰回调
+func (c *LFU[K, V]) Purge() {
+	c.items = make(map[K]*collections.Element[lfuEntry[K, V]])
+	c.freqs = make(map[int]*collections.List[lfuEntry[K, V]])
+	c.minFreq = 0
+}
+
+// touch 将元素移动到访问次数加一的链表
+func (c *LFU[K, V]) touch(e *collections.Element[lfuEntry[K, V]]) {
+	ent := e.Value
+	old := c.freqs[ent.freq]
+	old.Remove(e)
+	if old.Len() == 0 {
+		delete(c.freqs, ent.freq)
+		if c.minFreq == ent.freq {
+			c.minFreq++
+		}
+	}
+	ent.freq++
+	c.items[ent.key] = c.list(ent.freq).PushFront(ent)
+}
+
+// unlink 从缓存中删除元素并返回其内容
+func (c *LFU[K, V]) unlink(e *collections.Element[lfuEntry[K, V]]) lfuEntry[K, V] {
+	ent := e.Value
+	list := c.freqs[ent.freq]
+	list.Remove(e)
+	if list.Len() == 0 {
+		delete(c.freqs, ent.freq)
+	}
+	delete(c.items, ent.key)
+	return ent
+}
+
+// list 返回访问次数为 freq 的链表，不存在时创建
+func (c *LFU[K, V]) list(freq int) *collections.List[lfuEntry[K, V]] {
+	l, ok := c.freqs[freq]
+	if !ok {
+		l = collections.NewList[lfuEntry[K, V]]()
+		c.freqs[freq] = l
+	}
+	return l
+}
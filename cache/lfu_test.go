@@ -0,0 +1,97 @@
+package cache
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLFU(t *testing.T) {
+	tests := []struct {
+		name     string
+		capacity int
+		ops      func(c *LFU[string, int])
+		present  []string
+		absent   []string
+	}{
+		{"淘汰访问次数最少", 2, func(c *LFU[string, int]) {
+			c.Put("a", 1)
+			c.Put("b", 2)
+			c.Get("a")
+			c.Put("c", 3)
+		}, []string{"a", "c"}, []string{"b"}},
+		{"次数相同淘汰最久未访问", 2, func(c *LFU[string, int]) {
+			c.Put("a", 1)
+			c.Put("b", 2)
+			c.Get("a")
+			c.Get("b")
+			c.Put("c", 3)
+		}, []string{"b", "c"}, []string{"a"}},
+		{"扫描不会挤掉热点", 3, func(c *LFU[string, int]) {
+			c.Put("hot", 0)
+			for range 5 {
+				c.Get("hot")
+			}
+			for _, k := range []string{"x", "y", "z", "w"} {
+				c.Put(k, 0)
+			}
+		}, []string{"hot", "z", "w"}, []string{"x", "y"}},
+		{"Peek 不增加次数", 2, func(c *LFU[string, int]) {
+			c.Put("a", 1)
+			c.Put("b", 2)
+			c.Peek("a")
+			c.Put("c", 3)
+		}, []string{"b", "c"}, []string{"a"}},
+		{"删除后不淘汰", 2, func(c *LFU[string, int]) {
+			c.Put("a", 1)
+			c.Put("b", 2)
+			c.Remove("a")
+			c.Put("c", 3)
+		}, []string{"b", "c"}, []string{"a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewLFU[string, int](tt.capacity)
+			tt.ops(c)
+			for _, k := range tt.present {
+				if !c.Contains(k) {
+					t.Errorf("Contains(%q) = false, 期望 true", k)
+				}
+			}
+			for _, k := range tt.absent {
+				if c.Contains(k) {
+					t.Errorf("Contains(%q) = true, 期望 false", k)
+				}
+			}
+		})
+	}
+}
+
+func TestLFUGetAndEvict(t *testing.T) {
+	var evicted []string
+	c := NewLFU(2, WithOnEvict(func(k string, v int) {
+		evicted = append(evicted, k)
+	}))
+	c.Put("a", 1)
+	c.Put("a", 10)
+	if c.Frequency("a") != 2 {
+		t.Errorf("Frequency(a) = %v, 期望 %v", c.Frequency("a"), 2)
+	}
+	c.Put("b", 2)
+	if !c.Put("c", 3) {
+		t.Error("超出容量的 Put() = false, 期望 true")
+	}
+	if v, ok := c.Get("a"); !ok || v != 10 {
+		t.Errorf("Get(a) = %v, %v, 期望 10, true", v, ok)
+	}
+	if want := []string{"b"}; !slices.Equal(evicted, want) {
+		t.Errorf("淘汰回调 = %v, 期望 %v", evicted, want)
+	}
+	c.Purge()
+	if c.Len() != 0 || c.Frequency("a") != 0 || c.Cap() != 2 {
+		t.Error("Purge() 后缓存不为空")
+	}
+	c.Put("d", 4)
+	if c.Len() != 1 {
+		t.Errorf("Len() = %v, 期望 %v", c.Len(), 1)
+	}
+}
@@ -0,0 +1,192 @@
+package cache
+
+import (
+	"sync"
+
+	"github.com/jiu-u/gogout/collections"
+)
+
+// entry 缓存中的一个键值对
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// LRU 最近最少使用缓存，容量满时淘汰最久未被访问的元素
+// 非并发安全，并发场景使用 SyncLRU
+type LRU[K comparable, V any] struct {
+	capacity int
+	items    map[K]*collections.Element[entry[K, V]]
+	order    collections.List[entry[K, V]] // 头部为最近访问的元素
+	cfg      config[K, V]
+}
+
+// NewLRU 创建一个容量为 capacity 的 LRU 缓存，capacity <= 0 时按 1 处理
+func NewLRU[K comparable, V any](capacity int, opts ...Option[K, V]) *LRU[K, V] {
+	return &LRU[K, V]{
+		capacity: max(capacity, 1),
+		items:    make(map[K]*collections.Element[entry[K, V]]),
+		cfg:      newConfig(opts),
+	}
+}
+
+// Get 返回 key 对应的值并将其标记为最近访问
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	e, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.value, true
+}
+
+// Peek 返回 key 对应的值，不影响访问顺序
+func (c *LRU[K, V]) Peek(key K) (V, bool) {
+	e, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return e.Value.value, true
+}
+
+// Contains 判断 key 是否存在，不影响访问顺序
+func (c *LRU[K, V]) Contains(key K) bool {
+	_, ok := c.items[key]
+	return ok
+}
+
+// Put 写入键值对并将其标记为最近访问，因容量不足淘汰了其他元素时返回 true
+func (c *LRU[K, V]) Put(key K, value V) bool {
+	evicted, ok := c.put(key, value)
+	if ok && c.cfg.onEvict != nil {
+		c.cfg.onEvict(evicted.key, evicted.value)
+	}
+	return ok
+}
+
+// put 写入键值对，返回被淘汰的元素
+func (c *LRU[K, V]) put(key K, value V) (entry[K, V], bool) {
+	if e, ok := c.items[key]; ok {
+		e.Value.value = value
+		c.order.MoveToFront(e)
+		return entry[K, V]{}, false
+	}
+	c.items[key] = c.order.PushFront(entry[K, V]{key: key, value: value})
+	if c.order.Len() <= c.capacity {
+		return entry[K, V]{}, false
+	}
+	oldest := c.order.Remove(c.order.Back())
+	delete(c.items, oldest.key)
+	return oldest, true
+}
+
+// Remove 删除 key，key 存在时返回 true
+func (c *LRU[K, V]) Remove(key K) bool {
+	e, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	c.order.Remove(e)
+	delete(c.items, key)
+	return true
+}
+
+// Keys 按从最近到最久访问的顺序返回所有 key
+func (c *LRU[K, V]) Keys() []K {
+	keys := make([]K, 0, c.order.Len())
+	c.order.Range(func(e entry[K, V]) bool {
+		keys = append(keys, e.key)
+		return true
+	})
+	return keys
+}
+
+// Len 返回缓存中的元素数量
+func (c *LRU[K, V]) Len() int {
+	return c.order.Len()
+}
+
+// Cap 返回缓存的容量
+func (c *LRU[K, V]) Cap() int {
+	return c.capacity
+}
+
+// Purge 清空缓存，不触发淘汰回调
+func (c *LRU[K, V]) Purge() {
+	c.items = make(map[K]*collections.Element[entry[K, V]])
+	c.order = collections.List[entry[K, V]]{}
+}
+
+// SyncLRU 并发安全的 LRU 缓存
+// 淘汰回调在释放锁之后调用，因此回调中可以安全地访问缓存
+type SyncLRU[K comparable, V any] struct {
+	mu  sync.Mutex
+	lru *LRU[K, V]
+}
+
+// NewSyncLRU 创建一个容量为 capacity 的并发安全 LRU 缓存
+func NewSyncLRU[K comparable, V any](capacity int, opts ...Option[K, V]) *SyncLRU[K, V] {
+	return &SyncLRU[K, V]{lru: NewLRU(capacity, opts...)}
+}
+
+// Get 返回 key 对应的值并将其标记为最近访问
+func (c *SyncLRU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Get(key)
+}
+
+// Peek 返回 key 对应的值，不影响访问顺序
+func (c *SyncLRU[K, V]) Peek(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Peek(key)
+}
+
+// Contains 判断 key 是否存在，不影响访问顺序
+func (c *SyncLRU[K, V]) Contains(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Contains(key)
+}
+
+// Put 写入键值对并将其标记为最近访问，因容量不足淘汰了其他元素时返回 true
+func (c *SyncLRU[K, V]) Put(key K, value V) bool {
+	c.mu.Lock()
+	evicted, ok := c.lru.put(key, value)
+	c.mu.Unlock()
+	if ok && c.lru.cfg.onEvict != nil {
+		c.lru.cfg.onEvict(evicted.key, evicted.value)
+	}
+	return ok
+}
+
+// Remove 删除 key，key 存在时返回 true
+func (c *SyncLRU[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Remove(key)
+}
+
+// Keys 按从最近到最久访问的顺序返回所有 key
+func (c *SyncLRU[K, V]) Keys() []K {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Keys()
+}
+
+// Len 返回缓存中的元素数量
+func (c *SyncLRU[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// Purge 清空缓存，不触发淘汰回调
+func (c *SyncLRU[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lru.Purge()
+}
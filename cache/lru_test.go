@@ -0,0 +1,116 @@
+package cache
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestLRU(t *testing.T) {
+	tests := []struct {
+		name     string
+		capacity int
+		ops      func(c *LRU[string, int])
+		wantKeys []string
+	}{
+		{"未超出容量", 3, func(c *LRU[string, int]) {
+			c.Put("a", 1)
+			c.Put("b", 2)
+		}, []string{"b", "a"}},
+		{"淘汰最久未访问", 2, func(c *LRU[string, int]) {
+			c.Put("a", 1)
+			c.Put("b", 2)
+			c.Get("a")
+			c.Put("c", 3)
+		}, []string{"c", "a"}},
+		{"Peek 不影响顺序", 2, func(c *LRU[string, int]) {
+			c.Put("a", 1)
+			c.Put("b", 2)
+			c.Peek("a")
+			c.Put("c", 3)
+		}, []string{"c", "b"}},
+		{"更新已有 key", 2, func(c *LRU[string, int]) {
+			c.Put("a", 1)
+			c.Put("b", 2)
+			c.Put("a", 10)
+			c.Put("c", 3)
+		}, []string{"c", "a"}},
+		{"删除", 2, func(c *LRU[string, int]) {
+			c.Put("a", 1)
+			c.Put("b", 2)
+			c.Remove("a")
+		}, []string{"b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewLRU[string, int](tt.capacity)
+			tt.ops(c)
+			if got := c.Keys(); !slices.Equal(got, tt.wantKeys) {
+				t.Errorf("Keys() = %v, 期望 %v", got, tt.wantKeys)
+			}
+			if c.Len() != len(tt.wantKeys) {
+				t.Errorf("Len() = %v, 期望 %v", c.Len(), len(tt.wantKeys))
+			}
+		})
+	}
+}
+
+func TestLRUGetAndEvict(t *testing.T) {
+	var evicted []string
+	c := NewLRU(2, WithOnEvict(func(k string, v int) {
+		evicted = append(evicted, k)
+	}))
+	c.Put("a", 1)
+	c.Put("b", 2)
+	if c.Put("a", 100) {
+		t.Error("更新已有 key 的 Put() = true, 期望 false")
+	}
+	if !c.Put("c", 3) {
+		t.Error("超出容量的 Put() = false, 期望 true")
+	}
+	c.Remove("a")
+
+	if v, ok := c.Get("c"); !ok || v != 3 {
+		t.Errorf("Get(c) = %v, %v, 期望 3, true", v, ok)
+	}
+	if _, ok := c.Get("b"); ok {
+		t.Error("Get(b) 应已被淘汰")
+	}
+	if want := []string{"b"}; !slices.Equal(evicted, want) {
+		t.Errorf("淘汰回调 = %v, 期望 %v", evicted, want)
+	}
+	c.Purge()
+	if c.Len() != 0 || c.Contains("c") || c.Cap() != 2 {
+		t.Error("Purge() 后缓存不为空")
+	}
+}
+
+func TestSyncLRU(t *testing.T) {
+	var evictions atomic.Int32
+	var c *SyncLRU[int, int]
+	c = NewSyncLRU(10, WithOnEvict(func(k, v int) {
+		// 回调在释放锁后调用，可以访问缓存
+		c.Contains(k)
+		evictions.Add(1)
+	}))
+
+	var wg sync.WaitGroup
+	for i := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 100 {
+				c.Put(i*100+j, j)
+				c.Get(j)
+			}
+		}()
+	}
+	wg.Wait()
+	if c.Len() != 10 {
+		t.Errorf("Len() = %v, 期望 %v", c.Len(), 10)
+	}
+	if evictions.Load() != 390 {
+		t.Errorf("淘汰次数 = %v, 期望 %v", evictions.Load(), 390)
+	}
+}
@@ -0,0 +1,208 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jiu-u/gogout/concurrency"
+	"github.com/jiu-u/gogout/funcutils"
+)
+
+// ttlEntry TTL 缓存中的一个值及其过期时间
+type ttlEntry[V any] struct {
+	value     V
+	expiresAt time.Time // 零值表示永不过期
+}
+
+// expired 判断在 now 时是否已经过期
+func (e ttlEntry[V]) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// loadCall 一次正在进行的加载，等待同一个 key 的调用者共享其结果
+type loadCall[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// TTL 带过期时间的并发安全缓存
+// 过期元素在访问时惰性删除，也可以通过 StartCleanup 在后台定期清理；过期删除时会触发淘汰回调
+type TTL[K comparable, V any] struct {
+	ttl time.Duration
+	cfg config[K, V]
+
+	mu    sync.Mutex
+	items map[K]ttlEntry[V]
+	calls map[K]*loadCall[V]
+}
+
+// NewTTL 创建一个默认过期时间为 ttl 的缓存，ttl <= 0 表示默认永不过期
+func NewTTL[K comparable, V any](ttl time.Duration, opts ...Option[K, V]) *TTL[K, V] {
+	return &TTL[K, V]{
+		ttl:   ttl,
+		cfg:   newConfig(opts),
+		items: make(map[K]ttlEntry[V]),
+		calls: make(map[K]*loadCall[V]),
+	}
+}
+
+// Set 使用默认过期时间写入键值对
+func (c *TTL[K, V]) Set(key K, value V) {
+	c.SetWithTTL(key, value, c.ttl)
+}
+
+// SetWithTTL 使用指定的过期时间写入键值对，ttl <= 0 表示永不过期
+func (c *TTL[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.set(key, value, ttl)
+}
+
+// set 写入键值对，调用方需持有锁
+func (c *TTL[K, V]) set(key K, value V, ttl time.Duration) {
+	e := ttlEntry[V]{value: value}
+	if ttl > 0 {
+		e.expiresAt = c.cfg.clock.Now().Add(ttl)
+	}
+	c.items[key] = e
+}
+
+// Get 返回 key 对应的值，不存在或已过期时返回零值和 false
+func (c *TTL[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	e, ok := c.items[key]
+	expired := ok && e.expired(c.cfg.clock.Now())
+	if expired {
+		delete(c.items, key)
+	}
+	c.mu.Unlock()
+
+	if expired {
+		c.evicted(key, e.value)
+	}
+	if !ok || expired {
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+// TTLOf 返回 key 的剩余存活时间，永不过期时返回 0 和 true，不存在或已过期时返回 false
+func (c *TTL[K, V]) TTLOf(key K) (time.Duration, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.items[key]
+	now := c.cfg.clock.Now()
+	if !ok || e.expired(now) {
+		return 0, false
+	}
+	if e.expiresAt.IsZero() {
+		return 0, true
+	}
+	return e.expiresAt.Sub(now), true
+}
+
+// GetOrLoad 返回 key 对应的值，不存在或已过期时调用 loader 加载并以默认过期时间写入
+// 同一个 key 的并发调用只会执行一次 loader，其余调用等待并共享其结果；loader 返回错误时不写入缓存
+// loader 使用首个调用者的 ctx 执行，等待中的调用者在自己的 ctx 结束时返回 ctx.Err()
+func (c *TTL[K, V]) GetOrLoad(ctx context.Context, key K, loader func(ctx context.Context, key K) (V, error)) (V, error) {
+	if v, ok := c.Get(key); ok {
+		return v, nil
+	}
+
+	c.mu.Lock()
+	if e, ok := c.items[key]; ok && !e.expired(c.cfg.clock.Now()) {
+		c.mu.Unlock()
+		return e.value, nil
+	}
+	if call, ok := c.calls[key]; ok {
+		c.mu.Unlock()
+		select {
+		case <-call.done:
+			return call.value, call.err
+		case <-ctx.Done():
+			var zero V
+			return zero, ctx.Err()
+		}
+	}
+	call := &loadCall[V]{done: make(chan struct{})}
+	c.calls[key] = call
+	c.mu.Unlock()
+
+	call.value, call.err = funcutils.Try1(func() (V, error) {
+		return loader(ctx, key)
+	})
+
+	c.mu.Lock()
+	if call.err == nil {
+		c.set(key, call.value, c.ttl)
+	}
+	delete(c.calls, key)
+	c.mu.Unlock()
+	close(call.done)
+	return call.value, call.err
+}
+
+// Remove 删除 key，key 存在且未过期时返回 true；不触发淘汰回调
+func (c *TTL[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.items[key]
+	delete(c.items, key)
+	return ok && !e.expired(c.cfg.clock.Now())
+}
+
+// Len 返回缓存中的元素数量，包括已过期但尚未清理的元素
+func (c *TTL[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
+
+// DeleteExpired 删除所有已过期的元素并触发淘汰回调，返回删除的数量
+func (c *TTL[K, V]) DeleteExpired() int {
+	type expiredItem struct {
+		key   K
+		value V
+	}
+	var removed []expiredItem
+
+	c.mu.Lock()
+	now := c.cfg.clock.Now()
+	for k, e := range c.items {
+		if e.expired(now) {
+			delete(c.items, k)
+			removed = append(removed, expiredItem{k, e.value})
+		}
+	}
+	c.mu.Unlock()
+
+	for _, it := range removed {
+		c.evicted(it.key, it.value)
+	}
+	return len(removed)
+}
+
+// StartCleanup 启动后台 goroutine，每隔 interval 调用一次 DeleteExpired，直到 ctx 结束
+func (c *TTL[K, V]) StartCleanup(ctx context.Context, interval time.Duration) {
+	go concurrency.Every(ctx, interval, func(context.Context) error {
+		c.DeleteExpired()
+		return nil
+	}, concurrency.WithClock(c.cfg.clock))
+}
+
+// Purge 清空缓存，不触发淘汰回调
+func (c *TTL[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items = make(map[K]ttlEntry[V])
+}
+
+// evicted 在不持有锁的情况下调用淘汰回调
+func (c *TTL[K, V]) evicted(key K, value V) {
+	if c.cfg.onEvict != nil {
+		c.cfg.onEvict(key, value)
+	}
+}
@@ -0,0 +1,170 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jiu-u/gogout/timeutils"
+)
+
+// newTestTTL 创建一个使用 FakeClock 的 TTL 缓存，返回推进时间的函数
+func newTestTTL[K comparable, V any](ttl time.Duration, opts ...Option[K, V]) (*TTL[K, V], func(time.Duration)) {
+	clock := timeutils.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	c := NewTTL(ttl, append(opts, WithClock[K, V](clock))...)
+	return c, clock.Advance
+}
+
+func TestTTL(t *testing.T) {
+	tests := []struct {
+		name    string
+		ttl     time.Duration
+		setTTL  time.Duration
+		elapsed time.Duration
+		wantOK  bool
+	}{
+		{"未过期", time.Minute, -1, 30 * time.Second, true},
+		{"已过期", time.Minute, -1, time.Minute, false},
+		{"单独设置的过期时间", time.Minute, time.Hour, 30 * time.Minute, true},
+		{"永不过期", 0, -1, 1000 * time.Hour, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, advance := newTestTTL[string, int](tt.ttl)
+			if tt.setTTL < 0 {
+				c.Set("k", 1)
+			} else {
+				c.SetWithTTL("k", 1, tt.setTTL)
+			}
+			advance(tt.elapsed)
+			if _, ok := c.Get("k"); ok != tt.wantOK {
+				t.Errorf("Get() ok = %v, 期望 %v", ok, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestTTLExpiry(t *testing.T) {
+	var evicted []string
+	var mu sync.Mutex
+	c, advance := newTestTTL(time.Minute, WithOnEvict(func(k string, v int) {
+		mu.Lock()
+		defer mu.Unlock()
+		evicted = append(evicted, k)
+	}))
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.SetWithTTL("c", 3, 0)
+
+	if d, ok := c.TTLOf("a"); !ok || d != time.Minute {
+		t.Errorf("TTLOf(a) = %v, %v, 期望 %v, true", d, ok, time.Minute)
+	}
+	advance(2 * time.Minute)
+	if c.Len() != 3 {
+		t.Errorf("清理前 Len() = %v, 期望 %v", c.Len(), 3)
+	}
+	if _, ok := c.Get("a"); ok {
+		t.Error("Get(a) 应已过期")
+	}
+	if n := c.DeleteExpired(); n != 1 {
+		t.Errorf("DeleteExpired() = %v, 期望 %v", n, 1)
+	}
+	if c.Len() != 1 || len(evicted) != 2 {
+		t.Errorf("Len() = %v, 回调次数 = %v, 期望 1, 2", c.Len(), len(evicted))
+	}
+	if c.Remove("a") || !c.Remove("c") {
+		t.Error("Remove() 结果错误")
+	}
+}
+
+func TestTTLStartCleanup(t *testing.T) {
+	var evictions atomic.Int32
+	clock := timeutils.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	c := NewTTL(time.Minute, WithOnEvict(func(string, int) { evictions.Add(1) }), WithClock[string, int](clock))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c.StartCleanup(ctx, time.Minute)
+	c.Set("a", 1)
+	c.Set("b", 2)
+	clock.BlockUntil(1)
+	clock.Advance(time.Minute)
+
+	deadline := time.Now().Add(time.Second)
+	for c.Len() > 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if c.Len() != 0 || evictions.Load() != 2 {
+		t.Errorf("Len() = %v, 回调次数 = %v, 期望 0, 2", c.Len(), evictions.Load())
+	}
+}
+
+func TestTTLGetOrLoad(t *testing.T) {
+	t.Run("并发加载只执行一次", func(t *testing.T) {
+		c := NewTTL[string, int](time.Minute)
+		var calls atomic.Int32
+		release := make(chan struct{})
+		loader := func(ctx context.Context, key string) (int, error) {
+			calls.Add(1)
+			<-release
+			return len(key), nil
+		}
+
+		var wg sync.WaitGroup
+		results := make([]int, 10)
+		for i := range results {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[i], _ = c.GetOrLoad(context.Background(), "key", loader)
+			}()
+		}
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		if calls.Load() != 1 {
+			t.Errorf("loader 调用次数 = %v, 期望 %v", calls.Load(), 1)
+		}
+		for _, r := range results {
+			if r != 3 {
+				t.Errorf("GetOrLoad() = %v, 期望 %v", r, 3)
+			}
+		}
+		if v, ok := c.Get("key"); !ok || v != 3 {
+			t.Errorf("Get() = %v, %v, 期望 3, true", v, ok)
+		}
+	})
+
+	t.Run("错误和 panic 不写入缓存", func(t *testing.T) {
+		c := NewTTL[string, int](time.Minute)
+		errLoad := errors.New("load failed")
+		if _, err := c.GetOrLoad(context.Background(), "a", func(context.Context, string) (int, error) {
+			return 0, errLoad
+		}); !errors.Is(err, errLoad) {
+			t.Errorf("GetOrLoad() 错误 = %v, 期望 %v", err, errLoad)
+		}
+		if _, err := c.GetOrLoad(context.Background(), "a", func(context.Context, string) (int, error) {
+			panic("oops")
+		}); err == nil {
+			t.Error("GetOrLoad() 未返回 panic 错误")
+		}
+		if c.Len() != 0 {
+			t.Errorf("Len() = %v, 期望 %v", c.Len(), 0)
+		}
+	})
+
+	t.Run("已缓存时不调用 loader", func(t *testing.T) {
+		c := NewTTL[string, int](time.Minute)
+		c.Set("a", 1)
+		v, err := c.GetOrLoad(context.Background(), "a", func(context.Context, string) (int, error) {
+			t.Error("不应调用 loader")
+			return 0, nil
+		})
+		if v != 1 || err != nil {
+			t.Errorf("GetOrLoad() = %v, %v, 期望 1, nil", v, err)
+		}
+	})
+}
@@ -0,0 +1,367 @@
+package chanutils
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrClosed channel 已经关闭
+var ErrClosed = errors.New("chanutils: channel is closed")
+
+// send 将 v 发送到 out，ctx 先结束时放弃发送并返回 false
+func send[T any](ctx context.Context, out chan<- T, v T) bool {
+	select {
+	case out <- v:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Merge 将多个输入 channel 合并为一个输出 channel，值的顺序不保证
+// 所有输入都关闭或 ctx 取消后输出 channel 会被关闭
+func Merge[T any](ctx context.Context, chans ...<-chan T) <-chan T {
+	out := make(chan T)
+	var wg sync.WaitGroup
+	wg.Add(len(chans))
+	for _, ch := range chans {
+		go func(ch <-chan T) {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case v, ok := <-ch:
+					if !ok || !send(ctx, out, v) {
+						return
+					}
+				}
+			}
+		}(ch)
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// Tee 将 in 中的每个值复制到 n 个输出 channel，每个输出都会收到全部值
+// 每个值发送到所有输出后才会读取下一个值，因此最慢的消费者决定整体速度
+// in 关闭或 ctx 取消后所有输出 channel 都会被关闭；n <= 0 时按 1 处理
+func Tee[T any](ctx context.Context, in <-chan T, n int) []<-chan T {
+	n = max(n, 1)
+	outs := make([]chan T, n)
+	result := make([]<-chan T, n)
+	for i := range outs {
+		outs[i] = make(chan T)
+		result[i] = outs[i]
+	}
+	go func() {
+		defer func() {
+			for _, out := range outs {
+				close(out)
+			}
+		}()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-in:
+				if !ok {
+					return
+				}
+				for _, out := range outs {
+					if !send(ctx, out, v) {
+						return
+					}
+				}
+			}
+		}
+	}()
+	return result
+}
+
+// Split 按 predicate 将 in 中的值分流到两个 channel，满足条件的进入 matched，其余进入 rest
+// 两个输出需要同时被消费，否则会阻塞另一个输出；in 关闭或 ctx 取消后两个输出都会被关闭
+func Split[T any](ctx context.Context, in <-chan T, predicate func(T) bool) (matched, rest <-chan T) {
+	yes, no := make(chan T), make(chan T)
+	go func() {
+		defer close(yes)
+		defer close(no)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-in:
+				if !ok {
+					return
+				}
+				out := no
+				if predicate(v) {
+					out = yes
+				}
+				if !send(ctx, out, v) {
+					return
+				}
+			}
+		}
+	}()
+	return yes, no
+}
+
+// Batch 将 in 中的值按批次输出，批次达到 maxSize 个或距该批第一个值超过 maxWait 时发送
+// in 关闭时剩余的值作为最后一批发送；ctx 取消后未发送的值会被丢弃
+// maxSize <= 0 时按 1 处理，maxWait <= 0 时只按数量分批
+func Batch[T any](ctx context.Context, in <-chan T, maxSize int, maxWait time.Duration) <-chan []T {
+	maxSize = max(maxSize, 1)
+	out := make(chan []T)
+	go func() {
+		defer close(out)
+		var (
+			batch   []T
+			timer   *time.Timer
+			timeout <-chan time.Time
+		)
+		flush := func() bool {
+			if timer != nil {
+				timer.Stop()
+				timer, timeout = nil, nil
+			}
+			if len(batch) == 0 {
+				return true
+			}
+			b := batch
+			batch = nil
+			return send(ctx, out, b)
+		}
+		defer func() {
+			if timer != nil {
+				timer.Stop()
+			}
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timeout:
+				timer, timeout = nil, nil
+				if !flush() {
+					return
+				}
+			case v, ok := <-in:
+				if !ok {
+					flush()
+					return
+				}
+				batch = append(batch, v)
+				if len(batch) == 1 && maxWait > 0 {
+					timer = time.NewTimer(maxWait)
+					timeout = timer.C
+				}
+				if len(batch) >= maxSize && !flush() {
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// MapChan 对 in 中的每个值应用 fn 并发送到输出 channel，保持原有顺序
+// in 关闭或 ctx 取消后输出 channel 会被关闭
+func MapChan[T, R any](ctx context.Context, in <-chan T, fn func(T) R) <-chan R {
+	out := make(chan R)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-in:
+				if !ok || !send(ctx, out, fn(v)) {
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// FilterChan 只将 in 中满足 predicate 的值发送到输出 channel，保持原有顺序
+// in 关闭或 ctx 取消后输出 channel 会被关闭
+func FilterChan[T any](ctx context.Context, in <-chan T, predicate func(T) bool) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-in:
+				if !ok {
+					return
+				}
+				if predicate(v) && !send(ctx, out, v) {
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// OrDone 转发 in 中的值，ctx 取消时立即关闭输出 channel
+// 用于 range 一个可能永远不关闭的 channel，避免消费者在 ctx 取消后一直阻塞
+func OrDone[T any](ctx context.Context, in <-chan T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-in:
+				if !ok || !send(ctx, out, v) {
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// RecvTimeout 从 ch 接收一个值，最多等待 d
+// 超时或 ch 已关闭时返回零值和 false；计时器在返回时会被释放
+func RecvTimeout[T any](ch <-chan T, d time.Duration) (T, bool) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case v, ok := <-ch:
+		return v, ok
+	case <-timer.C:
+		var zero T
+		return zero, false
+	}
+}
+
+// RecvCtx 从 ch 接收一个值，直到 ctx 结束
+// ctx 先结束时返回 ctx.Err()，ch 已关闭时返回 ErrClosed
+func RecvCtx[T any](ctx context.Context, ch <-chan T) (T, error) {
+	select {
+	case v, ok := <-ch:
+		if !ok {
+			return v, ErrClosed
+		}
+		return v, nil
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// TrySend 尝试非阻塞地将 v 发送到 ch，发送成功返回 true，ch 已满或没有接收者时返回 false
+// 与普通发送一样，向已关闭的 ch 发送会 panic
+func TrySend[T any](ch chan<- T, v T) bool {
+	select {
+	case ch <- v:
+		return true
+	default:
+		return false
+	}
+}
+
+// ToChannel 将 slice 中的值依次发送到一个缓冲区大小为 buf 的 channel，发送完毕后关闭
+// ctx 取消后停止发送并关闭 channel；buf < 0 时按 0 处理
+func ToChannel[T any](ctx context.Context, slice []T, buf int) <-chan T {
+	out := make(chan T, max(buf, 0))
+	go func() {
+		defer close(out)
+		for _, v := range slice {
+			if !send(ctx, out, v) {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Collect 从 ch 读取值到切片中，直到 ch 关闭、ctx 结束或读取了 limit 个值
+// limit <= 0 表示不限制数量
+func Collect[T any](ctx context.Context, ch <-chan T, limit int) []T {
+	var result []T
+	for limit <= 0 || len(result) < limit {
+		select {
+		case <-ctx.Done():
+			return result
+		case v, ok := <-ch:
+			if !ok {
+				return result
+			}
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// Drain 读取并丢弃 ch 中剩余的值直到 ch 关闭，返回丢弃的数量
+// 用于关闭流程中让仍在发送的生产者退出；ch 永远不关闭时会一直阻塞，此时应使用 DrainCtx
+func Drain[T any](ch <-chan T) int {
+	n := 0
+	for range ch {
+		n++
+	}
+	return n
+}
+
+// DrainCtx 与 Drain 相同，但 ctx 结束时停止并返回 ctx.Err()
+func DrainCtx[T any](ctx context.Context, ch <-chan T) (int, error) {
+	n := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return n, ctx.Err()
+		case _, ok := <-ch:
+			if !ok {
+				return n, nil
+			}
+			n++
+		}
+	}
+}
+
+// DiscardUntilClosed 在后台 goroutine 中丢弃 ch 中的值直到 ch 关闭，调用立即返回
+// 适用于不再关心结果、但需要保证生产者不被阻塞的场景
+func DiscardUntilClosed[T any](ch <-chan T) {
+	go Drain(ch)
+}
+
+// Bridge 依次读取 chans 发来的每个 channel，按顺序将其中的值转发到一个输出 channel
+// 当前 channel 关闭后才会读取下一个；chans 关闭或 ctx 取消后输出 channel 会被关闭
+func Bridge[T any](ctx context.Context, chans <-chan <-chan T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for {
+			var ch <-chan T
+			select {
+			case <-ctx.Done():
+				return
+			case c, ok := <-chans:
+				if !ok {
+					return
+				}
+				ch = c
+			}
+			for v := range OrDone(ctx, ch) {
+				if !send(ctx, out, v) {
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
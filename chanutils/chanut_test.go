@@ -0,0 +1,430 @@
+package chanutils
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// fromValues 返回一个依次发送 values 后关闭的 channel
+func fromValues[T any](values ...T) <-chan T {
+	ch := make(chan T, len(values))
+	for _, v := range values {
+		ch <- v
+	}
+	close(ch)
+	return ch
+}
+
+// readAll 读取 ch 中的所有值直到关闭
+func readAll[T any](t *testing.T, ch <-chan T) []T {
+	t.Helper()
+	var got []T
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case v, ok := <-ch:
+			if !ok {
+				return got
+			}
+			got = append(got, v)
+		case <-timeout:
+			t.Error("channel 未在超时时间内关闭")
+			return got
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name  string
+		chans []<-chan int
+		want  []int
+	}{
+		{"多个输入", []<-chan int{fromValues(1, 2), fromValues(3), fromValues(4, 5)}, []int{1, 2, 3, 4, 5}},
+		{"包含空输入", []<-chan int{fromValues[int](), fromValues(1)}, []int{1}},
+		{"没有输入", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := readAll(t, Merge(context.Background(), tt.chans...))
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Merge() = %v, 期望 %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("取消后关闭", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		out := Merge(ctx, make(chan int))
+		cancel()
+		readAll(t, out)
+	})
+}
+
+func TestTee(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []int
+		n      int
+		outs   int
+	}{
+		{"复制到三个输出", []int{1, 2, 3}, 3, 3},
+		{"n 为 0", []int{1}, 0, 1},
+		{"空输入", nil, 2, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outs := Tee(context.Background(), fromValues(tt.values...), tt.n)
+			if len(outs) != tt.outs {
+				t.Fatalf("len(Tee()) = %v, 期望 %v", len(outs), tt.outs)
+			}
+			results := make([][]int, len(outs))
+			done := make(chan int)
+			for i, out := range outs {
+				go func() {
+					results[i] = readAll(t, out)
+					done <- i
+				}()
+			}
+			for range outs {
+				<-done
+			}
+			for i, got := range results {
+				if !slices.Equal(got, tt.values) {
+					t.Errorf("输出 %d = %v, 期望 %v", i, got, tt.values)
+				}
+			}
+		})
+	}
+}
+
+func TestSplit(t *testing.T) {
+	isEven := func(n int) bool { return n%2 == 0 }
+	matched, rest := Split(context.Background(), fromValues(1, 2, 3, 4, 5), isEven)
+
+	var evens, odds []int
+	done := make(chan struct{})
+	go func() {
+		odds = readAll(t, rest)
+		close(done)
+	}()
+	evens = readAll(t, matched)
+	<-done
+
+	if want := []int{2, 4}; !slices.Equal(evens, want) {
+		t.Errorf("matched = %v, 期望 %v", evens, want)
+	}
+	if want := []int{1, 3, 5}; !slices.Equal(odds, want) {
+		t.Errorf("rest = %v, 期望 %v", odds, want)
+	}
+}
+
+func TestBatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []int
+		maxSize int
+		want    [][]int
+	}{
+		{"按数量分批", []int{1, 2, 3, 4, 5}, 2, [][]int{{1, 2}, {3, 4}, {5}}},
+		{"正好整批", []int{1, 2, 3, 4}, 2, [][]int{{1, 2}, {3, 4}}},
+		{"maxSize 为 0", []int{1, 2}, 0, [][]int{{1}, {2}}},
+		{"空输入", nil, 3, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := readAll(t, Batch(context.Background(), fromValues(tt.values...), tt.maxSize, time.Hour))
+			if !slices.EqualFunc(got, tt.want, slices.Equal[[]int]) {
+				t.Errorf("Batch() = %v, 期望 %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("超时发送", func(t *testing.T) {
+		in := make(chan int)
+		out := Batch(context.Background(), in, 10, 20*time.Millisecond)
+		in <- 1
+		in <- 2
+		select {
+		case got := <-out:
+			if want := []int{1, 2}; !slices.Equal(got, want) {
+				t.Errorf("Batch() = %v, 期望 %v", got, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("超时后没有发送批次")
+		}
+		close(in)
+		readAll(t, out)
+	})
+}
+
+func TestMapChan(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []int
+		want   []string
+	}{
+		{"转换每个值", []int{1, 2, 3}, []string{"1", "2", "3"}},
+		{"空输入", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := readAll(t, MapChan(context.Background(), fromValues(tt.values...), strconv.Itoa))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("MapChan() = %v, 期望 %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterChan(t *testing.T) {
+	isEven := func(n int) bool { return n%2 == 0 }
+	tests := []struct {
+		name   string
+		values []int
+		want   []int
+	}{
+		{"过滤偶数", []int{1, 2, 3, 4}, []int{2, 4}},
+		{"没有匹配", []int{1, 3}, nil},
+		{"空输入", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := readAll(t, FilterChan(context.Background(), fromValues(tt.values...), isEven))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("FilterChan() = %v, 期望 %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrDone(t *testing.T) {
+	t.Run("转发所有值", func(t *testing.T) {
+		got := readAll(t, OrDone(context.Background(), fromValues(1, 2, 3)))
+		if want := []int{1, 2, 3}; !slices.Equal(got, want) {
+			t.Errorf("OrDone() = %v, 期望 %v", got, want)
+		}
+	})
+
+	t.Run("取消后停止", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		in := make(chan int)
+		out := OrDone(ctx, in)
+		go func() { in <- 1 }()
+		if v := <-out; v != 1 {
+			t.Errorf("OrDone() 收到 %v, 期望 %v", v, 1)
+		}
+		cancel()
+		if got := readAll(t, out); len(got) != 0 {
+			t.Errorf("取消后收到 %v, 期望没有值", got)
+		}
+	})
+}
+
+func TestRecvTimeout(t *testing.T) {
+	closed := make(chan int)
+	close(closed)
+	tests := []struct {
+		name   string
+		ch     <-chan int
+		want   int
+		wantOK bool
+	}{
+		{"有值", fromValues(7), 7, true},
+		{"超时", make(chan int), 0, false},
+		{"已关闭", closed, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := RecvTimeout(tt.ch, 10*time.Millisecond)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("RecvTimeout() = %v, %v, 期望 %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRecvCtx(t *testing.T) {
+	closed := make(chan int)
+	close(closed)
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		ch      <-chan int
+		want    int
+		wantErr error
+	}{
+		{"有值", context.Background(), fromValues(7), 7, nil},
+		{"已取消", cancelled, make(chan int), 0, context.Canceled},
+		{"已关闭", context.Background(), closed, 0, ErrClosed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RecvCtx(tt.ctx, tt.ch)
+			if got != tt.want || !errors.Is(err, tt.wantErr) {
+				t.Errorf("RecvCtx() = %v, %v, 期望 %v, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTrySend(t *testing.T) {
+	ch := make(chan int, 1)
+	tests := []struct {
+		name string
+		v    int
+		want bool
+	}{
+		{"缓冲区有空间", 1, true},
+		{"缓冲区已满", 2, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TrySend(ch, tt.v); got != tt.want {
+				t.Errorf("TrySend() = %v, 期望 %v", got, tt.want)
+			}
+		})
+	}
+	if v := <-ch; v != 1 {
+		t.Errorf("收到 %v, 期望 %v", v, 1)
+	}
+}
+
+func TestToChannel(t *testing.T) {
+	tests := []struct {
+		name  string
+		slice []int
+		buf   int
+	}{
+		{"无缓冲", []int{1, 2, 3}, 0},
+		{"有缓冲", []int{1, 2, 3}, 2},
+		{"负缓冲", []int{1}, -1},
+		{"空切片", nil, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := readAll(t, ToChannel(context.Background(), tt.slice, tt.buf))
+			if !slices.Equal(got, tt.slice) {
+				t.Errorf("ToChannel() = %v, 期望 %v", got, tt.slice)
+			}
+		})
+	}
+}
+
+func TestCollect(t *testing.T) {
+	tests := []struct {
+		name  string
+		ch    <-chan int
+		limit int
+		want  []int
+	}{
+		{"读取全部", fromValues(1, 2, 3), 0, []int{1, 2, 3}},
+		{"限制数量", fromValues(1, 2, 3), 2, []int{1, 2}},
+		{"数量超过可用值", fromValues(1), 5, []int{1}},
+		{"空 channel", fromValues[int](), 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Collect(context.Background(), tt.ch, tt.limit)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Collect() = %v, 期望 %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("ctx 结束", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if got := Collect(ctx, make(chan int), 0); len(got) != 0 {
+			t.Errorf("Collect() = %v, 期望空", got)
+		}
+	})
+}
+
+func TestDrain(t *testing.T) {
+	tests := []struct {
+		name string
+		ch   <-chan int
+		want int
+	}{
+		{"丢弃剩余值", fromValues(1, 2, 3), 3},
+		{"空 channel", fromValues[int](), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Drain(tt.ch); got != tt.want {
+				t.Errorf("Drain() = %v, 期望 %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDrainCtx(t *testing.T) {
+	n, err := DrainCtx(context.Background(), fromValues(1, 2))
+	if n != 2 || err != nil {
+		t.Errorf("DrainCtx() = %v, %v, 期望 %v, nil", n, err, 2)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	ch := make(chan int, 1)
+	ch <- 1
+	n, err = DrainCtx(ctx, ch)
+	if n != 1 || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("DrainCtx() = %v, %v, 期望 %v, %v", n, err, 1, context.DeadlineExceeded)
+	}
+}
+
+func TestDiscardUntilClosed(t *testing.T) {
+	ch := make(chan int)
+	DiscardUntilClosed(ch)
+	for i := range 3 {
+		select {
+		case ch <- i:
+		case <-time.After(time.Second):
+			t.Fatal("发送被阻塞")
+		}
+	}
+	close(ch)
+}
+
+func TestBridge(t *testing.T) {
+	tests := []struct {
+		name  string
+		pages [][]int
+		want  []int
+	}{
+		{"按顺序展开", [][]int{{1, 2}, {3}, {4, 5}}, []int{1, 2, 3, 4, 5}},
+		{"包含空 channel", [][]int{{}, {1}}, []int{1}},
+		{"没有 channel", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chans := make(chan (<-chan int), len(tt.pages))
+			for _, page := range tt.pages {
+				chans <- fromValues(page...)
+			}
+			close(chans)
+			got := readAll(t, Bridge(context.Background(), chans))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Bridge() = %v, 期望 %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("取消后关闭", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		chans := make(chan (<-chan int), 1)
+		chans <- make(chan int)
+		out := Bridge(ctx, chans)
+		cancel()
+		readAll(t, out)
+	})
+}
@@ -0,0 +1,143 @@
+package collections
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrQueueClosed 队列已关闭
+var ErrQueueClosed = errors.New("collections: queue is closed")
+
+// BlockingQueue 并发安全的有界阻塞队列，支持多生产者多消费者
+// 相比 channel，它可以查看队首元素和长度，并在生产者和消费者之间提供背压
+type BlockingQueue[T any] struct {
+	mu       sync.Mutex
+	items    Deque[T]
+	capacity int
+	closed   bool
+	notEmpty chan struct{} // 有新元素或关闭时关闭并重建，用于唤醒所有等待的消费者
+	notFull  chan struct{} // 有空位或关闭时关闭并重建，用于唤醒所有等待的生产者
+}
+
+// NewBlockingQueue 创建一个容量为 capacity 的阻塞队列，capacity <= 0 时按 1 处理
+func NewBlockingQueue[T any](capacity int) *BlockingQueue[T] {
+	return &BlockingQueue[T]{
+		capacity: max(capacity, 1),
+		notEmpty: make(chan struct{}),
+		notFull:  make(chan struct{}),
+	}
+}
+
+// Put 将 v 加入队尾，队列已满时阻塞直到有空位、ctx 结束或队列关闭
+// 队列已关闭时返回 ErrQueueClosed，ctx 结束时返回 ctx.Err()
+func (q *BlockingQueue[T]) Put(ctx context.Context, v T) error {
+	for {
+		q.mu.Lock()
+		if q.closed {
+			q.mu.Unlock()
+			return ErrQueueClosed
+		}
+		if q.items.Len() < q.capacity {
+			q.items.PushBack(v)
+			q.broadcast(&q.notEmpty)
+			q.mu.Unlock()
+			return nil
+		}
+		wait := q.notFull
+		q.mu.Unlock()
+
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// TryPut 尝试非阻塞地将 v 加入队尾，队列已满或已关闭时返回 false
+func (q *BlockingQueue[T]) TryPut(v T) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed || q.items.Len() >= q.capacity {
+		return false
+	}
+	q.items.PushBack(v)
+	q.broadcast(&q.notEmpty)
+	return true
+}
+
+// Take 移除并返回队首元素，队列为空时阻塞直到有元素、ctx 结束或队列关闭
+// 队列关闭后仍可取出剩余元素，取完后返回 ErrQueueClosed
+func (q *BlockingQueue[T]) Take(ctx context.Context) (T, error) {
+	for {
+		q.mu.Lock()
+		if v, ok := q.items.PopFront(); ok {
+			q.broadcast(&q.notFull)
+			q.mu.Unlock()
+			return v, nil
+		}
+		if q.closed {
+			q.mu.Unlock()
+			var zero T
+			return zero, ErrQueueClosed
+		}
+		wait := q.notEmpty
+		q.mu.Unlock()
+
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+}
+
+// TryTake 尝试非阻塞地移除并返回队首元素，队列为空时返回零值和 false
+func (q *BlockingQueue[T]) TryTake() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	v, ok := q.items.PopFront()
+	if ok {
+		q.broadcast(&q.notFull)
+	}
+	return v, ok
+}
+
+// Peek 返回队首元素但不移除，队列为空时返回零值和 false
+func (q *BlockingQueue[T]) Peek() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.items.Front()
+}
+
+// Len 返回队列中的元素数量
+func (q *BlockingQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.items.Len()
+}
+
+// Cap 返回队列的容量
+func (q *BlockingQueue[T]) Cap() int {
+	return q.capacity
+}
+
+// Close 关闭队列并唤醒所有等待者，之后的 Put 返回 ErrQueueClosed；重复调用是安全的
+func (q *BlockingQueue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return
+	}
+	q.closed = true
+	q.broadcast(&q.notEmpty)
+	q.broadcast(&q.notFull)
+}
+
+// broadcast 关闭 ch 唤醒所有等待者并替换为新的 channel，调用方需持有锁
+func (q *BlockingQueue[T]) broadcast(ch *chan struct{}) {
+	close(*ch)
+	*ch = make(chan struct{})
+}
@@ -0,0 +1,149 @@
+package collections
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBlockingQueueNonBlocking(t *testing.T) {
+	q := NewBlockingQueue[int](2)
+	tests := []struct {
+		name string
+		v    int
+		want bool
+	}{
+		{"第一个", 1, true},
+		{"第二个", 2, true},
+		{"已满", 3, false},
+	}
+	for _, tt := range tests {
+		if got := q.TryPut(tt.v); got != tt.want {
+			t.Errorf("%s: TryPut(%d) = %v, 期望 %v", tt.name, tt.v, got, tt.want)
+		}
+	}
+	if v, ok := q.Peek(); !ok || v != 1 {
+		t.Errorf("Peek() = %v, %v, 期望 1, true", v, ok)
+	}
+	if q.Len() != 2 || q.Cap() != 2 {
+		t.Errorf("Len() = %v, Cap() = %v, 期望 2, 2", q.Len(), q.Cap())
+	}
+	if v, ok := q.TryTake(); !ok || v != 1 {
+		t.Errorf("TryTake() = %v, %v, 期望 1, true", v, ok)
+	}
+	q.TryTake()
+	if _, ok := q.TryTake(); ok {
+		t.Error("空队列 TryTake() 返回 true")
+	}
+}
+
+func TestBlockingQueueBlocking(t *testing.T) {
+	t.Run("满时阻塞直到有空位", func(t *testing.T) {
+		q := NewBlockingQueue[int](1)
+		q.TryPut(1)
+		done := make(chan error)
+		go func() { done <- q.Put(context.Background(), 2) }()
+
+		select {
+		case <-done:
+			t.Fatal("队列已满时 Put() 没有阻塞")
+		case <-time.After(20 * time.Millisecond):
+		}
+		if v, _ := q.Take(context.Background()); v != 1 {
+			t.Errorf("Take() = %v, 期望 %v", v, 1)
+		}
+		if err := <-done; err != nil {
+			t.Errorf("Put() 错误 = %v", err)
+		}
+	})
+
+	t.Run("ctx 结束", func(t *testing.T) {
+		q := NewBlockingQueue[int](1)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if _, err := q.Take(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Take() 错误 = %v, 期望 %v", err, context.DeadlineExceeded)
+		}
+		q.TryPut(1)
+		if err := q.Put(ctx, 2); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Put() 错误 = %v, 期望 %v", err, context.DeadlineExceeded)
+		}
+	})
+
+	t.Run("关闭", func(t *testing.T) {
+		q := NewBlockingQueue[int](2)
+		q.TryPut(1)
+		waiting := make(chan error)
+		empty := NewBlockingQueue[int](1)
+		go func() {
+			_, err := empty.Take(context.Background())
+			waiting <- err
+		}()
+		time.Sleep(10 * time.Millisecond)
+		empty.Close()
+		if err := <-waiting; !errors.Is(err, ErrQueueClosed) {
+			t.Errorf("等待中的 Take() 错误 = %v, 期望 %v", err, ErrQueueClosed)
+		}
+
+		q.Close()
+		q.Close()
+		if err := q.Put(context.Background(), 2); !errors.Is(err, ErrQueueClosed) {
+			t.Errorf("Put() 错误 = %v, 期望 %v", err, ErrQueueClosed)
+		}
+		if v, err := q.Take(context.Background()); v != 1 || err != nil {
+			t.Errorf("关闭后 Take() = %v, %v, 期望取出剩余元素 1", v, err)
+		}
+		if _, err := q.Take(context.Background()); !errors.Is(err, ErrQueueClosed) {
+			t.Errorf("Take() 错误 = %v, 期望 %v", err, ErrQueueClosed)
+		}
+	})
+}
+
+func TestBlockingQueueMPMC(t *testing.T) {
+	q := NewBlockingQueue[int](4)
+	ctx := context.Background()
+	var producers, consumers sync.WaitGroup
+	var mu sync.Mutex
+	var got []int
+
+	for p := range 4 {
+		producers.Add(1)
+		go func() {
+			defer producers.Done()
+			for i := range 50 {
+				q.Put(ctx, p*50+i)
+			}
+		}()
+	}
+	for range 3 {
+		consumers.Add(1)
+		go func() {
+			defer consumers.Done()
+			for {
+				v, err := q.Take(ctx)
+				if err != nil {
+					return
+				}
+				mu.Lock()
+				got = append(got, v)
+				mu.Unlock()
+			}
+		}()
+	}
+	producers.Wait()
+	q.Close()
+	consumers.Wait()
+
+	slices.Sort(got)
+	if len(got) != 200 {
+		t.Fatalf("消费了 %v 个元素, 期望 %v", len(got), 200)
+	}
+	for i, v := range got {
+		if v != i {
+			t.Fatalf("元素 %v 丢失或重复", i)
+		}
+	}
+}
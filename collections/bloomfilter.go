@@ -0,0 +1,145 @@
+package collections
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/fnv"
+	"math"
+	"math/bits"
+)
+
+var (
+	// ErrBloomIncompatible 两个布隆过滤器的参数不同，无法合并
+	ErrBloomIncompatible = errors.New("collections: bloom filters have different parameters")
+	// ErrBloomInvalidData 反序列化的数据格式不正确
+	ErrBloomInvalidData = errors.New("collections: invalid bloom filter data")
+)
+
+// BloomFilter 布隆过滤器，用于在昂贵的成员判断之前做廉价的预过滤
+// MayContain 返回 false 时元素一定不存在，返回 true 时元素可能存在；非并发安全
+type BloomFilter struct {
+	bits []uint64
+	m    uint64 // 位数
+	k    uint64 // 哈希函数个数
+}
+
+// NewBloomFilter 根据预期元素数量和期望的误判率创建布隆过滤器
+// expectedItems 为 0 时按 1 处理，fpRate 不在 (0, 1) 范围内时按 0.01 处理
+func NewBloomFilter(expectedItems uint64, fpRate float64) *BloomFilter {
+	n := float64(max(expectedItems, 1))
+	if fpRate <= 0 || fpRate >= 1 {
+		fpRate = 0.01
+	}
+	m := uint64(math.Ceil(-n * math.Log(fpRate) / (math.Ln2 * math.Ln2)))
+	k := uint64(math.Round(float64(m) / n * math.Ln2))
+	return newBloomFilter(max(m, 1), max(k, 1))
+}
+
+// newBloomFilter 使用指定的位数和哈希函数个数创建布隆过滤器
+func newBloomFilter(m, k uint64) *BloomFilter {
+	return &BloomFilter{bits: make([]uint64, (m+63)/64), m: m, k: k}
+}
+
+// Add 添加一个元素
+func (f *BloomFilter) Add(data []byte) {
+	h1, h2 := bloomHash(data)
+	for i := range f.k {
+		pos := (h1 + i*h2) % f.m
+		f.bits[pos/64] |= 1 << (pos % 64)
+	}
+}
+
+// AddString 添加一个字符串元素
+func (f *BloomFilter) AddString(s string) {
+	f.Add([]byte(s))
+}
+
+// MayContain 判断元素是否可能存在，返回 false 时一定不存在
+func (f *BloomFilter) MayContain(data []byte) bool {
+	h1, h2 := bloomHash(data)
+	for i := range f.k {
+		pos := (h1 + i*h2) % f.m
+		if f.bits[pos/64]&(1<<(pos%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// MayContainString 判断字符串元素是否可能存在
+func (f *BloomFilter) MayContainString(s string) bool {
+	return f.MayContain([]byte(s))
+}
+
+// Merge 将 other 中的元素合并到 f，两者必须使用相同的参数创建，否则返回 ErrBloomIncompatible
+func (f *BloomFilter) Merge(other *BloomFilter) error {
+	if f.m != other.m || f.k != other.k {
+		return ErrBloomIncompatible
+	}
+	for i, w := range other.bits {
+		f.bits[i] |= w
+	}
+	return nil
+}
+
+// EstimatedFillRatio 返回已置位的比例，越接近 1 误判率越高
+func (f *BloomFilter) EstimatedFillRatio() float64 {
+	set := 0
+	for _, w := range f.bits {
+		set += bits.OnesCount64(w)
+	}
+	return float64(set) / float64(f.m)
+}
+
+// Clear 清空所有元素
+func (f *BloomFilter) Clear() {
+	clear(f.bits)
+}
+
+// MarshalBinary 实现 encoding.BinaryMarshaler，格式为 m、k 和位数组，均为小端序
+func (f *BloomFilter) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 16+8*len(f.bits))
+	binary.LittleEndian.PutUint64(buf[0:], f.m)
+	binary.LittleEndian.PutUint64(buf[8:], f.k)
+	for i, w := range f.bits {
+		binary.LittleEndian.PutUint64(buf[16+8*i:], w)
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary 实现 encoding.BinaryUnmarshaler，数据格式不正确时返回 ErrBloomInvalidData
+func (f *BloomFilter) UnmarshalBinary(data []byte) error {
+	if len(data) < 16 {
+		return ErrBloomInvalidData
+	}
+	m := binary.LittleEndian.Uint64(data[0:])
+	k := binary.LittleEndian.Uint64(data[8:])
+	// 以不会溢出的方式计算字数并比较长度，m 来自不可信的输入
+	words := m / 64
+	if m%64 != 0 {
+		words++
+	}
+	n := len(data) - 16
+	// k 超过 m 没有意义，并且会让 Add 和 MayContain 执行任意多次循环
+	if m == 0 || k == 0 || k > m || n%8 != 0 || uint64(n/8) != words {
+		return ErrBloomInvalidData
+	}
+	nf := newBloomFilter(m, k)
+	for i := range nf.bits {
+		nf.bits[i] = binary.LittleEndian.Uint64(data[16+8*i:])
+	}
+	*f = *nf
+	return nil
+}
+
+// bloomHash 计算两个独立的哈希值，用于双重哈希生成 k 个位置
+func bloomHash(data []byte) (uint64, uint64) {
+	h := fnv.New64a()
+	h.Write(data)
+	h1 := h.Sum64()
+	h2 := bits.RotateLeft64(h1, 32) ^ 0x9e3779b97f4a7c15
+	h2 ^= h2 >> 29
+	h2 *= 0xbf58476d1ce4e5b9
+	h2 ^= h2 >> 32
+	return h1, h2 | 1
+}
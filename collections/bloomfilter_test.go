@@ -0,0 +1,135 @@
+package collections
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestBloomFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		n      uint64
+		fpRate float64
+	}{
+		{"百分之一误判率", 1000, 0.01},
+		{"千分之一误判率", 1000, 0.001},
+		{"非法参数", 0, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewBloomFilter(tt.n, tt.fpRate)
+			n := int(max(tt.n, 1))
+			for i := range n {
+				f.AddString("item-" + strconv.Itoa(i))
+			}
+			for i := range n {
+				if !f.MayContainString("item-" + strconv.Itoa(i)) {
+					t.Fatalf("MayContain(item-%d) = false, 已添加的元素不能漏判", i)
+				}
+			}
+
+			rate := tt.fpRate
+			if rate <= 0 || rate >= 1 {
+				rate = 0.01
+			}
+			falsePositives := 0
+			const probes = 10000
+			for i := range probes {
+				if f.MayContainString("other-" + strconv.Itoa(i)) {
+					falsePositives++
+				}
+			}
+			if got := float64(falsePositives) / probes; got > rate*3 {
+				t.Errorf("误判率 = %v, 期望不超过 %v", got, rate*3)
+			}
+		})
+	}
+}
+
+func TestBloomFilterMerge(t *testing.T) {
+	a := NewBloomFilter(100, 0.01)
+	b := NewBloomFilter(100, 0.01)
+	a.AddString("a")
+	b.AddString("b")
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("Merge() 错误 = %v", err)
+	}
+	if !a.MayContainString("a") || !a.MayContainString("b") {
+		t.Error("合并后缺少元素")
+	}
+	if err := a.Merge(NewBloomFilter(10, 0.1)); !errors.Is(err, ErrBloomIncompatible) {
+		t.Errorf("Merge() 错误 = %v, 期望 %v", err, ErrBloomIncompatible)
+	}
+	a.Clear()
+	if a.MayContainString("a") || a.EstimatedFillRatio() != 0 {
+		t.Error("Clear() 后仍包含元素")
+	}
+}
+
+func TestBloomFilterBinary(t *testing.T) {
+	f := NewBloomFilter(100, 0.01)
+	f.AddString("hello")
+	data, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() 错误 = %v", err)
+	}
+
+	var g BloomFilter
+	if err := g.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() 错误 = %v", err)
+	}
+	if !g.MayContainString("hello") || g.MayContainString("world") && !f.MayContainString("world") {
+		t.Error("反序列化后的结果与原过滤器不一致")
+	}
+	if err := g.Merge(f); err != nil {
+		t.Errorf("反序列化后的过滤器应与原过滤器兼容, Merge() 错误 = %v", err)
+	}
+
+	invalid := []struct {
+		name string
+		data []byte
+	}{
+		{"数据过短", []byte{1, 2, 3}},
+		{"长度不匹配", data[:len(data)-8]},
+		{"参数为 0", make([]byte, 16)},
+		{"m 接近上限时不能溢出", append(bytes.Repeat([]byte{0xff}, 8), 1, 0, 0, 0, 0, 0, 0, 0)},
+		{"m 为 2^64-63", append([]byte{0xc1, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, 1, 0, 0, 0, 0, 0, 0, 0)},
+		{"k 超过 m", append([]byte{64, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0}, make([]byte, 8)...)},
+		{"位数组长度不是 8 的倍数", append(data[:16:16], make([]byte, len(data)-16+4)...)},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := g.UnmarshalBinary(tt.data); !errors.Is(err, ErrBloomInvalidData) {
+				t.Errorf("UnmarshalBinary() 错误 = %v, 期望 %v", err, ErrBloomInvalidData)
+			}
+		})
+	}
+}
+
+func FuzzBloomFilterUnmarshalBinary(f *testing.F) {
+	valid, _ := NewBloomFilter(10, 0.1).MarshalBinary()
+	f.Add(valid)
+	f.Add(append(bytes.Repeat([]byte{0xff}, 8), 1, 0, 0, 0, 0, 0, 0, 0))
+	f.Add(make([]byte, 16))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var g BloomFilter
+		if err := g.UnmarshalBinary(data); err != nil {
+			return
+		}
+		if g.k > 64 {
+			// 合法但 k 过大的输入只会让测试变慢
+			return
+		}
+		// 成功解析的过滤器必须可以安全使用，并且能原样序列化
+		g.AddString("fuzz")
+		if !g.MayContainString("fuzz") {
+			t.Errorf("MayContainString() = false, 期望 true")
+		}
+		out, _ := g.MarshalBinary()
+		if len(out) != len(data) {
+			t.Errorf("MarshalBinary() 长度 = %v, 期望 %v", len(out), len(data))
+		}
+	})
+}
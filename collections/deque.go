@@ -0,0 +1,127 @@
+package collections
+
+// minDequeCap 环形缓冲区的最小容量
+const minDequeCap = 8
+
+// Deque 双端队列，基于可自动扩缩容的环形缓冲区实现，两端的插入和删除均为均摊 O(1)
+// 移除的元素会被清零以便回收；零值可直接使用，非并发安全
+type Deque[T any] struct {
+	buf  []T
+	head int
+	size int
+}
+
+// NewDeque 创建一个初始容量至少为 capacity 的双端队列
+func NewDeque[T any](capacity int) *Deque[T] {
+	return &Deque[T]{buf: make([]T, max(capacity, minDequeCap))}
+}
+
+// PushFront 将 v 插入队首
+func (d *Deque[T]) PushFront(v T) {
+	d.grow()
+	d.head = (d.head - 1 + len(d.buf)) % len(d.buf)
+	d.buf[d.head] = v
+	d.size++
+}
+
+// PushBack 将 v 插入队尾
+func (d *Deque[T]) PushBack(v T) {
+	d.grow()
+	d.buf[(d.head+d.size)%len(d.buf)] = v
+	d.size++
+}
+
+// PopFront 移除并返回队首元素，队列为空时返回零值和 false
+func (d *Deque[T]) PopFront() (T, bool) {
+	var zero T
+	if d.size == 0 {
+		return zero, false
+	}
+	v := d.buf[d.head]
+	d.buf[d.head] = zero
+	d.head = (d.head + 1) % len(d.buf)
+	d.size--
+	d.shrink()
+	return v, true
+}
+
+// PopBack 移除并返回队尾元素，队列为空时返回零值和 false
+func (d *Deque[T]) PopBack() (T, bool) {
+	var zero T
+	if d.size == 0 {
+		return zero, false
+	}
+	i := (d.head + d.size - 1) % len(d.buf)
+	v := d.buf[i]
+	d.buf[i] = zero
+	d.size--
+	d.shrink()
+	return v, true
+}
+
+// Front 返回队首元素但不移除，队列为空时返回零值和 false
+func (d *Deque[T]) Front() (T, bool) {
+	if d.size == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.buf[d.head], true
+}
+
+// Back 返回队尾元素但不移除，队列为空时返回零值和 false
+func (d *Deque[T]) Back() (T, bool) {
+	if d.size == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.buf[(d.head+d.size-1)%len(d.buf)], true
+}
+
+// At 返回从队首开始第 i 个元素，i 越界时 panic
+func (d *Deque[T]) At(i int) T {
+	if i < 0 || i >= d.size {
+		panic("collections: deque index out of range")
+	}
+	return d.buf[(d.head+i)%len(d.buf)]
+}
+
+// Len 返回队列中的元素数量
+func (d *Deque[T]) Len() int {
+	return d.size
+}
+
+// Clear 移除所有元素
+func (d *Deque[T]) Clear() {
+	d.buf = nil
+	d.head = 0
+	d.size = 0
+}
+
+// grow 缓冲区已满时扩容为两倍
+func (d *Deque[T]) grow() {
+	if d.size == len(d.buf) {
+		d.resize(max(len(d.buf)*2, minDequeCap))
+	}
+}
+
+// shrink 元素数量降到容量的四分之一以下时缩容一半
+func (d *Deque[T]) shrink() {
+	if len(d.buf) > minDequeCap && d.size <= len(d.buf)/4 {
+		d.resize(len(d.buf) / 2)
+	}
+}
+
+// resize 将元素按顺序复制到容量为 n 的新缓冲区
+func (d *Deque[T]) resize(n int) {
+	buf := make([]T, n)
+	if d.size > 0 {
+		if d.head+d.size <= len(d.buf) {
+			copy(buf, d.buf[d.head:d.head+d.size])
+		} else {
+			k := copy(buf, d.buf[d.head:])
+			copy(buf[k:], d.buf[:d.size-k])
+		}
+	}
+	d.buf = buf
+	d.head = 0
+}
@@ -0,0 +1,97 @@
+package collections
+
+import (
+	"slices"
+	"testing"
+)
+
+// dequeValues 按顺序返回 d 中的所有元素
+func dequeValues[T any](d *Deque[T]) []T {
+	var values []T
+	for i := range d.Len() {
+		values = append(values, d.At(i))
+	}
+	return values
+}
+
+func TestDeque(t *testing.T) {
+	tests := []struct {
+		name string
+		ops  func(d *Deque[int])
+		want []int
+	}{
+		{"队尾插入", func(d *Deque[int]) {
+			d.PushBack(1)
+			d.PushBack(2)
+		}, []int{1, 2}},
+		{"队首插入", func(d *Deque[int]) {
+			d.PushFront(1)
+			d.PushFront(2)
+		}, []int{2, 1}},
+		{"两端混合", func(d *Deque[int]) {
+			for i := range 20 {
+				d.PushBack(i)
+				d.PushFront(-i)
+			}
+			for range 19 {
+				d.PopFront()
+				d.PopBack()
+			}
+		}, []int{0, 0}},
+		{"弹出到空", func(d *Deque[int]) {
+			d.PushBack(1)
+			d.PopBack()
+			d.PopFront()
+		}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d Deque[int]
+			tt.ops(&d)
+			if got := dequeValues(&d); !slices.Equal(got, tt.want) {
+				t.Errorf("Deque = %v, 期望 %v", got, tt.want)
+			}
+			if d.Len() != len(tt.want) {
+				t.Errorf("Len() = %v, 期望 %v", d.Len(), len(tt.want))
+			}
+		})
+	}
+
+	t.Run("两端读取", func(t *testing.T) {
+		d := NewDeque[string](0)
+		if _, ok := d.Front(); ok {
+			t.Error("空队列 Front() 返回 true")
+		}
+		if _, ok := d.PopBack(); ok {
+			t.Error("空队列 PopBack() 返回 true")
+		}
+		d.PushBack("b")
+		d.PushFront("a")
+		d.PushBack("c")
+		if v, _ := d.Front(); v != "a" {
+			t.Errorf("Front() = %v, 期望 %v", v, "a")
+		}
+		if v, _ := d.Back(); v != "c" {
+			t.Errorf("Back() = %v, 期望 %v", v, "c")
+		}
+		if v, _ := d.PopBack(); v != "c" {
+			t.Errorf("PopBack() = %v, 期望 %v", v, "c")
+		}
+		if v, _ := d.PopFront(); v != "a" {
+			t.Errorf("PopFront() = %v, 期望 %v", v, "a")
+		}
+		d.Clear()
+		if d.Len() != 0 {
+			t.Errorf("Clear() 后 Len() = %v, 期望 0", d.Len())
+		}
+	})
+
+	t.Run("At 越界", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("At() 未 panic")
+			}
+		}()
+		NewDeque[int](0).At(0)
+	})
+}
@@ -0,0 +1,92 @@
+package collections
+
+// DisjointSet 并查集，用于连通性判断和聚类，例如对相互关联的记录去重
+// Find 使用路径压缩，Union 按秩合并，均摊复杂度接近 O(1)；元素在首次使用时自动加入；零值可直接使用，非并发安全
+type DisjointSet[T comparable] struct {
+	parent map[T]T
+	rank   map[T]int
+	sets   int
+}
+
+// NewDisjointSet 创建一个包含 elems 的并查集，每个元素自成一个集合
+func NewDisjointSet[T comparable](elems ...T) *DisjointSet[T] {
+	d := &DisjointSet[T]{}
+	for _, e := range elems {
+		d.Add(e)
+	}
+	return d
+}
+
+// Add 添加元素 x 作为单独的集合，x 已存在时返回 false
+func (d *DisjointSet[T]) Add(x T) bool {
+	if d.parent == nil {
+		d.parent = make(map[T]T)
+		d.rank = make(map[T]int)
+	}
+	if _, ok := d.parent[x]; ok {
+		return false
+	}
+	d.parent[x] = x
+	d.sets++
+	return true
+}
+
+// Find 返回 x 所在集合的代表元素，x 不存在时会先将其加入
+func (d *DisjointSet[T]) Find(x T) T {
+	d.Add(x)
+	root := x
+	for d.parent[root] != root {
+		root = d.parent[root]
+	}
+	// 路径压缩
+	for x != root {
+		next := d.parent[x]
+		d.parent[x] = root
+		x = next
+	}
+	return root
+}
+
+// Union 合并 x 和 y 所在的集合，两者原本不在同一集合时返回 true
+func (d *DisjointSet[T]) Union(x, y T) bool {
+	rx, ry := d.Find(x), d.Find(y)
+	if rx == ry {
+		return false
+	}
+	switch {
+	case d.rank[rx] < d.rank[ry]:
+		d.parent[rx] = ry
+	case d.rank[rx] > d.rank[ry]:
+		d.parent[ry] = rx
+	default:
+		d.parent[ry] = rx
+		d.rank[rx]++
+	}
+	d.sets--
+	return true
+}
+
+// SameSet 判断 x 和 y 是否在同一个集合中
+func (d *DisjointSet[T]) SameSet(x, y T) bool {
+	return d.Find(x) == d.Find(y)
+}
+
+// Len 返回元素数量
+func (d *DisjointSet[T]) Len() int {
+	return len(d.parent)
+}
+
+// Sets 返回集合数量
+func (d *DisjointSet[T]) Sets() int {
+	return d.sets
+}
+
+// Groups 返回所有集合，键为集合的代表元素，值为该集合中的所有元素（顺序不保证）
+func (d *DisjointSet[T]) Groups() map[T][]T {
+	groups := make(map[T][]T, d.sets)
+	for x := range d.parent {
+		root := d.Find(x)
+		groups[root] = append(groups[root], x)
+	}
+	return groups
+}
@@ -0,0 +1,70 @@
+package collections
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDisjointSet(t *testing.T) {
+	tests := []struct {
+		name      string
+		elems     []int
+		unions    [][2]int
+		same      [][2]int
+		different [][2]int
+		wantSets  int
+	}{
+		{"没有合并", []int{1, 2, 3}, nil, nil, [][2]int{{1, 2}}, 3},
+		{"链式合并", []int{1, 2, 3, 4}, [][2]int{{1, 2}, {2, 3}}, [][2]int{{1, 3}}, [][2]int{{1, 4}}, 2},
+		{"重复合并", []int{1, 2}, [][2]int{{1, 2}, {2, 1}}, [][2]int{{1, 2}}, nil, 1},
+		{"自动加入", nil, [][2]int{{5, 6}}, [][2]int{{5, 6}}, [][2]int{{5, 7}}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDisjointSet(tt.elems...)
+			for _, u := range tt.unions {
+				d.Union(u[0], u[1])
+			}
+			for _, p := range tt.same {
+				if !d.SameSet(p[0], p[1]) {
+					t.Errorf("SameSet(%d, %d) = false, 期望 true", p[0], p[1])
+				}
+			}
+			for _, p := range tt.different {
+				if d.SameSet(p[0], p[1]) {
+					t.Errorf("SameSet(%d, %d) = true, 期望 false", p[0], p[1])
+				}
+			}
+			if d.Sets() != tt.wantSets {
+				t.Errorf("Sets() = %v, 期望 %v", d.Sets(), tt.wantSets)
+			}
+		})
+	}
+}
+
+func TestDisjointSetGroups(t *testing.T) {
+	var d DisjointSet[string]
+	if !d.Union("a", "b") || d.Union("b", "a") {
+		t.Error("Union() 结果错误")
+	}
+	d.Union("c", "d")
+	d.Union("d", "e")
+	d.Add("f")
+
+	var groups [][]string
+	for root, members := range d.Groups() {
+		if d.Find(root) != root {
+			t.Errorf("Groups() 的键 %q 不是代表元素", root)
+		}
+		slices.Sort(members)
+		groups = append(groups, members)
+	}
+	slices.SortFunc(groups, func(a, b []string) int { return slices.Compare(a, b) })
+	want := [][]string{{"a", "b"}, {"c", "d", "e"}, {"f"}}
+	if !slices.EqualFunc(groups, want, slices.Equal[[]string]) {
+		t.Errorf("Groups() = %v, 期望 %v", groups, want)
+	}
+	if d.Len() != 6 {
+		t.Errorf("Len() = %v, 期望 %v", d.Len(), 6)
+	}
+}
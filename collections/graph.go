@@ -0,0 +1,199 @@
+package collections
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrCycle 图中存在环，无法进行拓扑排序
+var ErrCycle = errors.New("collections: graph has a cycle")
+
+// CycleError 描述拓扑排序时发现的环，errors.Is(err, ErrCycle) 为 true
+type CycleError[T comparable] struct {
+	// Path 环上的节点，首尾为同一个节点，例如 [a b c a]
+	Path []T
+}
+
+// Error 实现 error 接口
+func (e *CycleError[T]) Error() string {
+	parts := make([]string, len(e.Path))
+	for i, n := range e.Path {
+		parts[i] = fmt.Sprint(n)
+	}
+	return fmt.Sprintf("%v: %s", ErrCycle, strings.Join(parts, " -> "))
+}
+
+// Unwrap 返回 ErrCycle
+func (e *CycleError[T]) Unwrap() error {
+	return ErrCycle
+}
+
+// Graph 有向图，适用于迁移、任务 DAG、配置引用等依赖排序场景
+// 节点和边按添加顺序保存，因此遍历和排序结果是确定的；零值可直接使用，非并发安全
+type Graph[T comparable] struct {
+	nodes []T
+	edges map[T][]T
+	index map[T]map[T]struct{} // 用于边去重
+}
+
+// NewGraph 创建一个空的有向图
+func NewGraph[T comparable]() *Graph[T] {
+	return &Graph[T]{}
+}
+
+// AddNode 添加节点，节点已存在时返回 false
+func (g *Graph[T]) AddNode(n T) bool {
+	if g.edges == nil {
+		g.edges = make(map[T][]T)
+		g.index = make(map[T]map[T]struct{})
+	}
+	if _, ok := g.edges[n]; ok {
+		return false
+	}
+	g.nodes = append(g.nodes, n)
+	g.edges[n] = nil
+	g.index[n] = make(map[T]struct{})
+	return true
+}
+
+// AddEdge 添加从 from 指向 to 的边，节点不存在时自动添加；边已存在时返回 false
+// 在依赖场景中，from 依赖 to 时可以添加 to -> from，使拓扑排序中 to 排在前面
+func (g *Graph[T]) AddEdge(from, to T) bool {
+	g.AddNode(from)
+	g.AddNode(to)
+	if _, ok := g.index[from][to]; ok {
+		return false
+	}
+	g.index[from][to] = struct{}{}
+	g.edges[from] = append(g.edges[from], to)
+	return true
+}
+
+// HasNode 判断节点是否存在
+func (g *Graph[T]) HasNode(n T) bool {
+	_, ok := g.edges[n]
+	return ok
+}
+
+// HasEdge 判断从 from 指向 to 的边是否存在
+func (g *Graph[T]) HasEdge(from, to T) bool {
+	_, ok := g.index[from][to]
+	return ok
+}
+
+// Nodes 按添加顺序返回所有节点
+func (g *Graph[T]) Nodes() []T {
+	return append([]T(nil), g.nodes...)
+}
+
+// Neighbors 按添加顺序返回 n 指向的所有节点
+func (g *Graph[T]) Neighbors(n T) []T {
+	return append([]T(nil), g.edges[n]...)
+}
+
+// TopoSort 返回拓扑排序结果，每条边的起点都排在终点之前
+// 存在环时返回 *CycleError，其中包含环上的节点路径
+func (g *Graph[T]) TopoSort() ([]T, error) {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[T]int, len(g.nodes))
+	order := make([]T, 0, len(g.nodes))
+	var stack []T
+
+	var visit func(n T) error
+	visit = func(n T) error {
+		state[n] = visiting
+		stack = append(stack, n)
+		for _, next := range g.edges[n] {
+			switch state[next] {
+			case visiting:
+				// 从栈中找到环的起点
+				start := len(stack) - 1
+				for stack[start] != next {
+					start--
+				}
+				path := append(append([]T(nil), stack[start:]...), next)
+				return &CycleError[T]{Path: path}
+			case unvisited:
+				if err := visit(next); err != nil {
+					return err
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[n] = done
+		order = append(order, n)
+		return nil
+	}
+
+	for _, n := range g.nodes {
+		if state[n] == unvisited {
+			if err := visit(n); err != nil {
+				return nil, err
+			}
+		}
+	}
+	// 后序遍历的逆序即为拓扑序
+	for i, j := 0, len(order)-1; i < j; i, j = i+1, j-1 {
+		order[i], order[j] = order[j], order[i]
+	}
+	return order, nil
+}
+
+// DFS 从 start 开始深度优先遍历可达的节点，fn 返回 false 时停止遍历
+func (g *Graph[T]) DFS(start T, fn func(n T) bool) {
+	if !g.HasNode(start) {
+		return
+	}
+	visited := map[T]bool{}
+	var visit func(n T) bool
+	visit = func(n T) bool {
+		visited[n] = true
+		if !fn(n) {
+			return false
+		}
+		for _, next := range g.edges[n] {
+			if !visited[next] && !visit(next) {
+				return false
+			}
+		}
+		return true
+	}
+	visit(start)
+}
+
+// BFS 从 start 开始广度优先遍历可达的节点，fn 返回 false 时停止遍历
+func (g *Graph[T]) BFS(start T, fn func(n T) bool) {
+	if !g.HasNode(start) {
+		return
+	}
+	visited := map[T]bool{start: true}
+	var queue Queue[T]
+	queue.Enqueue(start)
+	for queue.Len() > 0 {
+		n, _ := queue.Dequeue()
+		if !fn(n) {
+			return
+		}
+		for _, next := range g.edges[n] {
+			if !visited[next] {
+				visited[next] = true
+				queue.Enqueue(next)
+			}
+		}
+	}
+}
+
+// Reachable 判断是否存在从 from 到 to 的路径，节点可以到达自身
+func (g *Graph[T]) Reachable(from, to T) bool {
+	found := false
+	g.BFS(from, func(n T) bool {
+		found = n == to
+		return !found
+	})
+	return found
+}
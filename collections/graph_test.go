@@ -0,0 +1,117 @@
+package collections
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// newTestGraph 根据边列表创建图
+func newTestGraph(edges [][2]string) *Graph[string] {
+	g := NewGraph[string]()
+	for _, e := range edges {
+		g.AddEdge(e[0], e[1])
+	}
+	return g
+}
+
+func TestGraphTopoSort(t *testing.T) {
+	tests := []struct {
+		name      string
+		edges     [][2]string
+		wantCycle []string
+	}{
+		{"链", [][2]string{{"a", "b"}, {"b", "c"}}, nil},
+		{"菱形", [][2]string{{"a", "b"}, {"a", "c"}, {"b", "d"}, {"c", "d"}}, nil},
+		{"多个连通分量", [][2]string{{"x", "y"}, {"a", "b"}}, nil},
+		{"环", [][2]string{{"a", "b"}, {"b", "c"}, {"c", "a"}}, []string{"a", "b", "c", "a"}},
+		{"自环", [][2]string{{"a", "a"}}, []string{"a", "a"}},
+		{"环在后面", [][2]string{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"d", "b"}}, []string{"b", "c", "d", "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGraph(tt.edges)
+			order, err := g.TopoSort()
+			if tt.wantCycle != nil {
+				var cycleErr *CycleError[string]
+				if !errors.As(err, &cycleErr) || !errors.Is(err, ErrCycle) {
+					t.Fatalf("TopoSort() 错误 = %v, 期望 CycleError", err)
+				}
+				if !slices.Equal(cycleErr.Path, tt.wantCycle) {
+					t.Errorf("环路径 = %v, 期望 %v", cycleErr.Path, tt.wantCycle)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("TopoSort() 错误 = %v", err)
+			}
+			if len(order) != len(g.Nodes()) {
+				t.Fatalf("TopoSort() = %v, 缺少节点", order)
+			}
+			pos := map[string]int{}
+			for i, n := range order {
+				pos[n] = i
+			}
+			for _, e := range tt.edges {
+				if pos[e[0]] >= pos[e[1]] {
+					t.Errorf("TopoSort() = %v, %s 应在 %s 之前", order, e[0], e[1])
+				}
+			}
+		})
+	}
+
+	err := &CycleError[string]{Path: []string{"a", "b", "a"}}
+	if want := "collections: graph has a cycle: a -> b -> a"; err.Error() != want {
+		t.Errorf("Error() = %q, 期望 %q", err.Error(), want)
+	}
+}
+
+func TestGraphTraversal(t *testing.T) {
+	g := newTestGraph([][2]string{{"a", "b"}, {"a", "c"}, {"b", "d"}, {"c", "d"}, {"e", "a"}})
+	if g.AddEdge("a", "b") {
+		t.Error("重复的 AddEdge() = true, 期望 false")
+	}
+
+	tests := []struct {
+		name string
+		walk func(string, func(string) bool)
+		want []string
+	}{
+		{"DFS", g.DFS, []string{"a", "b", "d", "c"}},
+		{"BFS", g.BFS, []string{"a", "b", "c", "d"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			tt.walk("a", func(n string) bool {
+				got = append(got, n)
+				return true
+			})
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("%s() = %v, 期望 %v", tt.name, got, tt.want)
+			}
+		})
+	}
+
+	reach := []struct {
+		from, to string
+		want     bool
+	}{
+		{"a", "d", true},
+		{"e", "d", true},
+		{"d", "a", false},
+		{"a", "a", true},
+		{"a", "missing", false},
+	}
+	for _, tt := range reach {
+		if got := g.Reachable(tt.from, tt.to); got != tt.want {
+			t.Errorf("Reachable(%q, %q) = %v, 期望 %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+	if !g.HasEdge("e", "a") || g.HasEdge("a", "e") {
+		t.Error("HasEdge() 结果错误")
+	}
+	if got := g.Neighbors("a"); !slices.Equal(got, []string{"b", "c"}) {
+		t.Errorf("Neighbors(a) = %v, 期望 %v", got, []string{"b", "c"})
+	}
+}
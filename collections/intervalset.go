@@ -0,0 +1,138 @@
+package collections
+
+import (
+	"cmp"
+	"sort"
+)
+
+// Interval 左闭右开区间 [Start, End)，Start >= End 时为空区间
+type Interval[T cmp.Ordered] struct {
+	Start T
+	End   T
+}
+
+// Empty 判断区间是否为空
+func (iv Interval[T]) Empty() bool {
+	return iv.Start >= iv.End
+}
+
+// Contains 判断 point 是否在区间内
+func (iv Interval[T]) Contains(point T) bool {
+	return iv.Start <= point && point < iv.End
+}
+
+// Overlaps 判断两个区间是否有交集
+func (iv Interval[T]) Overlaps(other Interval[T]) bool {
+	return !iv.Empty() && !other.Empty() && iv.Start < other.End && other.Start < iv.End
+}
+
+// IntervalSet 区间集合，自动合并重叠和相邻的区间，适用于 IP 段判断、维护窗口和日程空闲时间计算
+// 区间按起点有序保存，查询为 O(log n)；零值可直接使用，非并发安全
+type IntervalSet[T cmp.Ordered] struct {
+	intervals []Interval[T] // 有序且互不重叠、互不相邻
+}
+
+// NewIntervalSet 创建一个包含 intervals 的区间集合
+func NewIntervalSet[T cmp.Ordered](intervals ...Interval[T]) *IntervalSet[T] {
+	s := &IntervalSet[T]{}
+	for _, iv := range intervals {
+		s.Add(iv)
+	}
+	return s
+}
+
+// Add 添加区间，与已有区间重叠或相邻时合并；空区间会被忽略
+func (s *IntervalSet[T]) Add(iv Interval[T]) {
+	if iv.Empty() {
+		return
+	}
+	// 第一个 End >= iv.Start 的区间（可能与 iv 重叠或相邻）
+	lo := sort.Search(len(s.intervals), func(i int) bool { return s.intervals[i].End >= iv.Start })
+	// 第一个 Start > iv.End 的区间（不受影响）
+	hi := sort.Search(len(s.intervals), func(i int) bool { return s.intervals[i].Start > iv.End })
+	if lo < hi {
+		iv.Start = min(iv.Start, s.intervals[lo].Start)
+		iv.End = max(iv.End, s.intervals[hi-1].End)
+	}
+	s.intervals = append(s.intervals[:lo], append([]Interval[T]{iv}, s.intervals[hi:]...)...)
+}
+
+// Remove 从集合中移除区间 iv 覆盖的部分，可能会把已有区间拆成两段
+func (s *IntervalSet[T]) Remove(iv Interval[T]) {
+	if iv.Empty() {
+		return
+	}
+	var result []Interval[T]
+	for _, cur := range s.intervals {
+		if !cur.Overlaps(iv) {
+			result = append(result, cur)
+			continue
+		}
+		if cur.Start < iv.Start {
+			result = append(result, Interval[T]{cur.Start, iv.Start})
+		}
+		if iv.End < cur.End {
+			result = append(result, Interval[T]{iv.End, cur.End})
+		}
+	}
+	s.intervals = result
+}
+
+// Contains 判断 point 是否在集合的某个区间内
+func (s *IntervalSet[T]) Contains(point T) bool {
+	i := sort.Search(len(s.intervals), func(i int) bool { return s.intervals[i].End > point })
+	return i < len(s.intervals) && s.intervals[i].Contains(point)
+}
+
+// Overlaps 判断 iv 是否与集合中的任意区间有交集
+func (s *IntervalSet[T]) Overlaps(iv Interval[T]) bool {
+	if iv.Empty() {
+		return false
+	}
+	i := sort.Search(len(s.intervals), func(i int) bool { return s.intervals[i].End > iv.Start })
+	return i < len(s.intervals) && s.intervals[i].Overlaps(iv)
+}
+
+// Covers 判断 iv 是否完全被集合中的某个区间覆盖
+func (s *IntervalSet[T]) Covers(iv Interval[T]) bool {
+	if iv.Empty() {
+		return true
+	}
+	i := sort.Search(len(s.intervals), func(i int) bool { return s.intervals[i].End > iv.Start })
+	return i < len(s.intervals) && s.intervals[i].Start <= iv.Start && iv.End <= s.intervals[i].End
+}
+
+// Gaps 返回 within 范围内不被集合覆盖的部分，例如计算日程中的空闲时间段
+func (s *IntervalSet[T]) Gaps(within Interval[T]) []Interval[T] {
+	if within.Empty() {
+		return nil
+	}
+	var gaps []Interval[T]
+	cursor := within.Start
+	for _, cur := range s.intervals {
+		if cur.End <= cursor {
+			continue
+		}
+		if cur.Start >= within.End {
+			break
+		}
+		if cur.Start > cursor {
+			gaps = append(gaps, Interval[T]{cursor, cur.Start})
+		}
+		cursor = cur.End
+	}
+	if cursor < within.End {
+		gaps = append(gaps, Interval[T]{cursor, within.End})
+	}
+	return gaps
+}
+
+// Intervals 按起点顺序返回合并后的所有区间
+func (s *IntervalSet[T]) Intervals() []Interval[T] {
+	return append([]Interval[T](nil), s.intervals...)
+}
+
+// Len 返回合并后的区间数量
+func (s *IntervalSet[T]) Len() int {
+	return len(s.intervals)
+}
@@ -0,0 +1,114 @@
+package collections
+
+import (
+	"slices"
+	"testing"
+)
+
+// iv 创建一个 int 区间
+func iv(start, end int) Interval[int] {
+	return Interval[int]{start, end}
+}
+
+func TestIntervalSetAdd(t *testing.T) {
+	tests := []struct {
+		name string
+		add  []Interval[int]
+		want []Interval[int]
+	}{
+		{"不重叠", []Interval[int]{iv(5, 7), iv(1, 3)}, []Interval[int]{iv(1, 3), iv(5, 7)}},
+		{"重叠合并", []Interval[int]{iv(1, 4), iv(3, 6)}, []Interval[int]{iv(1, 6)}},
+		{"相邻合并", []Interval[int]{iv(1, 3), iv(3, 5)}, []Interval[int]{iv(1, 5)}},
+		{"跨越多个区间", []Interval[int]{iv(1, 2), iv(4, 5), iv(7, 8), iv(0, 6)}, []Interval[int]{iv(0, 6), iv(7, 8)}},
+		{"被包含", []Interval[int]{iv(1, 10), iv(3, 4)}, []Interval[int]{iv(1, 10)}},
+		{"空区间", []Interval[int]{iv(3, 3), iv(5, 1)}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewIntervalSet(tt.add...)
+			if got := s.Intervals(); !slices.Equal(got, tt.want) {
+				t.Errorf("Intervals() = %v, 期望 %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIntervalSetRemove(t *testing.T) {
+	tests := []struct {
+		name   string
+		remove Interval[int]
+		want   []Interval[int]
+	}{
+		{"拆分", iv(3, 5), []Interval[int]{iv(0, 3), iv(5, 10), iv(20, 30)}},
+		{"截断两端", iv(8, 25), []Interval[int]{iv(0, 8), iv(25, 30)}},
+		{"完全移除", iv(-5, 40), nil},
+		{"不相交", iv(12, 18), []Interval[int]{iv(0, 10), iv(20, 30)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewIntervalSet(iv(0, 10), iv(20, 30))
+			s.Remove(tt.remove)
+			if got := s.Intervals(); !slices.Equal(got, tt.want) {
+				t.Errorf("Intervals() = %v, 期望 %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIntervalSetQueries(t *testing.T) {
+	s := NewIntervalSet(iv(0, 10), iv(20, 30))
+
+	points := []struct {
+		point int
+		want  bool
+	}{
+		{0, true},
+		{9, true},
+		{10, false},
+		{15, false},
+		{29, true},
+		{-1, false},
+	}
+	for _, tt := range points {
+		if got := s.Contains(tt.point); got != tt.want {
+			t.Errorf("Contains(%d) = %v, 期望 %v", tt.point, got, tt.want)
+		}
+	}
+
+	ranges := []struct {
+		name         string
+		r            Interval[int]
+		wantOverlaps bool
+		wantCovers   bool
+	}{
+		{"部分重叠", iv(5, 15), true, false},
+		{"完全覆盖", iv(2, 8), true, true},
+		{"在空隙中", iv(10, 20), false, false},
+		{"跨越空隙", iv(5, 25), true, false},
+	}
+	for _, tt := range ranges {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.Overlaps(tt.r); got != tt.wantOverlaps {
+				t.Errorf("Overlaps(%v) = %v, 期望 %v", tt.r, got, tt.wantOverlaps)
+			}
+			if got := s.Covers(tt.r); got != tt.wantCovers {
+				t.Errorf("Covers(%v) = %v, 期望 %v", tt.r, got, tt.wantCovers)
+			}
+		})
+	}
+
+	gaps := []struct {
+		within Interval[int]
+		want   []Interval[int]
+	}{
+		{iv(-5, 35), []Interval[int]{iv(-5, 0), iv(10, 20), iv(30, 35)}},
+		{iv(5, 25), []Interval[int]{iv(10, 20)}},
+		{iv(2, 8), nil},
+		{iv(12, 15), []Interval[int]{iv(12, 15)}},
+	}
+	for _, tt := range gaps {
+		if got := s.Gaps(tt.within); !slices.Equal(got, tt.want) {
+			t.Errorf("Gaps(%v) = %v, 期望 %v", tt.within, got, tt.want)
+		}
+	}
+}
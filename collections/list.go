@@ -0,0 +1,176 @@
+package collections
+
+// Element List 中的元素，可用于在其前后插入、移动或删除
+type Element[T any] struct {
+	Value T
+
+	next, prev *Element[T]
+	list       *List[T]
+}
+
+// Next 返回下一个元素，没有时返回 nil
+func (e *Element[T]) Next() *Element[T] {
+	if n := e.next; e.list != nil && n != &e.list.root {
+		return n
+	}
+	return nil
+}
+
+// Prev 返回上一个元素，没有时返回 nil
+func (e *Element[T]) Prev() *Element[T] {
+	if p := e.prev; e.list != nil && p != &e.list.root {
+		return p
+	}
+	return nil
+}
+
+// List 泛型双向链表，与 container/list 相同但 Value 有确定类型，无需类型断言
+// 零值是可直接使用的空链表，非并发安全
+type List[T any] struct {
+	root Element[T] // 哨兵节点，root.next 为首元素，root.prev 为尾元素
+	len  int
+}
+
+// NewList 创建一个包含 values 的链表
+func NewList[T any](values ...T) *List[T] {
+	l := &List[T]{}
+	for _, v := range values {
+		l.PushBack(v)
+	}
+	return l
+}
+
+// lazyInit 初始化零值链表的哨兵节点
+func (l *List[T]) lazyInit() {
+	if l.root.next == nil {
+		l.root.next = &l.root
+		l.root.prev = &l.root
+	}
+}
+
+// Len 返回链表中的元素数量
+func (l *List[T]) Len() int {
+	return l.len
+}
+
+// Front 返回首元素，链表为空时返回 nil
+func (l *List[T]) Front() *Element[T] {
+	if l.len == 0 {
+		return nil
+	}
+	return l.root.next
+}
+
+// Back 返回尾元素，链表为空时返回 nil
+func (l *List[T]) Back() *Element[T] {
+	if l.len == 0 {
+		return nil
+	}
+	return l.root.prev
+}
+
+// PushFront 在链表头部插入 v 并返回新元素
+func (l *List[T]) PushFront(v T) *Element[T] {
+	l.lazyInit()
+	return l.insert(&Element[T]{Value: v}, &l.root)
+}
+
+// PushBack 在链表尾部插入 v 并返回新元素
+func (l *List[T]) PushBack(v T) *Element[T] {
+	l.lazyInit()
+	return l.insert(&Element[T]{Value: v}, l.root.prev)
+}
+
+// InsertBefore 在 mark 之前插入 v 并返回新元素，mark 不属于该链表时返回 nil
+func (l *List[T]) InsertBefore(v T, mark *Element[T]) *Element[T] {
+	if mark.list != l {
+		return nil
+	}
+	return l.insert(&Element[T]{Value: v}, mark.prev)
+}
+
+// InsertAfter 在 mark 之后插入 v 并返回新元素，mark 不属于该链表时返回 nil
+func (l *List[T]) InsertAfter(v T, mark *Element[T]) *Element[T] {
+	if mark.list != l {
+		return nil
+	}
+	return l.insert(&Element[T]{Value: v}, mark)
+}
+
+// Remove 从链表中删除 e 并返回其值，e 不属于该链表时链表不变
+func (l *List[T]) Remove(e *Element[T]) T {
+	if e.list == l {
+		l.unlink(e)
+	}
+	return e.Value
+}
+
+// MoveToFront 将 e 移动到链表头部，e 不属于该链表时链表不变
+func (l *List[T]) MoveToFront(e *Element[T]) {
+	if e.list != l || l.root.next == e {
+		return
+	}
+	l.move(e, &l.root)
+}
+
+// MoveToBack 将 e 移动到链表尾部，e 不属于该链表时链表不变
+func (l *List[T]) MoveToBack(e *Element[T]) {
+	if e.list != l || l.root.prev == e {
+		return
+	}
+	l.move(e, l.root.prev)
+}
+
+// Range 从头到尾依次对每个值调用 fn，fn 返回 false 时停止遍历
+func (l *List[T]) Range(fn func(v T) bool) {
+	for e := l.Front(); e != nil; e = e.Next() {
+		if !fn(e.Value) {
+			return
+		}
+	}
+}
+
+// Values 按从头到尾的顺序返回所有值
+func (l *List[T]) Values() []T {
+	values := make([]T, 0, l.len)
+	l.Range(func(v T) bool {
+		values = append(values, v)
+		return true
+	})
+	return values
+}
+
+// insert 将 e 插入到 at 之后
+func (l *List[T]) insert(e, at *Element[T]) *Element[T] {
+	e.prev = at
+	e.next = at.next
+	e.prev.next = e
+	e.next.prev = e
+	e.list = l
+	l.len++
+	return e
+}
+
+// unlink 将 e 从链表中摘除
+func (l *List[T]) unlink(e *Element[T]) {
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	e.next = nil
+	e.prev = nil
+	e.list = nil
+	l.len--
+}
+
+// move 将 e 移动到 at 之后
+func (l *List[T]) move(e, at *Element[T]) {
+	if e == at {
+		return
+	}
+	e.prev.next = e.next
+	e.next.prev = e.prev
+
+	e.prev = at
+	e.next = at.next
+	e.prev.next = e
+	e.next.prev = e
+}
@@ -0,0 +1,88 @@
+package collections
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestList(t *testing.T) {
+	tests := []struct {
+		name string
+		ops  func(l *List[int])
+		want []int
+	}{
+		{"头尾插入", func(l *List[int]) {
+			l.PushBack(2)
+			l.PushFront(1)
+			l.PushBack(3)
+		}, []int{1, 2, 3}},
+		{"前后插入", func(l *List[int]) {
+			mid := l.PushBack(2)
+			l.InsertBefore(1, mid)
+			l.InsertAfter(3, mid)
+		}, []int{1, 2, 3}},
+		{"删除", func(l *List[int]) {
+			l.PushBack(1)
+			e := l.PushBack(2)
+			l.PushBack(3)
+			if v := l.Remove(e); v != 2 {
+				t.Errorf("Remove() = %v, 期望 %v", v, 2)
+			}
+			l.Remove(e)
+		}, []int{1, 3}},
+		{"移动", func(l *List[int]) {
+			a := l.PushBack(1)
+			l.PushBack(2)
+			c := l.PushBack(3)
+			l.MoveToFront(c)
+			l.MoveToBack(a)
+		}, []int{3, 2, 1}},
+		{"空链表", func(l *List[int]) {}, []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var l List[int]
+			tt.ops(&l)
+			if got := l.Values(); !slices.Equal(got, tt.want) {
+				t.Errorf("Values() = %v, 期望 %v", got, tt.want)
+			}
+			if l.Len() != len(tt.want) {
+				t.Errorf("Len() = %v, 期望 %v", l.Len(), len(tt.want))
+			}
+		})
+	}
+}
+
+func TestListTraversal(t *testing.T) {
+	l := NewList("a", "b", "c")
+
+	var forward []string
+	for e := l.Front(); e != nil; e = e.Next() {
+		forward = append(forward, e.Value)
+	}
+	if want := []string{"a", "b", "c"}; !slices.Equal(forward, want) {
+		t.Errorf("正向遍历 = %v, 期望 %v", forward, want)
+	}
+
+	var backward []string
+	for e := l.Back(); e != nil; e = e.Prev() {
+		backward = append(backward, e.Value)
+	}
+	if want := []string{"c", "b", "a"}; !slices.Equal(backward, want) {
+		t.Errorf("反向遍历 = %v, 期望 %v", backward, want)
+	}
+
+	var visited []string
+	l.Range(func(v string) bool {
+		visited = append(visited, v)
+		return v != "b"
+	})
+	if want := []string{"a", "b"}; !slices.Equal(visited, want) {
+		t.Errorf("Range() 提前停止 = %v, 期望 %v", visited, want)
+	}
+
+	other := NewList("x")
+	if e := l.InsertAfter("y", other.Front()); e != nil {
+		t.Error("使用其他链表的元素 InsertAfter() 应返回 nil")
+	}
+}
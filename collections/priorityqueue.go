@@ -0,0 +1,138 @@
+package collections
+
+// PQItem PriorityQueue 中元素的句柄，用于更新或删除已入队的元素
+type PQItem[T any] struct {
+	value T
+	index int // 在堆中的位置，出队后为 -1
+}
+
+// Value 返回句柄对应的值
+func (it *PQItem[T]) Value() T {
+	return it.value
+}
+
+// PriorityQueue 基于二叉堆的优先队列，less(a, b) 为 true 时 a 先出队
+// 非并发安全
+type PriorityQueue[T any] struct {
+	items []*PQItem[T]
+	less  func(a, b T) bool
+}
+
+// NewPriorityQueue 创建一个使用 less 比较优先级的优先队列
+// 例如 less 为 a < b 时是最小堆，a > b 时是最大堆
+func NewPriorityQueue[T any](less func(a, b T) bool) *PriorityQueue[T] {
+	return &PriorityQueue[T]{less: less}
+}
+
+// Push 将 v 加入队列，返回可用于 Update 和 Remove 的句柄
+func (pq *PriorityQueue[T]) Push(v T) *PQItem[T] {
+	it := &PQItem[T]{value: v, index: len(pq.items)}
+	pq.items = append(pq.items, it)
+	pq.up(it.index)
+	return it
+}
+
+// Pop 移除并返回优先级最高的元素，队列为空时返回零值和 false
+func (pq *PriorityQueue[T]) Pop() (T, bool) {
+	if len(pq.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return pq.removeAt(0).value, true
+}
+
+// Peek 返回优先级最高的元素但不移除，队列为空时返回零值和 false
+func (pq *PriorityQueue[T]) Peek() (T, bool) {
+	if len(pq.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return pq.items[0].value, true
+}
+
+// Len 返回队列中的元素数量
+func (pq *PriorityQueue[T]) Len() int {
+	return len(pq.items)
+}
+
+// Update 将句柄对应的值替换为 v 并调整其位置，句柄已出队时返回 false
+func (pq *PriorityQueue[T]) Update(it *PQItem[T], v T) bool {
+	if !pq.contains(it) {
+		return false
+	}
+	it.value = v
+	if !pq.down(it.index) {
+		pq.up(it.index)
+	}
+	return true
+}
+
+// Remove 从队列中删除句柄对应的元素，句柄已出队时返回 false
+func (pq *PriorityQueue[T]) Remove(it *PQItem[T]) bool {
+	if !pq.contains(it) {
+		return false
+	}
+	pq.removeAt(it.index)
+	return true
+}
+
+// contains 判断句柄是否仍在本队列中
+func (pq *PriorityQueue[T]) contains(it *PQItem[T]) bool {
+	return it != nil && it.index >= 0 && it.index < len(pq.items) && pq.items[it.index] == it
+}
+
+// removeAt 删除位置 i 的元素并恢复堆性质
+func (pq *PriorityQueue[T]) removeAt(i int) *PQItem[T] {
+	n := len(pq.items) - 1
+	it := pq.items[i]
+	if i != n {
+		pq.swap(i, n)
+	}
+	pq.items[n] = nil
+	pq.items = pq.items[:n]
+	if i != n && !pq.down(i) {
+		pq.up(i)
+	}
+	it.index = -1
+	return it
+}
+
+// up 将位置 i 的元素向上调整
+func (pq *PriorityQueue[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !pq.less(pq.items[i].value, pq.items[parent].value) {
+			break
+		}
+		pq.swap(i, parent)
+		i = parent
+	}
+}
+
+// down 将位置 i 的元素向下调整，发生移动时返回 true
+func (pq *PriorityQueue[T]) down(i int) bool {
+	start := i
+	n := len(pq.items)
+	for {
+		child := 2*i + 1
+		if child >= n {
+			break
+		}
+		if right := child + 1; right < n && pq.less(pq.items[right].value, pq.items[child].value) {
+			child = right
+		}
+		if !pq.less(pq.items[child].value, pq.items[i].value) {
+			break
+		}
+		pq.swap(i, child)
+		i = child
+	}
+	return i > start
+}
+
+// swap 交换两个位置的元素并更新其索引
+func (pq *PriorityQueue[T]) swap(i, j int) {
+	pq.items[i], pq.items[j] = pq.items[j], pq.items[i]
+	pq.items[i].index = i
+	pq.items[j].index = j
+}
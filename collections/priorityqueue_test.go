@@ -0,0 +1,90 @@
+package collections
+
+import (
+	"slices"
+	"testing"
+)
+
+// popAll 依次弹出 pq 中的所有元素
+func popAll[T any](pq *PriorityQueue[T]) []T {
+	var values []T
+	for {
+		v, ok := pq.Pop()
+		if !ok {
+			return values
+		}
+		values = append(values, v)
+	}
+}
+
+func TestPriorityQueue(t *testing.T) {
+	tests := []struct {
+		name   string
+		less   func(a, b int) bool
+		values []int
+		want   []int
+	}{
+		{"最小堆", func(a, b int) bool { return a < b }, []int{5, 1, 4, 2, 3}, []int{1, 2, 3, 4, 5}},
+		{"最大堆", func(a, b int) bool { return a > b }, []int{5, 1, 4, 2, 3}, []int{5, 4, 3, 2, 1}},
+		{"重复值", func(a, b int) bool { return a < b }, []int{2, 1, 2, 1}, []int{1, 1, 2, 2}},
+		{"空队列", func(a, b int) bool { return a < b }, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pq := NewPriorityQueue(tt.less)
+			for _, v := range tt.values {
+				pq.Push(v)
+			}
+			if pq.Len() != len(tt.values) {
+				t.Errorf("Len() = %v, 期望 %v", pq.Len(), len(tt.values))
+			}
+			if len(tt.want) > 0 {
+				if v, ok := pq.Peek(); !ok || v != tt.want[0] {
+					t.Errorf("Peek() = %v, %v, 期望 %v, true", v, ok, tt.want[0])
+				}
+			}
+			if got := popAll(pq); !slices.Equal(got, tt.want) {
+				t.Errorf("Pop() 顺序 = %v, 期望 %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPriorityQueueHandles(t *testing.T) {
+	type task struct {
+		name     string
+		priority int
+	}
+	pq := NewPriorityQueue(func(a, b task) bool { return a.priority < b.priority })
+	a := pq.Push(task{"a", 3})
+	b := pq.Push(task{"b", 5})
+	c := pq.Push(task{"c", 7})
+	pq.Push(task{"d", 4})
+
+	if !pq.Update(c, task{"c", 1}) {
+		t.Error("Update() = false, 期望 true")
+	}
+	if !pq.Remove(a) {
+		t.Error("Remove() = false, 期望 true")
+	}
+	if pq.Remove(a) {
+		t.Error("重复 Remove() = true, 期望 false")
+	}
+	if !pq.Update(b, task{"b", 2}) {
+		t.Error("Update() = false, 期望 true")
+	}
+	if b.Value().priority != 2 {
+		t.Errorf("Value().priority = %v, 期望 %v", b.Value().priority, 2)
+	}
+
+	var names []string
+	for _, v := range popAll(pq) {
+		names = append(names, v.name)
+	}
+	if want := []string{"c", "b", "d"}; !slices.Equal(names, want) {
+		t.Errorf("Pop() 顺序 = %v, 期望 %v", names, want)
+	}
+	if pq.Update(c, task{"c", 0}) {
+		t.Error("已出队的句柄 Update() = true, 期望 false")
+	}
+}
@@ -0,0 +1,32 @@
+package collections
+
+// Queue 先进先出队列，基于 Deque 的环形缓冲区实现
+// 出队的元素会被清零，不会像 s = s[1:] 那样让底层数组一直持有已出队的元素；零值可直接使用，非并发安全
+type Queue[T any] struct {
+	d Deque[T]
+}
+
+// NewQueue 创建一个初始容量至少为 capacity 的队列
+func NewQueue[T any](capacity int) *Queue[T] {
+	return &Queue[T]{d: *NewDeque[T](capacity)}
+}
+
+// Enqueue 将 v 加入队尾
+func (q *Queue[T]) Enqueue(v T) {
+	q.d.PushBack(v)
+}
+
+// Dequeue 移除并返回队首元素，队列为空时返回零值和 false
+func (q *Queue[T]) Dequeue() (T, bool) {
+	return q.d.PopFront()
+}
+
+// Peek 返回队首元素但不移除，队列为空时返回零值和 false
+func (q *Queue[T]) Peek() (T, bool) {
+	return q.d.Front()
+}
+
+// Len 返回队列中的元素数量
+func (q *Queue[T]) Len() int {
+	return q.d.Len()
+}
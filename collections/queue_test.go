@@ -0,0 +1,71 @@
+package collections
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestQueue(t *testing.T) {
+	tests := []struct {
+		name     string
+		capacity int
+		values   []int
+	}{
+		{"少量元素", 0, []int{1, 2, 3}},
+		{"触发扩容", 2, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17}},
+		{"空队列", 4, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := NewQueue[int](tt.capacity)
+			for _, v := range tt.values {
+				q.Enqueue(v)
+			}
+			if q.Len() != len(tt.values) {
+				t.Errorf("Len() = %v, 期望 %v", q.Len(), len(tt.values))
+			}
+			if len(tt.values) > 0 {
+				if v, ok := q.Peek(); !ok || v != tt.values[0] {
+					t.Errorf("Peek() = %v, %v, 期望 %v, true", v, ok, tt.values[0])
+				}
+			}
+			var got []int
+			for {
+				v, ok := q.Dequeue()
+				if !ok {
+					break
+				}
+				got = append(got, v)
+			}
+			if !slices.Equal(got, tt.values) {
+				t.Errorf("Dequeue() 顺序 = %v, 期望 %v", got, tt.values)
+			}
+			if _, ok := q.Peek(); ok {
+				t.Error("空队列 Peek() 返回 true")
+			}
+		})
+	}
+
+	t.Run("交替入队出队", func(t *testing.T) {
+		var q Queue[int]
+		var want []int
+		for i := range 100 {
+			q.Enqueue(i)
+			q.Enqueue(i + 1000)
+			want = append(want, i, i+1000)
+			v, _ := q.Dequeue()
+			if v != want[0] {
+				t.Fatalf("Dequeue() = %v, 期望 %v", v, want[0])
+			}
+			want = want[1:]
+		}
+		if q.Len() != len(want) {
+			t.Errorf("Len() = %v, 期望 %v", q.Len(), len(want))
+		}
+		for _, w := range want {
+			if v, _ := q.Dequeue(); v != w {
+				t.Fatalf("Dequeue() = %v, 期望 %v", v, w)
+			}
+		}
+	})
+}
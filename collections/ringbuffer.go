@@ -0,0 +1,84 @@
+package collections
+
+// FullPolicy RingBuffer 已满时写入新元素的处理方式
+type FullPolicy int
+
+const (
+	// OverwriteOldest 覆盖最旧的元素
+	OverwriteOldest FullPolicy = iota
+	// RejectNew 拒绝写入新元素
+	RejectNew
+)
+
+// RingBuffer 固定容量的环形缓冲区，适用于保留最近 N 条日志或指标样本
+// 非并发安全
+type RingBuffer[T any] struct {
+	buf    []T
+	head   int
+	size   int
+	policy FullPolicy
+}
+
+// NewRingBuffer 创建一个容量为 capacity 的环形缓冲区，capacity <= 0 时按 1 处理
+func NewRingBuffer[T any](capacity int, policy FullPolicy) *RingBuffer[T] {
+	return &RingBuffer[T]{buf: make([]T, max(capacity, 1)), policy: policy}
+}
+
+// Push 写入 v，缓冲区已满且策略为 RejectNew 时返回 false
+func (r *RingBuffer[T]) Push(v T) bool {
+	if r.size == len(r.buf) {
+		if r.policy == RejectNew {
+			return false
+		}
+		r.buf[r.head] = v
+		r.head = (r.head + 1) % len(r.buf)
+		return true
+	}
+	r.buf[(r.head+r.size)%len(r.buf)] = v
+	r.size++
+	return true
+}
+
+// Pop 移除并返回最旧的元素，缓冲区为空时返回零值和 false
+func (r *RingBuffer[T]) Pop() (T, bool) {
+	var zero T
+	if r.size == 0 {
+		return zero, false
+	}
+	v := r.buf[r.head]
+	r.buf[r.head] = zero
+	r.head = (r.head + 1) % len(r.buf)
+	r.size--
+	return v, true
+}
+
+// Len 返回缓冲区中的元素数量
+func (r *RingBuffer[T]) Len() int {
+	return r.size
+}
+
+// Cap 返回缓冲区的容量
+func (r *RingBuffer[T]) Cap() int {
+	return len(r.buf)
+}
+
+// Full 判断缓冲区是否已满
+func (r *RingBuffer[T]) Full() bool {
+	return r.size == len(r.buf)
+}
+
+// Snapshot 按从旧到新的顺序返回所有元素的副本
+func (r *RingBuffer[T]) Snapshot() []T {
+	result := make([]T, r.size)
+	for i := range r.size {
+		result[i] = r.buf[(r.head+i)%len(r.buf)]
+	}
+	return result
+}
+
+// Clear 移除所有元素，容量不变
+func (r *RingBuffer[T]) Clear() {
+	clear(r.buf)
+	r.head = 0
+	r.size = 0
+}
@@ -0,0 +1,63 @@
+package collections
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRingBuffer(t *testing.T) {
+	tests := []struct {
+		name       string
+		capacity   int
+		policy     FullPolicy
+		values     []int
+		want       []int
+		wantReject int
+	}{
+		{"未满", 3, OverwriteOldest, []int{1, 2}, []int{1, 2}, 0},
+		{"覆盖最旧", 3, OverwriteOldest, []int{1, 2, 3, 4, 5}, []int{3, 4, 5}, 0},
+		{"拒绝写入", 3, RejectNew, []int{1, 2, 3, 4, 5}, []int{1, 2, 3}, 2},
+		{"容量为 0", 0, OverwriteOldest, []int{1, 2}, []int{2}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRingBuffer[int](tt.capacity, tt.policy)
+			rejected := 0
+			for _, v := range tt.values {
+				if !r.Push(v) {
+					rejected++
+				}
+			}
+			if got := r.Snapshot(); !slices.Equal(got, tt.want) {
+				t.Errorf("Snapshot() = %v, 期望 %v", got, tt.want)
+			}
+			if rejected != tt.wantReject {
+				t.Errorf("被拒绝 %v 次, 期望 %v", rejected, tt.wantReject)
+			}
+			if r.Len() != len(tt.want) {
+				t.Errorf("Len() = %v, 期望 %v", r.Len(), len(tt.want))
+			}
+		})
+	}
+
+	t.Run("弹出和清空", func(t *testing.T) {
+		r := NewRingBuffer[int](2, OverwriteOldest)
+		r.Push(1)
+		r.Push(2)
+		r.Push(3)
+		if !r.Full() || r.Cap() != 2 {
+			t.Errorf("Full() = %v, Cap() = %v, 期望 true, 2", r.Full(), r.Cap())
+		}
+		if v, ok := r.Pop(); !ok || v != 2 {
+			t.Errorf("Pop() = %v, %v, 期望 2, true", v, ok)
+		}
+		r.Push(4)
+		if got := r.Snapshot(); !slices.Equal(got, []int{3, 4}) {
+			t.Errorf("Snapshot() = %v, 期望 %v", got, []int{3, 4})
+		}
+		r.Clear()
+		if _, ok := r.Pop(); ok || r.Len() != 0 {
+			t.Error("Clear() 后缓冲区不为空")
+		}
+	})
+}
@@ -0,0 +1,206 @@
+package collections
+
+import (
+	"cmp"
+	"sync"
+
+	"github.com/jiu-u/gogout/randutils"
+)
+
+const (
+	// skipListMaxLevel 跳表的最大层数，足以容纳 2^32 个元素
+	skipListMaxLevel = 32
+	// skipListP 每个节点晋升到上一层的概率为 1/skipListP
+	skipListP = 4
+)
+
+// skipNode 跳表节点
+type skipNode[K cmp.Ordered, V any] struct {
+	key  K
+	val  V
+	next []*skipNode[K, V]
+}
+
+// SkipList 按 key 有序的跳表，查找、插入和删除的期望复杂度为 O(log n)
+// 零值可直接使用，此时使用 randutils.Default() 生成节点层数
+// 并发安全：读操作共享读锁，写操作独占；Range 等遍历方法的回调在持有读锁时执行，不能在回调中修改跳表
+type SkipList[K cmp.Ordered, V any] struct {
+	mu    sync.RWMutex
+	head  skipNode[K, V]
+	level int
+	size  int
+	rand  randutils.Source // 生成节点层数使用的随机源
+}
+
+// NewSkipList 创建一个空的跳表
+func NewSkipList[K cmp.Ordered, V any]() *SkipList[K, V] {
+	return NewSkipListFrom[K, V](randutils.Default())
+}
+
+// NewSkipListFrom 与 NewSkipList 相同，使用指定的随机源生成节点层数，相同种子下结构可复现
+func NewSkipListFrom[K cmp.Ordered, V any](src randutils.Source) *SkipList[K, V] {
+	s := &SkipList[K, V]{rand: src}
+	s.lazyInit()
+	return s
+}
+
+// lazyInit 初始化头节点和随机源，使零值可以直接使用；调用时必须持有写锁
+func (s *SkipList[K, V]) lazyInit() {
+	if s.head.next == nil {
+		s.head.next = make([]*skipNode[K, V], skipListMaxLevel)
+		s.level = 1
+	}
+	if s.rand == nil {
+		s.rand = randutils.Default()
+	}
+}
+
+// Set 写入键值对，key 已存在时覆盖并返回 false
+func (s *SkipList[K, V]) Set(key K, value V) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lazyInit()
+
+	var update [skipListMaxLevel]*skipNode[K, V]
+	n := s.findPrev(key, &update)
+	if next := n.next[0]; next != nil && next.key == key {
+		next.val = value
+		return false
+	}
+
+	level := s.randomLevel()
+	if level > s.level {
+		for i := s.level; i < level; i++ {
+			update[i] = &s.head
+		}
+		s.level = level
+	}
+	node := &skipNode[K, V]{key: key, val: value, next: make([]*skipNode[K, V], level)}
+	for i := range level {
+		node.next[i] = update[i].next[i]
+		update[i].next[i] = node
+	}
+	s.size++
+	return true
+}
+
+// Get 返回 key 对应的值
+func (s *SkipList[K, V]) Get(key K) (V, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if n := s.ceiling(key); n != nil && n.key == key {
+		return n.val, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Delete 删除 key，key 存在时返回 true
+func (s *SkipList[K, V]) Delete(key K) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.head.next == nil {
+		return false
+	}
+
+	var update [skipListMaxLevel]*skipNode[K, V]
+	n := s.findPrev(key, &update)
+	target := n.next[0]
+	if target == nil || target.key != key {
+		return false
+	}
+	for i := range len(target.next) {
+		update[i].next[i] = target.next[i]
+	}
+	for s.level > 1 && s.head.next[s.level-1] == nil {
+		s.level--
+	}
+	s.size--
+	return true
+}
+
+// Min 返回最小的键值对，跳表为空时返回 false
+func (s *SkipList[K, V]) Min() (K, V, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if n := s.first(); n != nil {
+		return n.key, n.val, true
+	}
+	var (
+		zk K
+		zv V
+	)
+	return zk, zv, false
+}
+
+// Range 按 key 升序遍历 [from, to) 范围内的键值对，fn 返回 false 时停止遍历
+func (s *SkipList[K, V]) Range(from, to K, fn func(key K, value V) bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for n := s.ceiling(from); n != nil && n.key < to; n = n.next[0] {
+		if !fn(n.key, n.val) {
+			return
+		}
+	}
+}
+
+// Each 按 key 升序遍历所有键值对，fn 返回 false 时停止遍历
+func (s *SkipList[K, V]) Each(fn func(key K, value V) bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for n := s.first(); n != nil; n = n.next[0] {
+		if !fn(n.key, n.val) {
+			return
+		}
+	}
+}
+
+// Len 返回跳表中的元素数量
+func (s *SkipList[K, V]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.size
+}
+
+// findPrev 返回第 0 层中 key 之前的最后一个节点，并在 update 中记录每一层的前驱节点
+func (s *SkipList[K, V]) findPrev(key K, update *[skipListMaxLevel]*skipNode[K, V]) *skipNode[K, V] {
+	n := &s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for n.next[i] != nil && n.next[i].key < key {
+			n = n.next[i]
+		}
+		update[i] = n
+	}
+	return n
+}
+
+// ceiling 返回第一个 key 大于等于 key 的节点
+func (s *SkipList[K, V]) ceiling(key K) *skipNode[K, V] {
+	n := &s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for n.next[i] != nil && n.next[i].key < key {
+			n = n.next[i]
+		}
+	}
+	if n == &s.head {
+		return s.first()
+	}
+	return n.next[0]
+}
+
+// first 返回第一个节点，跳表为空时返回 nil
+func (s *SkipList[K, V]) first() *skipNode[K, V] {
+	if s.head.next == nil {
+		return nil
+	}
+	return s.head.next[0]
+}
+
+// randomLevel 按几何分布随机生成新节点的层数
+func (s *SkipList[K, V]) randomLevel() int {
+	level := 1
+	for level < skipListMaxLevel && s.rand.Intn(skipListP) == 0 {
+		level++
+	}
+	return level
+}
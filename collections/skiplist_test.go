@@ -0,0 +1,172 @@
+package collections
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/jiu-u/gogout/randutils"
+)
+
+// skipListKeys 按顺序返回跳表中的所有 key
+func skipListKeys[K cmp.Ordered, V any](s *SkipList[K, V]) []K {
+	var keys []K
+	s.Each(func(k K, _ V) bool {
+		keys = append(keys, k)
+		return true
+	})
+	return keys
+}
+
+func TestSkipList(t *testing.T) {
+	s := NewSkipList[int, string]()
+	perm := rand.Perm(200)
+	for _, k := range perm {
+		if !s.Set(k, "v") {
+			t.Fatalf("Set(%d) = false, 期望 true", k)
+		}
+	}
+	if s.Set(10, "ten") {
+		t.Error("覆盖已有 key 的 Set() = true, 期望 false")
+	}
+
+	want := make([]int, 200)
+	for i := range want {
+		want[i] = i
+	}
+	if got := skipListKeys(s); !slices.Equal(got, want) {
+		t.Errorf("Each() 顺序错误")
+	}
+
+	tests := []struct {
+		name   string
+		key    int
+		want   string
+		wantOK bool
+	}{
+		{"覆盖后的值", 10, "ten", true},
+		{"存在", 0, "v", true},
+		{"不存在", 500, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := s.Get(tt.key)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Get(%d) = %q, %v, 期望 %q, %v", tt.key, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	for k := 0; k < 200; k += 2 {
+		if !s.Delete(k) {
+			t.Fatalf("Delete(%d) = false, 期望 true", k)
+		}
+	}
+	if s.Delete(0) {
+		t.Error("重复 Delete() = true, 期望 false")
+	}
+	if s.Len() != 100 {
+		t.Errorf("Len() = %v, 期望 %v", s.Len(), 100)
+	}
+	if k, _, ok := s.Min(); !ok || k != 1 {
+		t.Errorf("Min() = %v, %v, 期望 1, true", k, ok)
+	}
+}
+
+func TestSkipListRange(t *testing.T) {
+	s := NewSkipList[int, int]()
+	for _, k := range []int{1, 3, 5, 7, 9} {
+		s.Set(k, k*10)
+	}
+	tests := []struct {
+		name     string
+		from, to int
+		limit    int
+		want     []int
+	}{
+		{"区间内", 3, 8, 0, []int{3, 5, 7}},
+		{"左端不存在", 2, 6, 0, []int{3, 5}},
+		{"提前停止", 0, 100, 2, []int{1, 3}},
+		{"空区间", 4, 5, 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int
+			s.Range(tt.from, tt.to, func(k, v int) bool {
+				got = append(got, k)
+				return tt.limit == 0 || len(got) < tt.limit
+			})
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Range(%d, %d) = %v, 期望 %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSkipListConcurrent(t *testing.T) {
+	s := NewSkipList[int, int]()
+	var wg sync.WaitGroup
+	for i := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 100 {
+				s.Set(i*100+j, j)
+				s.Get(j)
+			}
+		}()
+	}
+	wg.Wait()
+	if s.Len() != 400 {
+		t.Errorf("Len() = %v, 期望 %v", s.Len(), 400)
+	}
+	if _, _, ok := NewSkipList[string, int]().Min(); ok {
+		t.Error("空跳表 Min() 返回 true")
+	}
+}
+
+func TestNewSkipListFrom(t *testing.T) {
+	// levels 返回每个节点的层数
+	levels := func(s *SkipList[int, int]) []int {
+		var result []int
+		for n := s.head.next[0]; n != nil; n = n.next[0] {
+			result = append(result, len(n.next))
+		}
+		return result
+	}
+	a := NewSkipListFrom[int, int](randutils.NewSource(42))
+	b := NewSkipListFrom[int, int](randutils.NewSource(42))
+	for i := range 100 {
+		a.Set(i, i)
+		b.Set(i, i)
+	}
+	if la, lb := levels(a), levels(b); !slices.Equal(la, lb) || a.level != b.level {
+		t.Errorf("相同种子的跳表层数不同: %v, %v", la, lb)
+	}
+}
+
+func TestSkipListZeroValue(t *testing.T) {
+	var s SkipList[string, int]
+	if _, ok := s.Get("a"); ok {
+		t.Error("零值跳表 Get() 返回 true")
+	}
+	if _, _, ok := s.Min(); ok {
+		t.Error("零值跳表 Min() 返回 true")
+	}
+	if s.Delete("a") {
+		t.Error("零值跳表 Delete() 返回 true")
+	}
+	s.Range("a", "z", func(string, int) bool {
+		t.Error("零值跳表 Range() 调用了 fn")
+		return true
+	})
+
+	for i, k := range []string{"c", "a", "b"} {
+		s.Set(k, i)
+	}
+	if keys := skipListKeys(&s); !slices.Equal(keys, []string{"a", "b", "c"}) || s.Len() != 3 {
+		t.Errorf("Set() 后的 key = %v, Len() = %v, 期望 [a b c], 3", keys, s.Len())
+	}
+}
@@ -0,0 +1,275 @@
+package collections
+
+// avlNode SortedMap 使用的 AVL 树节点
+type avlNode[K, V any] struct {
+	key         K
+	value       V
+	left, right *avlNode[K, V]
+	height      int
+}
+
+// nodeHeight 返回节点高度，nil 的高度为 0
+func nodeHeight[K, V any](n *avlNode[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// SortedMap 按 key 有序的映射，基于 AVL 树实现，查找、插入和删除均为 O(log n)
+// key 的顺序由构造时传入的 compare 函数决定；非并发安全
+type SortedMap[K, V any] struct {
+	root    *avlNode[K, V]
+	size    int
+	compare func(a, b K) int
+}
+
+// NewSortedMap 创建一个使用 compare 比较 key 的有序映射
+// compare(a, b) 在 a < b 时返回负数，相等时返回 0，a > b 时返回正数，例如 cmp.Compare[int]
+func NewSortedMap[K, V any](compare func(a, b K) int) *SortedMap[K, V] {
+	return &SortedMap[K, V]{compare: compare}
+}
+
+// Get 返回 key 对应的值
+func (m *SortedMap[K, V]) Get(key K) (V, bool) {
+	n := m.root
+	for n != nil {
+		c := m.compare(key, n.key)
+		switch {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n.value, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// Set 写入键值对，key 已存在时覆盖并返回 false
+func (m *SortedMap[K, V]) Set(key K, value V) bool {
+	var added bool
+	m.root = m.insert(m.root, key, value, &added)
+	if added {
+		m.size++
+	}
+	return added
+}
+
+// Delete 删除 key，key 存在时返回 true
+func (m *SortedMap[K, V]) Delete(key K) bool {
+	var removed bool
+	m.root = m.remove(m.root, key, &removed)
+	if removed {
+		m.size--
+	}
+	return removed
+}
+
+// Len 返回映射中的元素数量
+func (m *SortedMap[K, V]) Len() int {
+	return m.size
+}
+
+// Min 返回最小的键值对，映射为空时返回 false
+func (m *SortedMap[K, V]) Min() (K, V, bool) {
+	if m.root == nil {
+		return m.none()
+	}
+	n := m.root
+	for n.left != nil {
+		n = n.left
+	}
+	return n.key, n.value, true
+}
+
+// Max 返回最大的键值对，映射为空时返回 false
+func (m *SortedMap[K, V]) Max() (K, V, bool) {
+	if m.root == nil {
+		return m.none()
+	}
+	n := m.root
+	for n.right != nil {
+		n = n.right
+	}
+	return n.key, n.value, true
+}
+
+// Floor 返回小于等于 key 的最大键值对，不存在时返回 false
+func (m *SortedMap[K, V]) Floor(key K) (K, V, bool) {
+	var best *avlNode[K, V]
+	for n := m.root; n != nil; {
+		c := m.compare(key, n.key)
+		if c == 0 {
+			return n.key, n.value, true
+		}
+		if c < 0 {
+			n = n.left
+		} else {
+			best, n = n, n.right
+		}
+	}
+	if best == nil {
+		return m.none()
+	}
+	return best.key, best.value, true
+}
+
+// Ceiling 返回大于等于 key 的最小键值对，不存在时返回 false
+func (m *SortedMap[K, V]) Ceiling(key K) (K, V, bool) {
+	var best *avlNode[K, V]
+	for n := m.root; n != nil; {
+		c := m.compare(key, n.key)
+		if c == 0 {
+			return n.key, n.value, true
+		}
+		if c > 0 {
+			n = n.right
+		} else {
+			best, n = n, n.left
+		}
+	}
+	if best == nil {
+		return m.none()
+	}
+	return best.key, best.value, true
+}
+
+// Range 按 key 升序遍历 [from, to) 范围内的键值对，fn 返回 false 时停止遍历
+func (m *SortedMap[K, V]) Range(from, to K, fn func(key K, value V) bool) {
+	m.walk(m.root, &from, &to, fn)
+}
+
+// Each 按 key 升序遍历所有键值对，fn 返回 false 时停止遍历
+func (m *SortedMap[K, V]) Each(fn func(key K, value V) bool) {
+	m.walk(m.root, nil, nil, fn)
+}
+
+// Keys 按升序返回所有 key
+func (m *SortedMap[K, V]) Keys() []K {
+	keys := make([]K, 0, m.size)
+	m.Each(func(k K, _ V) bool {
+		keys = append(keys, k)
+		return true
+	})
+	return keys
+}
+
+// none 返回表示不存在的零值
+func (m *SortedMap[K, V]) none() (K, V, bool) {
+	var (
+		zk K
+		zv V
+	)
+	return zk, zv, false
+}
+
+// walk 中序遍历 n，from 和 to 为 nil 时表示不限制该端，返回 false 表示已停止
+func (m *SortedMap[K, V]) walk(n *avlNode[K, V], from, to *K, fn func(key K, value V) bool) bool {
+	if n == nil {
+		return true
+	}
+	aboveFrom := from == nil || m.compare(n.key, *from) >= 0
+	belowTo := to == nil || m.compare(n.key, *to) < 0
+	if aboveFrom && !m.walk(n.left, from, to, fn) {
+		return false
+	}
+	if aboveFrom && belowTo && !fn(n.key, n.value) {
+		return false
+	}
+	if belowTo {
+		return m.walk(n.right, from, to, fn)
+	}
+	return true
+}
+
+// insert 在子树 n 中插入键值对，返回新的子树根
+func (m *SortedMap[K, V]) insert(n *avlNode[K, V], key K, value V, added *bool) *avlNode[K, V] {
+	if n == nil {
+		*added = true
+		return &avlNode[K, V]{key: key, value: value, height: 1}
+	}
+	c := m.compare(key, n.key)
+	switch {
+	case c < 0:
+		n.left = m.insert(n.left, key, value, added)
+	case c > 0:
+		n.right = m.insert(n.right, key, value, added)
+	default:
+		n.value = value
+		return n
+	}
+	return rebalance(n)
+}
+
+// remove 在子树 n 中删除 key，返回新的子树根
+func (m *SortedMap[K, V]) remove(n *avlNode[K, V], key K, removed *bool) *avlNode[K, V] {
+	if n == nil {
+		return nil
+	}
+	c := m.compare(key, n.key)
+	switch {
+	case c < 0:
+		n.left = m.remove(n.left, key, removed)
+	case c > 0:
+		n.right = m.remove(n.right, key, removed)
+	default:
+		*removed = true
+		if n.left == nil {
+			return n.right
+		}
+		if n.right == nil {
+			return n.left
+		}
+		// 用右子树的最小节点替换当前节点
+		succ := n.right
+		for succ.left != nil {
+			succ = succ.left
+		}
+		n.key, n.value = succ.key, succ.value
+		var ignored bool
+		n.right = m.remove(n.right, succ.key, &ignored)
+	}
+	return rebalance(n)
+}
+
+// rebalance 更新节点高度并在失衡时旋转，返回新的子树根
+func rebalance[K, V any](n *avlNode[K, V]) *avlNode[K, V] {
+	n.height = 1 + max(nodeHeight(n.left), nodeHeight(n.right))
+	balance := nodeHeight(n.left) - nodeHeight(n.right)
+	switch {
+	case balance > 1:
+		if nodeHeight(n.left.left) < nodeHeight(n.left.right) {
+			n.left = rotateLeft(n.left)
+		}
+		return rotateRight(n)
+	case balance < -1:
+		if nodeHeight(n.right.right) < nodeHeight(n.right.left) {
+			n.right = rotateRight(n.right)
+		}
+		return rotateLeft(n)
+	}
+	return n
+}
+
+// rotateLeft 左旋
+func rotateLeft[K, V any](n *avlNode[K, V]) *avlNode[K, V] {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	n.height = 1 + max(nodeHeight(n.left), nodeHeight(n.right))
+	r.height = 1 + max(nodeHeight(r.left), nodeHeight(r.right))
+	return r
+}
+
+// rotateRight 右旋
+func rotateRight[K, V any](n *avlNode[K, V]) *avlNode[K, V] {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	n.height = 1 + max(nodeHeight(n.left), nodeHeight(n.right))
+	l.height = 1 + max(nodeHeight(l.left), nodeHeight(l.right))
+	return l
+}
@@ -0,0 +1,113 @@
+package collections
+
+import (
+	"cmp"
+	"math/bits"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestSortedMap(t *testing.T) {
+	m := NewSortedMap[int, int](cmp.Compare[int])
+	ref := map[int]int{}
+	for range 2000 {
+		k := rand.Intn(300)
+		if rand.Intn(3) == 0 {
+			_, exists := ref[k]
+			if got := m.Delete(k); got != exists {
+				t.Fatalf("Delete(%d) = %v, 期望 %v", k, got, exists)
+			}
+			delete(ref, k)
+		} else {
+			_, exists := ref[k]
+			if got := m.Set(k, k*2); got == exists {
+				t.Fatalf("Set(%d) = %v, 期望 %v", k, got, !exists)
+			}
+			ref[k] = k * 2
+		}
+	}
+
+	want := make([]int, 0, len(ref))
+	for k := range ref {
+		want = append(want, k)
+	}
+	slices.Sort(want)
+	if got := m.Keys(); !slices.Equal(got, want) {
+		t.Errorf("Keys() 与参照结果不一致")
+	}
+	if m.Len() != len(ref) {
+		t.Errorf("Len() = %v, 期望 %v", m.Len(), len(ref))
+	}
+	for k, v := range ref {
+		if got, ok := m.Get(k); !ok || got != v {
+			t.Fatalf("Get(%d) = %v, %v, 期望 %v, true", k, got, ok, v)
+		}
+	}
+	if h := nodeHeight(m.root); m.Len() > 0 && h > 2*bits.Len(uint(m.Len())) {
+		t.Errorf("树高度 = %v, 树不平衡", h)
+	}
+}
+
+func TestSortedMapNavigation(t *testing.T) {
+	m := NewSortedMap[string, int](cmp.Compare[string])
+	for i, k := range []string{"d", "b", "f", "a", "e"} {
+		m.Set(k, i)
+	}
+
+	tests := []struct {
+		name   string
+		fn     func(string) (string, int, bool)
+		key    string
+		want   string
+		wantOK bool
+	}{
+		{"Floor 精确", m.Floor, "b", "b", true},
+		{"Floor 之间", m.Floor, "c", "b", true},
+		{"Floor 过小", m.Floor, "0", "", false},
+		{"Ceiling 精确", m.Ceiling, "e", "e", true},
+		{"Ceiling 之间", m.Ceiling, "c", "d", true},
+		{"Ceiling 过大", m.Ceiling, "z", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, ok := tt.fn(tt.key)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("(%q) = %q, %v, 期望 %q, %v", tt.key, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	if k, _, _ := m.Min(); k != "a" {
+		t.Errorf("Min() = %q, 期望 %q", k, "a")
+	}
+	if k, _, _ := m.Max(); k != "f" {
+		t.Errorf("Max() = %q, 期望 %q", k, "f")
+	}
+
+	var got []string
+	m.Range("b", "e", func(k string, _ int) bool {
+		got = append(got, k)
+		return true
+	})
+	if want := []string{"b", "d"}; !slices.Equal(got, want) {
+		t.Errorf("Range(b, e) = %v, 期望 %v", got, want)
+	}
+
+	got = nil
+	m.Each(func(k string, _ int) bool {
+		got = append(got, k)
+		return len(got) < 2
+	})
+	if want := []string{"a", "b"}; !slices.Equal(got, want) {
+		t.Errorf("Each() 提前停止 = %v, 期望 %v", got, want)
+	}
+
+	empty := NewSortedMap[int, int](cmp.Compare[int])
+	if _, _, ok := empty.Min(); ok {
+		t.Error("空映射 Min() 返回 true")
+	}
+	if _, _, ok := empty.Max(); ok {
+		t.Error("空映射 Max() 返回 true")
+	}
+}
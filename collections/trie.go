@@ -0,0 +1,161 @@
+package collections
+
+import "slices"
+
+// trieNode Trie 的节点
+type trieNode[V any] struct {
+	children map[byte]*trieNode[V]
+	value    V
+	hasValue bool
+}
+
+// Trie 以字符串为键的前缀树，适用于路由表、自动补全和最长前缀匹配
+// 按字节划分，对 UTF-8 字符串同样适用；零值可直接使用，非并发安全
+type Trie[V any] struct {
+	root trieNode[V]
+	size int
+}
+
+// NewTrie 创建一个空的前缀树
+func NewTrie[V any]() *Trie[V] {
+	return &Trie[V]{}
+}
+
+// Insert 写入 key 对应的值，key 已存在时覆盖并返回 false
+func (t *Trie[V]) Insert(key string, value V) bool {
+	n := &t.root
+	for i := 0; i < len(key); i++ {
+		if n.children == nil {
+			n.children = make(map[byte]*trieNode[V])
+		}
+		child, ok := n.children[key[i]]
+		if !ok {
+			child = &trieNode[V]{}
+			n.children[key[i]] = child
+		}
+		n = child
+	}
+	added := !n.hasValue
+	n.value, n.hasValue = value, true
+	if added {
+		t.size++
+	}
+	return added
+}
+
+// Get 返回 key 对应的值
+func (t *Trie[V]) Get(key string) (V, bool) {
+	n := t.find(key)
+	if n == nil || !n.hasValue {
+		var zero V
+		return zero, false
+	}
+	return n.value, true
+}
+
+// Delete 删除 key，key 存在时返回 true；不再需要的节点会被一并清理
+func (t *Trie[V]) Delete(key string) bool {
+	path := make([]*trieNode[V], 0, len(key)+1)
+	n := &t.root
+	path = append(path, n)
+	for i := 0; i < len(key); i++ {
+		n = n.children[key[i]]
+		if n == nil {
+			return false
+		}
+		path = append(path, n)
+	}
+	if !n.hasValue {
+		return false
+	}
+	var zero V
+	n.value, n.hasValue = zero, false
+	t.size--
+
+	// 从叶子向上删除没有值也没有子节点的节点
+	for i := len(key); i > 0; i-- {
+		node := path[i]
+		if node.hasValue || len(node.children) > 0 {
+			break
+		}
+		delete(path[i-1].children, key[i-1])
+	}
+	return true
+}
+
+// HasPrefix 判断是否存在以 prefix 开头的 key
+func (t *Trie[V]) HasPrefix(prefix string) bool {
+	n := t.find(prefix)
+	return n != nil && (n.hasValue || len(n.children) > 0)
+}
+
+// WalkPrefix 按字典序遍历所有以 prefix 开头的键值对，fn 返回 false 时停止遍历
+func (t *Trie[V]) WalkPrefix(prefix string, fn func(key string, value V) bool) {
+	n := t.find(prefix)
+	if n == nil {
+		return
+	}
+	buf := []byte(prefix)
+	walkTrie(n, &buf, fn)
+}
+
+// LongestPrefix 返回 s 的最长的、存在于树中的前缀及其值，例如路由 "/api/users/1" 匹配 "/api/users"
+func (t *Trie[V]) LongestPrefix(s string) (string, V, bool) {
+	var (
+		matched string
+		value   V
+		found   bool
+	)
+	n := &t.root
+	if n.hasValue {
+		value, found = n.value, true
+	}
+	for i := 0; i < len(s); i++ {
+		n = n.children[s[i]]
+		if n == nil {
+			break
+		}
+		if n.hasValue {
+			matched, value, found = s[:i+1], n.value, true
+		}
+	}
+	return matched, value, found
+}
+
+// Len 返回树中 key 的数量
+func (t *Trie[V]) Len() int {
+	return t.size
+}
+
+// find 返回 key 对应的节点，不存在时返回 nil
+func (t *Trie[V]) find(key string) *trieNode[V] {
+	n := &t.root
+	for i := 0; i < len(key); i++ {
+		n = n.children[key[i]]
+		if n == nil {
+			return nil
+		}
+	}
+	return n
+}
+
+// walkTrie 按字典序深度优先遍历 n 及其子节点，buf 为当前节点的 key，返回 false 表示已停止
+func walkTrie[V any](n *trieNode[V], buf *[]byte, fn func(key string, value V) bool) bool {
+	if n.hasValue && !fn(string(*buf), n.value) {
+		return false
+	}
+	keys := make([]byte, 0, len(n.children))
+	for b := range n.children {
+		keys = append(keys, b)
+	}
+	slices.Sort(keys)
+	for _, b := range keys {
+		*buf = append(*buf, b)
+		ok := walkTrie(n.children[b], buf, fn)
+		*buf = (*buf)[:len(*buf)-1]
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,132 @@
+package collections
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTrie(t *testing.T) {
+	tr := NewTrie[int]()
+	for i, k := range []string{"tea", "ten", "to", "inn", "in", "中文"} {
+		tr.Insert(k, i)
+	}
+	if tr.Insert("to", 100) {
+		t.Error("覆盖已有 key 的 Insert() = true, 期望 false")
+	}
+
+	tests := []struct {
+		name   string
+		key    string
+		want   int
+		wantOK bool
+	}{
+		{"存在", "tea", 0, true},
+		{"覆盖后的值", "to", 100, true},
+		{"多字节", "中文", 5, true},
+		{"只是前缀", "te", 0, false},
+		{"不存在", "x", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tr.Get(tt.key)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Get(%q) = %v, %v, 期望 %v, %v", tt.key, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	prefixTests := []struct {
+		prefix string
+		want   bool
+	}{
+		{"te", true},
+		{"in", true},
+		{"中", true},
+		{"tx", false},
+		{"", true},
+	}
+	for _, tt := range prefixTests {
+		if got := tr.HasPrefix(tt.prefix); got != tt.want {
+			t.Errorf("HasPrefix(%q) = %v, 期望 %v", tt.prefix, got, tt.want)
+		}
+	}
+	if tr.Len() != 6 {
+		t.Errorf("Len() = %v, 期望 %v", tr.Len(), 6)
+	}
+}
+
+func TestTrieWalkPrefix(t *testing.T) {
+	var tr Trie[bool]
+	for _, k := range []string{"tea", "ten", "to", "inn", "in", "t"} {
+		tr.Insert(k, true)
+	}
+	tests := []struct {
+		name   string
+		prefix string
+		limit  int
+		want   []string
+	}{
+		{"全部", "", 0, []string{"in", "inn", "t", "tea", "ten", "to"}},
+		{"指定前缀", "te", 0, []string{"tea", "ten"}},
+		{"提前停止", "t", 2, []string{"t", "tea"}},
+		{"不存在的前缀", "x", 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			tr.WalkPrefix(tt.prefix, func(key string, _ bool) bool {
+				got = append(got, key)
+				return tt.limit == 0 || len(got) < tt.limit
+			})
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("WalkPrefix(%q) = %v, 期望 %v", tt.prefix, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrieDelete(t *testing.T) {
+	var tr Trie[int]
+	tr.Insert("tea", 1)
+	tr.Insert("team", 2)
+
+	if !tr.Delete("team") || tr.Delete("team") || tr.Delete("te") {
+		t.Error("Delete() 结果错误")
+	}
+	if tr.HasPrefix("team") {
+		t.Error("删除后的节点没有被清理")
+	}
+	if _, ok := tr.Get("tea"); !ok {
+		t.Error("删除 team 不应影响 tea")
+	}
+	tr.Delete("tea")
+	if tr.HasPrefix("t") || tr.Len() != 0 {
+		t.Error("删除所有 key 后树不为空")
+	}
+}
+
+func TestTrieLongestPrefix(t *testing.T) {
+	var tr Trie[string]
+	tr.Insert("/api", "api")
+	tr.Insert("/api/users", "users")
+	tests := []struct {
+		name      string
+		s         string
+		wantKey   string
+		wantValue string
+		wantOK    bool
+	}{
+		{"最长匹配", "/api/users/1", "/api/users", "users", true},
+		{"较短匹配", "/api/orders", "/api", "api", true},
+		{"完全匹配", "/api", "/api", "api", true},
+		{"没有匹配", "/static", "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, value, ok := tr.LongestPrefix(tt.s)
+			if key != tt.wantKey || value != tt.wantValue || ok != tt.wantOK {
+				t.Errorf("LongestPrefix(%q) = %q, %q, %v, 期望 %q, %q, %v", tt.s, key, value, ok, tt.wantKey, tt.wantValue, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package collections
+
+const (
+	vectorBits  = 5
+	vectorWidth = 1 << vectorBits
+	vectorMask  = vectorWidth - 1
+)
+
+// vectorNode Vector 的 32 叉树节点，叶子节点保存值，内部节点保存子节点
+type vectorNode[T any] struct {
+	children []*vectorNode[T]
+	values   []T
+}
+
+// Vector 不可变的持久化向量，基于 32 叉树的结构共享实现
+// Append、Set 和 Slice 返回新版本而不修改原向量，新旧版本共享未改变的节点，复制开销为 O(log32 n)
+// 因此可以无锁地在 goroutine 之间共享，或保留历史版本用于撤销；零值为空向量
+// Slice 返回的向量与原向量共享底层节点，被切掉的元素在所有版本释放前不会被回收
+type Vector[T any] struct {
+	root   *vectorNode[T]
+	shift  uint // 根节点所在层的位移，叶子层为 0
+	offset int  // 第一个元素在树中的位置
+	length int
+}
+
+// NewVector 创建一个包含 values 的向量
+func NewVector[T any](values ...T) Vector[T] {
+	var v Vector[T]
+	return v.Append(values...)
+}
+
+// Len 返回元素数量
+func (v Vector[T]) Len() int {
+	return v.length
+}
+
+// Get 返回第 i 个元素，i 越界时 panic
+func (v Vector[T]) Get(i int) T {
+	if i < 0 || i >= v.length {
+		panic("collections: vector index out of range")
+	}
+	idx := v.offset + i
+	n := v.root
+	for s := v.shift; s > 0; s -= vectorBits {
+		n = n.children[(idx>>s)&vectorMask]
+	}
+	return n.values[idx&vectorMask]
+}
+
+// Set 返回第 i 个元素被替换为 value 的新向量，i 越界时 panic
+func (v Vector[T]) Set(i int, value T) Vector[T] {
+	if i < 0 || i >= v.length {
+		panic("collections: vector index out of range")
+	}
+	v.root = setVectorNode(v.root, v.shift, v.offset+i, value)
+	return v
+}
+
+// Append 返回在末尾追加 values 后的新向量
+func (v Vector[T]) Append(values ...T) Vector[T] {
+	for _, value := range values {
+		idx := v.offset + v.length
+		for v.root != nil && idx >= 1<<(v.shift+vectorBits) {
+			// 树已满，增加一层
+			v.root = &vectorNode[T]{children: []*vectorNode[T]{v.root}}
+			v.shift += vectorBits
+		}
+		v.root = setVectorNode(v.root, v.shift, idx, value)
+		v.length++
+	}
+	return v
+}
+
+// Slice 返回 [start, end) 范围内元素组成的新向量，范围越界时 panic
+func (v Vector[T]) Slice(start, end int) Vector[T] {
+	if start < 0 || end > v.length || start > end {
+		panic("collections: vector slice bounds out of range")
+	}
+	v.offset += start
+	v.length = end - start
+	return v
+}
+
+// Each 按顺序遍历所有元素，fn 返回 false 时停止遍历
+func (v Vector[T]) Each(fn func(i int, value T) bool) {
+	for i := range v.length {
+		if !fn(i, v.Get(i)) {
+			return
+		}
+	}
+}
+
+// ToSlice 按顺序返回所有元素的副本
+func (v Vector[T]) ToSlice() []T {
+	result := make([]T, 0, v.length)
+	v.Each(func(_ int, value T) bool {
+		result = append(result, value)
+		return true
+	})
+	return result
+}
+
+// setVectorNode 复制从 n 到 idx 所在叶子的路径并写入 value，缺失的节点会被创建，返回新的节点
+func setVectorNode[T any](n *vectorNode[T], shift uint, idx int, value T) *vectorNode[T] {
+	slot := (idx >> shift) & vectorMask
+	if shift == 0 {
+		var values []T
+		if n != nil {
+			values = n.values
+		}
+		values = append(make([]T, 0, max(len(values), slot+1)), values...)
+		for len(values) <= slot {
+			var zero T
+			values = append(values, zero)
+		}
+		values[slot] = value
+		return &vectorNode[T]{values: values}
+	}
+
+	var children []*vectorNode[T]
+	if n != nil {
+		children = n.children
+	}
+	children = append(make([]*vectorNode[T], 0, max(len(children), slot+1)), children...)
+	for len(children) <= slot {
+		children = append(children, nil)
+	}
+	children[slot] = setVectorNode(children[slot], shift-vectorBits, idx, value)
+	return &vectorNode[T]{children: children}
+}
@@ -0,0 +1,118 @@
+package collections
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+// rangeInts 返回 [0, n) 的整数切片
+func rangeInts(n int) []int {
+	s := make([]int, n)
+	for i := range s {
+		s[i] = i
+	}
+	return s
+}
+
+func TestVector(t *testing.T) {
+	tests := []struct {
+		name string
+		n    int
+	}{
+		{"空向量", 0},
+		{"单层", 20},
+		{"两层", 100},
+		{"三层", 2000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := rangeInts(tt.n)
+			v := NewVector(want...)
+			if v.Len() != tt.n {
+				t.Errorf("Len() = %v, 期望 %v", v.Len(), tt.n)
+			}
+			if got := v.ToSlice(); !slices.Equal(got, want) {
+				t.Errorf("ToSlice() 与期望不一致")
+			}
+		})
+	}
+}
+
+func TestVectorPersistence(t *testing.T) {
+	v1 := NewVector(rangeInts(100)...)
+	v2 := v1.Set(50, -1)
+	v3 := v1.Append(100, 101)
+	v4 := v3.Slice(10, 20)
+	v5 := v4.Append(99)
+	v6 := v4.Set(0, 42)
+
+	checks := []struct {
+		name string
+		v    Vector[int]
+		want []int
+	}{
+		{"原向量不变", v1, rangeInts(100)},
+		{"Set", v2, slices.Concat(rangeInts(50), []int{-1}, rangeInts(100)[51:])},
+		{"Append", v3, rangeInts(102)},
+		{"Slice", v4, rangeInts(20)[10:]},
+		{"Slice 后 Append", v5, append(rangeInts(20)[10:], 99)},
+		{"Slice 后 Set", v6, append([]int{42}, rangeInts(20)[11:]...)},
+	}
+	for _, tt := range checks {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.v.ToSlice(); !slices.Equal(got, tt.want) {
+				t.Errorf("ToSlice() = %v, 期望 %v", got, tt.want)
+			}
+		})
+	}
+	if v3.Get(20) != 20 {
+		t.Errorf("Slice 后 Append 不应影响原向量, Get(20) = %v", v3.Get(20))
+	}
+
+	var zero Vector[string]
+	if zero.Len() != 0 || len(zero.Append("a").ToSlice()) != 1 {
+		t.Error("零值向量应可直接使用")
+	}
+}
+
+func TestVectorConcurrentReads(t *testing.T) {
+	v := NewVector(rangeInts(1000)...)
+	var wg sync.WaitGroup
+	for g := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mine := v.Set(g, -g)
+			for i := range 1000 {
+				if i != g && mine.Get(i) != i {
+					t.Errorf("Get(%d) = %v, 期望 %v", i, mine.Get(i), i)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestVectorPanics(t *testing.T) {
+	v := NewVector(1, 2, 3)
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{"Get 越界", func() { v.Get(3) }},
+		{"Set 越界", func() { v.Set(-1, 0) }},
+		{"Slice 越界", func() { v.Slice(2, 4) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s 未 panic", tt.name)
+				}
+			}()
+			tt.fn()
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package concurrency
+
+import (
+	"sync/atomic"
+
+	"github.com/jiu-u/gogout/mathutils"
+)
+
+// Atomic 任意类型值的原子封装，基于 atomic.Pointer 实现，读写都不加锁
+// 适用于共享的配置快照等读多写少的场景；零值可直接使用，Load 返回 T 的零值
+// 注意：存入的值如果包含引用（切片、map、指针），其指向的数据不受保护，应当视为不可变
+type Atomic[T any] struct {
+	p atomic.Pointer[T]
+}
+
+// NewAtomic 创建一个初始值为 v 的 Atomic
+func NewAtomic[T any](v T) *Atomic[T] {
+	a := &Atomic[T]{}
+	a.Store(v)
+	return a
+}
+
+// Load 返回当前值
+func (a *Atomic[T]) Load() T {
+	if p := a.p.Load(); p != nil {
+		return *p
+	}
+	var zero T
+	return zero
+}
+
+// Store 设置新值
+func (a *Atomic[T]) Store(v T) {
+	a.p.Store(&v)
+}
+
+// Swap 设置新值并返回旧值
+func (a *Atomic[T]) Swap(v T) T {
+	if old := a.p.Swap(&v); old != nil {
+		return *old
+	}
+	var zero T
+	return zero
+}
+
+// CompareAndSwap 当前值等于 old 时设置为 v 并返回 true，否则返回 false
+// 与 atomic.Value 相同，T 的动态类型不可比较时会 panic
+func (a *Atomic[T]) CompareAndSwap(old, v T) bool {
+	for {
+		p := a.p.Load()
+		var cur T
+		if p != nil {
+			cur = *p
+		}
+		if any(cur) != any(old) {
+			return false
+		}
+		if a.p.CompareAndSwap(p, &v) {
+			return true
+		}
+	}
+}
+
+// Update 使用 fn 根据当前值计算新值并原子地设置，返回新值
+// 并发修改时 fn 可能被调用多次，因此 fn 不应有副作用
+func (a *Atomic[T]) Update(fn func(T) T) T {
+	for {
+		p := a.p.Load()
+		var cur T
+		if p != nil {
+			cur = *p
+		}
+		v := fn(cur)
+		if a.p.CompareAndSwap(p, &v) {
+			return v
+		}
+	}
+}
+
+// AtomicInt 任意整数类型的原子计数器，零值可直接使用
+type AtomicInt[T mathutils.Integer] struct {
+	v atomic.Int64
+}
+
+// Load 返回当前值
+func (a *AtomicInt[T]) Load() T {
+	return T(a.v.Load())
+}
+
+// Store 设置新值
+func (a *AtomicInt[T]) Store(v T) {
+	a.v.Store(int64(v))
+}
+
+// Add 增加 delta 并返回新值，溢出时按 T 的位宽回绕
+func (a *AtomicInt[T]) Add(delta T) T {
+	return T(a.v.Add(int64(delta)))
+}
+
+// Swap 设置新值并返回旧值
+func (a *AtomicInt[T]) Swap(v T) T {
+	return T(a.v.Swap(int64(v)))
+}
+
+// CompareAndSwap 当前值等于 old 时设置为 v 并返回 true
+func (a *AtomicInt[T]) CompareAndSwap(old, v T) bool {
+	for {
+		cur := a.v.Load()
+		if T(cur) != old {
+			return false
+		}
+		if a.v.CompareAndSwap(cur, int64(v)) {
+			return true
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package concurrency
+
+import (
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestAtomic(t *testing.T) {
+	t.Run("零值", func(t *testing.T) {
+		var a Atomic[string]
+		if v := a.Load(); v != "" {
+			t.Errorf("Load() = %q, 期望空字符串", v)
+		}
+		if old := a.Swap("x"); old != "" {
+			t.Errorf("Swap() = %q, 期望空字符串", old)
+		}
+	})
+
+	t.Run("读写", func(t *testing.T) {
+		type config struct {
+			Name    string
+			Timeout int
+		}
+		a := NewAtomic(config{Name: "a", Timeout: 1})
+		a.Store(config{Name: "b", Timeout: 2})
+		if v := a.Load(); v.Name != "b" || v.Timeout != 2 {
+			t.Errorf("Load() = %v, 期望 {b 2}", v)
+		}
+		if old := a.Swap(config{Name: "c"}); old.Name != "b" {
+			t.Errorf("Swap() = %v, 期望旧值 b", old)
+		}
+	})
+
+	t.Run("CompareAndSwap", func(t *testing.T) {
+		a := NewAtomic(1)
+		if a.CompareAndSwap(2, 3) {
+			t.Errorf("当前值不等于 old 时 CompareAndSwap() 应该返回 false")
+		}
+		if !a.CompareAndSwap(1, 3) || a.Load() != 3 {
+			t.Errorf("CompareAndSwap() 应该成功并设置为 3, 当前 %v", a.Load())
+		}
+		var zero Atomic[int]
+		if !zero.CompareAndSwap(0, 5) || zero.Load() != 5 {
+			t.Errorf("零值的 CompareAndSwap(0, 5) 应该成功")
+		}
+	})
+
+	t.Run("并发 Update", func(t *testing.T) {
+		var a Atomic[int]
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				a.Update(func(v int) int { return v + 1 })
+			}()
+		}
+		wg.Wait()
+		if v := a.Load(); v != 50 {
+			t.Errorf("Load() = %v, 期望 %v", v, 50)
+		}
+	})
+}
+
+func TestAtomicInt(t *testing.T) {
+	t.Run("并发 Add", func(t *testing.T) {
+		var counter AtomicInt[int32]
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				counter.Add(2)
+			}()
+		}
+		wg.Wait()
+		if v := counter.Load(); v != 200 {
+			t.Errorf("Load() = %v, 期望 %v", v, 200)
+		}
+	})
+
+	t.Run("无符号整数", func(t *testing.T) {
+		var counter AtomicInt[uint64]
+		counter.Store(math.MaxUint64)
+		if v := counter.Load(); v != math.MaxUint64 {
+			t.Errorf("Load() = %v, 期望 %v", v, uint64(math.MaxUint64))
+		}
+		if v := counter.Add(1); v != 0 {
+			t.Errorf("Add() = %v, 期望回绕为 0", v)
+		}
+	})
+
+	t.Run("Swap 和 CompareAndSwap", func(t *testing.T) {
+		var counter AtomicInt[int]
+		if old := counter.Swap(5); old != 0 {
+			t.Errorf("Swap() = %v, 期望 %v", old, 0)
+		}
+		if counter.CompareAndSwap(4, 6) {
+			t.Errorf("CompareAndSwap(4, 6) 应该失败")
+		}
+		if !counter.CompareAndSwap(5, 6) || counter.Load() != 6 {
+			t.Errorf("CompareAndSwap(5, 6) 应该成功")
+		}
+	})
+}
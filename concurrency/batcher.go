@@ -0,0 +1,130 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrBatcherClosed Batcher 已经关闭，不再接受新的元素
+var ErrBatcherClosed = errors.New("concurrency: batcher is closed")
+
+// Batcher 将逐个添加的元素攒成批次，在累计 maxSize 个元素或距离批次中第一个元素加入已过 maxWait 时
+// 调用 flush 处理，适用于批量写入数据库、上报分析数据等场景
+// flush 在 Batcher 内部的单个 goroutine 中串行调用，每次收到的切片归 flush 所有
+type Batcher[T any] struct {
+	maxSize int
+	maxWait time.Duration
+	flush   func(batch []T)
+
+	items chan T
+	done  chan struct{}
+
+	mu        sync.RWMutex // 保护 closed，与 adders 配合防止向已关闭的 items 发送
+	closed    bool
+	adders    sync.WaitGroup // 正在向 items 发送的 Add
+	closing   chan struct{}  // Close 开始时关闭，唤醒阻塞的 Add
+	closeOnce sync.Once
+}
+
+// NewBatcher 创建并启动一个 Batcher
+// maxSize <= 0 时按 1 处理；maxWait <= 0 时只按数量触发
+func NewBatcher[T any](maxSize int, maxWait time.Duration, flush func(batch []T)) *Batcher[T] {
+	b := &Batcher[T]{
+		maxSize: max(maxSize, 1),
+		maxWait: maxWait,
+		flush:   flush,
+		items:   make(chan T),
+		done:    make(chan struct{}),
+		closing: make(chan struct{}),
+	}
+	go b.run()
+	return b
+}
+
+// run 收集元素并在满足条件时调用 flush
+func (b *Batcher[T]) run() {
+	defer close(b.done)
+
+	batch := make([]T, 0, b.maxSize)
+	var timer *time.Timer
+	var timeout <-chan time.Time
+
+	emit := func() {
+		if timer != nil {
+			timer.Stop()
+			timer, timeout = nil, nil
+		}
+		if len(batch) == 0 {
+			return
+		}
+		b.flush(batch)
+		batch = make([]T, 0, b.maxSize)
+	}
+
+	for {
+		select {
+		case item, ok := <-b.items:
+			if !ok {
+				emit()
+				return
+			}
+			batch = append(batch, item)
+			if len(batch) >= b.maxSize {
+				emit()
+			} else if len(batch) == 1 && b.maxWait > 0 {
+				timer = time.NewTimer(b.maxWait)
+				timeout = timer.C
+			}
+		case <-timeout:
+			timer, timeout = nil, nil
+			emit()
+		}
+	}
+}
+
+// Add 添加一个元素，在 Batcher 接收之前阻塞（例如 flush 正在执行时）
+// ctx 结束时返回 ctx.Err()；Batcher 已关闭或在阻塞期间开始关闭时返回 ErrBatcherClosed
+func (b *Batcher[T]) Add(ctx context.Context, item T) error {
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		return ErrBatcherClosed
+	}
+	b.adders.Add(1)
+	b.mu.RUnlock()
+	defer b.adders.Done()
+
+	select {
+	case b.items <- item:
+		return nil
+	case <-b.closing:
+		return ErrBatcherClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close 停止接受新元素，将剩余的元素作为最后一批 flush，并等待其完成
+// ctx 在 flush 完成前结束时返回 ctx.Err()，剩余的 flush 仍会在后台完成；重复调用是安全的
+func (b *Batcher[T]) Close(ctx context.Context) error {
+	b.closeOnce.Do(func() {
+		close(b.closing)
+		b.mu.Lock()
+		b.closed = true
+		b.mu.Unlock()
+		go func() {
+			// 等待正在发送的 Add 退出后才能关闭 items
+			b.adders.Wait()
+			close(b.items)
+		}()
+	})
+
+	select {
+	case <-b.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
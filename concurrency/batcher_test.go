@@ -0,0 +1,120 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// batchRecorder 记录 flush 收到的批次
+type batchRecorder struct {
+	mu      sync.Mutex
+	batches [][]int
+}
+
+func (r *batchRecorder) flush(batch []int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.batches = append(r.batches, batch)
+}
+
+func (r *batchRecorder) get() [][]int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.batches
+}
+
+func TestBatcher(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("按数量触发", func(t *testing.T) {
+		var r batchRecorder
+		b := NewBatcher(3, time.Hour, r.flush)
+		for i := 1; i <= 7; i++ {
+			b.Add(ctx, i)
+		}
+		b.Close(ctx)
+		expected := [][]int{{1, 2, 3}, {4, 5, 6}, {7}}
+		if !reflect.DeepEqual(r.get(), expected) {
+			t.Errorf("批次 = %v, 期望 %v", r.get(), expected)
+		}
+	})
+
+	t.Run("按时间触发", func(t *testing.T) {
+		var r batchRecorder
+		b := NewBatcher(100, 20*time.Millisecond, r.flush)
+		b.Add(ctx, 1)
+		b.Add(ctx, 2)
+		time.Sleep(50 * time.Millisecond)
+		if expected := [][]int{{1, 2}}; !reflect.DeepEqual(r.get(), expected) {
+			t.Errorf("批次 = %v, 期望 %v", r.get(), expected)
+		}
+		b.Close(ctx)
+	})
+
+	t.Run("关闭后拒绝新元素", func(t *testing.T) {
+		var r batchRecorder
+		b := NewBatcher(10, 0, r.flush)
+		b.Close(ctx)
+		if err := b.Add(ctx, 1); !errors.Is(err, ErrBatcherClosed) {
+			t.Errorf("Add() = %v, 期望 ErrBatcherClosed", err)
+		}
+		if err := b.Close(ctx); err != nil {
+			t.Errorf("重复 Close() = %v, 期望 nil", err)
+		}
+		if len(r.get()) != 0 {
+			t.Errorf("没有元素时不应该调用 flush")
+		}
+	})
+
+	t.Run("Add 响应取消", func(t *testing.T) {
+		block := make(chan struct{})
+		b := NewBatcher(1, 0, func([]int) { <-block })
+		b.Add(ctx, 1) // flush 阻塞中
+		timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		if err := b.Add(timeoutCtx, 2); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Add() = %v, 期望 DeadlineExceeded", err)
+		}
+		close(block)
+		b.Close(ctx)
+	})
+
+	t.Run("flush 阻塞时 Close 响应 ctx", func(t *testing.T) {
+		block := make(chan struct{})
+		defer close(block)
+		b := NewBatcher(1, 0, func([]int) { <-block })
+		b.Add(ctx, 1) // flush 阻塞中
+		addErr := make(chan error, 1)
+		go func() {
+			addErr <- b.Add(ctx, 2)
+		}()
+		time.Sleep(10 * time.Millisecond)
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		closeErr := make(chan error, 1)
+		go func() {
+			closeErr <- b.Close(timeoutCtx)
+		}()
+		select {
+		case err := <-closeErr:
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("Close() = %v, 期望 DeadlineExceeded", err)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("Close() 在 ctx 超时后仍未返回")
+		}
+		select {
+		case err := <-addErr:
+			if !errors.Is(err, ErrBatcherClosed) {
+				t.Errorf("阻塞的 Add() = %v, 期望 ErrBatcherClosed", err)
+			}
+		case <-time.After(time.Second):
+			t.Errorf("阻塞的 Add() 在关闭后仍未返回")
+		}
+	})
+}
@@ -0,0 +1,206 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrBrokerClosed Broker 已经关闭
+var ErrBrokerClosed = errors.New("concurrency: broker is closed")
+
+// SlowPolicy 订阅者的缓冲区已满（消费过慢）时的处理策略
+type SlowPolicy int
+
+const (
+	// DropNewest 丢弃新发布的消息（默认），不影响发布者和其他订阅者
+	DropNewest SlowPolicy = iota
+	// DropOldest 丢弃缓冲区中最旧的消息，为新消息腾出位置
+	DropOldest
+	// Block 阻塞发布者直到订阅者有空位、订阅者退订或发布的 ctx 结束
+	Block
+)
+
+// subscribeConfig Subscribe 的配置
+type subscribeConfig struct {
+	bufferSize int
+	policy     SlowPolicy
+}
+
+// SubscribeOption Subscribe 的可选配置项
+type SubscribeOption func(*subscribeConfig)
+
+// WithBufferSize 设置订阅 channel 的缓冲区大小，默认为 16
+// DropOldest 策略需要缓冲区来保存待丢弃的消息，size 小于 1 时按 1 处理
+func WithBufferSize(size int) SubscribeOption {
+	return func(c *subscribeConfig) {
+		c.bufferSize = size
+	}
+}
+
+// WithSlowPolicy 设置缓冲区已满时的处理策略，默认为 DropNewest
+func WithSlowPolicy(policy SlowPolicy) SubscribeOption {
+	return func(c *subscribeConfig) {
+		c.policy = policy
+	}
+}
+
+// subscriber 一个订阅者
+type subscriber[T any] struct {
+	ch     chan T
+	policy SlowPolicy
+	done   chan struct{} // 退订时关闭，用于唤醒阻塞的发布者
+
+	mu     sync.RWMutex // 发送时持有读锁，关闭 ch 时持有写锁
+	closed bool
+	once   sync.Once
+}
+
+// send 按策略向订阅者发送消息
+func (s *subscriber[T]) send(ctx context.Context, v T) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return nil
+	}
+
+	switch s.policy {
+	case Block:
+		select {
+		case s.ch <- v:
+		case <-s.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	case DropOldest:
+		for {
+			select {
+			case s.ch <- v:
+				return nil
+			default:
+			}
+			// 缓冲区已满，丢弃最旧的一条后重试
+			select {
+			case <-s.ch:
+			default:
+			}
+		}
+	default:
+		select {
+		case s.ch <- v:
+		default:
+		}
+	}
+	return nil
+}
+
+// close 关闭订阅者的 channel，重复调用是安全的
+func (s *subscriber[T]) close() {
+	s.once.Do(func() {
+		// 先关闭 done 唤醒阻塞中的发送，再获取写锁关闭 ch
+		close(s.done)
+		s.mu.Lock()
+		s.closed = true
+		close(s.ch)
+		s.mu.Unlock()
+	})
+}
+
+// Broker 进程内的发布/订阅中心，将发布的消息广播给所有订阅者
+// 每个订阅者有独立的缓冲区和慢消费处理策略，一个订阅者消费过慢不会拖慢其他订阅者（Block 策略除外）
+type Broker[T any] struct {
+	mu     sync.RWMutex
+	subs   map[<-chan T]*subscriber[T]
+	closed bool
+}
+
+// NewBroker 创建一个 Broker
+func NewBroker[T any]() *Broker[T] {
+	return &Broker[T]{subs: make(map[<-chan T]*subscriber[T])}
+}
+
+// Subscribe 订阅消息，返回接收消息的 channel
+// 退订或 Broker 关闭时该 channel 会被关闭；Broker 已关闭时返回一个已关闭的 channel
+func (b *Broker[T]) Subscribe(opts ...SubscribeOption) <-chan T {
+	cfg := subscribeConfig{bufferSize: 16, policy: DropNewest}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	size := max(cfg.bufferSize, 0)
+	if cfg.policy == DropOldest {
+		size = max(size, 1)
+	}
+	sub := &subscriber[T]{
+		ch:     make(chan T, size),
+		policy: cfg.policy,
+		done:   make(chan struct{}),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		sub.close()
+		return sub.ch
+	}
+	b.subs[sub.ch] = sub
+	return sub.ch
+}
+
+// Unsubscribe 取消订阅并关闭对应的 channel，对未知或已退订的 channel 不做任何操作
+func (b *Broker[T]) Unsubscribe(ch <-chan T) {
+	b.mu.Lock()
+	sub, ok := b.subs[ch]
+	delete(b.subs, ch)
+	b.mu.Unlock()
+	if ok {
+		sub.close()
+	}
+}
+
+// Publish 将 v 发送给所有订阅者
+// 只有 Block 策略的订阅者会让 Publish 阻塞，此时 ctx 结束会返回 ctx.Err()（其他订阅者仍会收到消息）
+// Broker 已关闭时返回 ErrBrokerClosed
+func (b *Broker[T]) Publish(ctx context.Context, v T) error {
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		return ErrBrokerClosed
+	}
+	subs := make([]*subscriber[T], 0, len(b.subs))
+	for _, sub := range b.subs {
+		subs = append(subs, sub)
+	}
+	b.mu.RUnlock()
+
+	var firstErr error
+	for _, sub := range subs {
+		if err := sub.send(ctx, v); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Len 返回当前订阅者的数量
+func (b *Broker[T]) Len() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subs)
+}
+
+// Close 关闭 Broker 并关闭所有订阅者的 channel，之后的 Publish 返回 ErrBrokerClosed
+func (b *Broker[T]) Close() {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.closed = true
+	subs := b.subs
+	b.subs = make(map[<-chan T]*subscriber[T])
+	b.mu.Unlock()
+
+	for _, sub := range subs {
+		sub.close()
+	}
+}
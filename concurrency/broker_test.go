@@ -0,0 +1,128 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBroker(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("广播给所有订阅者", func(t *testing.T) {
+		b := NewBroker[string]()
+		sub1 := b.Subscribe()
+		sub2 := b.Subscribe()
+		if err := b.Publish(ctx, "hello"); err != nil {
+			t.Fatalf("Publish() 返回错误: %v", err)
+		}
+		for i, sub := range []<-chan string{sub1, sub2} {
+			if v := <-sub; v != "hello" {
+				t.Errorf("订阅者 %d 收到 %v, 期望 %v", i, v, "hello")
+			}
+		}
+	})
+
+	t.Run("丢弃新消息", func(t *testing.T) {
+		b := NewBroker[int]()
+		sub := b.Subscribe(WithBufferSize(2))
+		for i := 1; i <= 4; i++ {
+			b.Publish(ctx, i)
+		}
+		b.Close()
+		var got []int
+		for v := range sub {
+			got = append(got, v)
+		}
+		if len(got) != 2 || got[0] != 1 || got[1] != 2 {
+			t.Errorf("收到 %v, 期望 [1 2]", got)
+		}
+	})
+
+	t.Run("丢弃旧消息", func(t *testing.T) {
+		b := NewBroker[int]()
+		sub := b.Subscribe(WithBufferSize(2), WithSlowPolicy(DropOldest))
+		for i := 1; i <= 4; i++ {
+			b.Publish(ctx, i)
+		}
+		b.Close()
+		var got []int
+		for v := range sub {
+			got = append(got, v)
+		}
+		if len(got) != 2 || got[0] != 3 || got[1] != 4 {
+			t.Errorf("收到 %v, 期望 [3 4]", got)
+		}
+	})
+
+	t.Run("丢弃旧消息且缓冲区为 0", func(t *testing.T) {
+		b := NewBroker[int]()
+		sub := b.Subscribe(WithBufferSize(0), WithSlowPolicy(DropOldest))
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for i := 1; i <= 3; i++ {
+				b.Publish(ctx, i)
+			}
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatalf("Publish() 在缓冲区为 0 时被阻塞")
+		}
+		b.Close()
+		var got []int
+		for v := range sub {
+			got = append(got, v)
+		}
+		if len(got) != 1 || got[0] != 3 {
+			t.Errorf("收到 %v, 期望 [3]", got)
+		}
+	})
+
+	t.Run("阻塞策略", func(t *testing.T) {
+		b := NewBroker[int]()
+		b.Subscribe(WithBufferSize(0), WithSlowPolicy(Block))
+		timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		if err := b.Publish(timeoutCtx, 1); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Publish() = %v, 期望 DeadlineExceeded", err)
+		}
+	})
+
+	t.Run("退订唤醒阻塞的发布者", func(t *testing.T) {
+		b := NewBroker[int]()
+		sub := b.Subscribe(WithBufferSize(0), WithSlowPolicy(Block))
+		done := make(chan error)
+		go func() { done <- b.Publish(ctx, 1) }()
+		time.Sleep(10 * time.Millisecond)
+		b.Unsubscribe(sub)
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Errorf("Publish() = %v, 期望 nil", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("退订后 Publish() 应该返回")
+		}
+		if _, ok := <-sub; ok {
+			t.Errorf("退订后 channel 应该被关闭")
+		}
+		if b.Len() != 0 {
+			t.Errorf("Len() = %v, 期望 %v", b.Len(), 0)
+		}
+	})
+
+	t.Run("关闭后", func(t *testing.T) {
+		b := NewBroker[int]()
+		b.Close()
+		if err := b.Publish(ctx, 1); !errors.Is(err, ErrBrokerClosed) {
+			t.Errorf("Publish() = %v, 期望 ErrBrokerClosed", err)
+		}
+		if _, ok := <-b.Subscribe(); ok {
+			t.Errorf("关闭后 Subscribe() 应该返回已关闭的 channel")
+		}
+		b.Close()
+	})
+}
@@ -0,0 +1,59 @@
+package concurrency
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/jiu-u/gogout/chanutils"
+)
+
+// FanOut 将 in 中的值分发到 n 个输出 channel，每个值只会被发送到其中一个输出
+// 哪个输出的消费者先准备好就由哪个接收，适用于把同一个输入分给多个 worker 并行处理；
+// 所有输出共用一个转发循环，因此某个消费者停止接收不会扣留值，但所有消费者都未就绪时会阻塞读取 in
+// in 关闭或 ctx 取消后所有输出 channel 都会被关闭；n <= 0 时按 1 处理
+func FanOut[T any](ctx context.Context, in <-chan T, n int) []<-chan T {
+	n = max(n, 1)
+	chans := make([]chan T, n)
+	outs := make([]<-chan T, n)
+	// cases[0] 监听 ctx，其余为向各输出发送
+	cases := make([]reflect.SelectCase, n+1)
+	cases[0] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())}
+	for i := range chans {
+		chans[i] = make(chan T)
+		outs[i] = chans[i]
+		cases[i+1] = reflect.SelectCase{Dir: reflect.SelectSend, Chan: reflect.ValueOf(chans[i])}
+	}
+
+	go func() {
+		defer func() {
+			for _, c := range chans {
+				close(c)
+			}
+		}()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-in:
+				if !ok {
+					return
+				}
+				// 通过指针取值，使 T 为接口类型且 v 为 nil 时也能得到类型正确的 reflect.Value
+				send := reflect.ValueOf(&v).Elem()
+				for i := 1; i <= n; i++ {
+					cases[i].Send = send
+				}
+				if chosen, _, _ := reflect.Select(cases); chosen == 0 {
+					return
+				}
+			}
+		}
+	}()
+	return outs
+}
+
+// FanIn 将多个输入 channel 合并为一个输出 channel，值的顺序不保证
+// 所有输入都关闭或 ctx 取消后输出 channel 会被关闭，等同于 chanutils.Merge
+func FanIn[T any](ctx context.Context, chans ...<-chan T) <-chan T {
+	return chanutils.Merge(ctx, chans...)
+}
@@ -0,0 +1,91 @@
+package concurrency
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestFanOutFanIn(t *testing.T) {
+	t.Run("分发后合并", func(t *testing.T) {
+		ctx := context.Background()
+		in := make(chan int)
+		go func() {
+			defer close(in)
+			for i := 0; i < 100; i++ {
+				in <- i
+			}
+		}()
+
+		outs := FanOut(ctx, in, 4)
+		if len(outs) != 4 {
+			t.Fatalf("FanOut() 输出数量 = %v, 期望 %v", len(outs), 4)
+		}
+
+		var results []int
+		for v := range FanIn(ctx, outs...) {
+			results = append(results, v)
+		}
+		sort.Ints(results)
+		if len(results) != 100 {
+			t.Fatalf("结果数量 = %v, 期望 %v", len(results), 100)
+		}
+		for i, v := range results {
+			if v != i {
+				t.Fatalf("结果[%d] = %v, 期望 %v", i, v, i)
+			}
+		}
+	})
+
+	t.Run("不会把值扣留给不接收的消费者", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		in := make(chan int)
+		go func() {
+			defer close(in)
+			for i := 0; i < 10; i++ {
+				in <- i
+			}
+		}()
+		// 只从第一个输出接收，第二个输出的消费者一直不就绪
+		outs := FanOut(ctx, in, 2)
+		var count int
+		for range outs[0] {
+			count++
+		}
+		if ctx.Err() != nil || count != 10 {
+			t.Errorf("收到 %v 个值, ctx.Err() = %v, 期望 10 个且未超时", count, ctx.Err())
+		}
+	})
+
+	t.Run("接口类型的 nil 值", func(t *testing.T) {
+		in := make(chan error, 1)
+		in <- nil
+		close(in)
+		if err, ok := <-FanOut(context.Background(), in, 2)[1]; !ok || err != nil {
+			t.Errorf("收到 %v, %v, 期望 nil, true", err, ok)
+		}
+	})
+
+	t.Run("取消后关闭输出", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		in := make(chan int) // 永不关闭
+		merged := FanIn(ctx, FanOut(ctx, in, 2)...)
+		cancel()
+		select {
+		case _, ok := <-merged:
+			if ok {
+				t.Errorf("取消后不应再收到值")
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("取消后输出 channel 没有关闭")
+		}
+	})
+
+	t.Run("没有输入", func(t *testing.T) {
+		if _, ok := <-FanIn[int](context.Background()); ok {
+			t.Errorf("没有输入时输出应该立即关闭")
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package concurrency
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jiu-u/gogout/funcutils"
+)
+
+// Group 带类型结果的任务组，类似 errgroup.Group，但会按提交顺序收集每个任务的结果
+// 任意任务返回错误时会取消组内的 ctx，通知其他任务尽快退出
+type Group[T any] struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	sem    chan struct{} // 并发限制，为 nil 时不限制
+	wg     sync.WaitGroup
+
+	mu      sync.Mutex
+	results []T
+	err     error
+}
+
+// NewGroup 创建一个任务组，任务收到的 ctx 派生自 ctx
+// limit 为同时运行的最大任务数，limit <= 0 表示不限制
+func NewGroup[T any](ctx context.Context, limit int) *Group[T] {
+	ctx, cancel := context.WithCancel(ctx)
+	g := &Group[T]{ctx: ctx, cancel: cancel}
+	if limit > 0 {
+		g.sem = make(chan struct{}, limit)
+	}
+	return g
+}
+
+// Go 提交一个任务，达到并发上限时阻塞直到有任务完成
+// 任务中发生的 panic 会被恢复并作为错误处理
+func (g *Group[T]) Go(fn func(ctx context.Context) (T, error)) {
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+
+	g.mu.Lock()
+	idx := len(g.results)
+	var zero T
+	g.results = append(g.results, zero)
+	g.mu.Unlock()
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if g.sem != nil {
+			defer func() { <-g.sem }()
+		}
+
+		result, err := funcutils.Try1(func() (T, error) {
+			return fn(g.ctx)
+		})
+
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		g.results[idx] = result
+		if err != nil && g.err == nil {
+			g.err = err
+			g.cancel()
+		}
+	}()
+}
+
+// Wait 等待所有任务完成，按提交顺序返回结果以及第一个发生的错误
+// 出错时结果切片仍会返回，其中失败或被取消的任务对应位置为其返回值（通常为零值）
+func (g *Group[T]) Wait() ([]T, error) {
+	g.wg.Wait()
+	g.cancel()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.results, g.err
+}
@@ -0,0 +1,82 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGroup(t *testing.T) {
+	t.Run("按提交顺序返回结果", func(t *testing.T) {
+		g := NewGroup[int](context.Background(), 0)
+		for i := 0; i < 5; i++ {
+			g.Go(func(ctx context.Context) (int, error) {
+				time.Sleep(time.Duration(5-i) * time.Millisecond)
+				return i * i, nil
+			})
+		}
+		results, err := g.Wait()
+		if err != nil {
+			t.Fatalf("Wait() 返回错误: %v", err)
+		}
+		if expected := []int{0, 1, 4, 9, 16}; !reflect.DeepEqual(results, expected) {
+			t.Errorf("Wait() = %v, 期望 %v", results, expected)
+		}
+	})
+
+	t.Run("第一个错误取消其他任务", func(t *testing.T) {
+		errBoom := errors.New("boom")
+		g := NewGroup[string](context.Background(), 0)
+		g.Go(func(ctx context.Context) (string, error) {
+			return "", errBoom
+		})
+		g.Go(func(ctx context.Context) (string, error) {
+			select {
+			case <-ctx.Done():
+				return "", ctx.Err()
+			case <-time.After(time.Second):
+				return "slow", nil
+			}
+		})
+		_, err := g.Wait()
+		if !errors.Is(err, errBoom) {
+			t.Errorf("Wait() = %v, 期望 boom", err)
+		}
+	})
+
+	t.Run("限制并发数", func(t *testing.T) {
+		g := NewGroup[struct{}](context.Background(), 2)
+		var running, peak atomic.Int32
+		for i := 0; i < 10; i++ {
+			g.Go(func(ctx context.Context) (struct{}, error) {
+				n := running.Add(1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				time.Sleep(2 * time.Millisecond)
+				running.Add(-1)
+				return struct{}{}, nil
+			})
+		}
+		if _, err := g.Wait(); err != nil {
+			t.Fatalf("Wait() 返回错误: %v", err)
+		}
+		if peak.Load() > 2 {
+			t.Errorf("最大并发数 = %v, 期望不超过 %v", peak.Load(), 2)
+		}
+	})
+
+	t.Run("panic 转换为错误", func(t *testing.T) {
+		g := NewGroup[int](context.Background(), 1)
+		g.Go(func(ctx context.Context) (int, error) { panic("oops") })
+		if _, err := g.Wait(); err == nil {
+			t.Errorf("Wait() 应该返回 panic 转换的错误")
+		}
+	})
+}
@@ -0,0 +1,81 @@
+package concurrency
+
+import "sync"
+
+// keyedEntry 单个 key 的互斥锁及其引用计数
+type keyedEntry struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// KeyedMutex 按 key 加锁的互斥锁，不同 key 之间互不阻塞
+// 每个 key 的锁在没有持有者和等待者时会被自动清理，不会无限增长；零值可直接使用
+type KeyedMutex[K comparable] struct {
+	mu      sync.Mutex
+	entries map[K]*keyedEntry
+}
+
+// NewKeyedMutex 创建一个 KeyedMutex
+func NewKeyedMutex[K comparable]() *KeyedMutex[K] {
+	return &KeyedMutex[K]{}
+}
+
+// acquire 获取 key 对应的条目并增加引用计数
+func (m *KeyedMutex[K]) acquire(key K) *keyedEntry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.entries == nil {
+		m.entries = make(map[K]*keyedEntry)
+	}
+	e, ok := m.entries[key]
+	if !ok {
+		e = &keyedEntry{}
+		m.entries[key] = e
+	}
+	e.refs++
+	return e
+}
+
+// release 减少引用计数，没有引用时删除条目
+func (m *KeyedMutex[K]) release(key K, e *keyedEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e.refs--
+	if e.refs == 0 {
+		delete(m.entries, key)
+	}
+}
+
+// Lock 锁定 key，如果 key 已被锁定则阻塞直到可用
+func (m *KeyedMutex[K]) Lock(key K) {
+	m.acquire(key).mu.Lock()
+}
+
+// TryLock 尝试锁定 key，成功返回 true，key 已被锁定时立即返回 false
+func (m *KeyedMutex[K]) TryLock(key K) bool {
+	e := m.acquire(key)
+	if e.mu.TryLock() {
+		return true
+	}
+	m.release(key, e)
+	return false
+}
+
+// Unlock 解锁 key；解锁未锁定的 key 会 panic
+func (m *KeyedMutex[K]) Unlock(key K) {
+	m.mu.Lock()
+	e, ok := m.entries[key]
+	m.mu.Unlock()
+	if !ok {
+		panic("concurrency: unlock of unlocked key")
+	}
+	e.mu.Unlock()
+	m.release(key, e)
+}
+
+// Len 返回当前被持有或等待中的 key 数量
+func (m *KeyedMutex[K]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.entries)
+}
@@ -0,0 +1,83 @@
+package concurrency
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestKeyedMutex(t *testing.T) {
+	t.Run("同一个 key 互斥", func(t *testing.T) {
+		var m KeyedMutex[string]
+		var wg sync.WaitGroup
+		counter := 0
+		for range 100 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				m.Lock("a")
+				counter++
+				m.Unlock("a")
+			}()
+		}
+		wg.Wait()
+		if counter != 100 {
+			t.Errorf("counter = %v, 期望 %v", counter, 100)
+		}
+		if m.Len() != 0 {
+			t.Errorf("Len() = %v, 期望 %v", m.Len(), 0)
+		}
+	})
+
+	t.Run("不同 key 互不阻塞", func(t *testing.T) {
+		m := NewKeyedMutex[int]()
+		m.Lock(1)
+		done := make(chan struct{})
+		go func() {
+			m.Lock(2)
+			m.Unlock(2)
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("锁定其他 key 被阻塞")
+		}
+		m.Unlock(1)
+	})
+
+	t.Run("TryLock", func(t *testing.T) {
+		m := NewKeyedMutex[string]()
+		tests := []struct {
+			name string
+			key  string
+			want bool
+		}{
+			{"首次锁定", "a", true},
+			{"重复锁定", "a", false},
+			{"其他 key", "b", true},
+		}
+		for _, tt := range tests {
+			if got := m.TryLock(tt.key); got != tt.want {
+				t.Errorf("%s: TryLock(%q) = %v, 期望 %v", tt.name, tt.key, got, tt.want)
+			}
+		}
+		if m.Len() != 2 {
+			t.Errorf("Len() = %v, 期望 %v", m.Len(), 2)
+		}
+		m.Unlock("a")
+		m.Unlock("b")
+		if m.Len() != 0 {
+			t.Errorf("Len() = %v, 期望 %v", m.Len(), 0)
+		}
+	})
+
+	t.Run("解锁未锁定的 key", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Unlock() 未 panic")
+			}
+		}()
+		NewKeyedMutex[string]().Unlock("x")
+	})
+}
@@ -0,0 +1,83 @@
+package concurrency
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/jiu-u/gogout/errorutils"
+	"github.com/jiu-u/gogout/funcutils"
+)
+
+// ParallelMap 使用最多 workers 个 goroutine 并发地对 input 中的每个元素执行 fn，结果按 input 的顺序排列
+// workers <= 0 时使用 runtime.GOMAXPROCS(0)；fn 中的 panic 会被转换为 *funcutils.PanicError
+// 某个元素出错不会中断其他元素，失败元素在结果中为 fn 的返回值（通常为零值），所有错误按下标顺序以
+// *errorutils.Multi 返回，每个错误的格式为 "concurrency: element i: ..."
+// ctx 结束后不再开始处理新的元素，未处理的元素为零值，并在错误中附加 ctx.Err()
+func ParallelMap[T, R any](ctx context.Context, input []T, workers int, fn func(ctx context.Context, v T) (R, error)) ([]R, error) {
+	results := make([]R, len(input))
+	if len(input) == 0 {
+		return results, nil
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, len(input))
+
+	var (
+		next    atomic.Int64
+		skipped atomic.Bool
+		wg      sync.WaitGroup
+	)
+	errs := make([]error, len(input))
+	wg.Add(workers)
+	for range workers {
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= len(input) {
+					return
+				}
+				if ctx.Err() != nil {
+					skipped.Store(true)
+					return
+				}
+				results[i], errs[i] = funcutils.Try1(func() (R, error) {
+					return fn(ctx, input[i])
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	var multi errorutils.Multi
+	for i, err := range errs {
+		if err != nil {
+			multi.Append(fmt.Errorf("concurrency: element %d: %w", i, err))
+		}
+	}
+	if skipped.Load() {
+		multi.Append(ctx.Err())
+	}
+	return results, multi.ErrorOrNil()
+}
+
+// ParallelFilter 使用最多 workers 个 goroutine 并发地对 input 中的每个元素执行 predicate，
+// 按 input 的顺序返回 predicate 返回 true 的元素；predicate 出错或未被执行的元素不会出现在结果中
+// 并发数、panic、错误和 ctx 的处理与 ParallelMap 相同
+func ParallelFilter[T any](ctx context.Context, input []T, workers int, predicate func(ctx context.Context, v T) (bool, error)) ([]T, error) {
+	keep, err := ParallelMap(ctx, input, workers, func(ctx context.Context, v T) (bool, error) {
+		ok, err := predicate(ctx, v)
+		return ok && err == nil, err
+	})
+	result := make([]T, 0, len(input))
+	for i, ok := range keep {
+		if ok {
+			result = append(result, input[i])
+		}
+	}
+	return result, err
+}
@@ -0,0 +1,131 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jiu-u/gogout/errorutils"
+	"github.com/jiu-u/gogout/funcutils"
+)
+
+func TestParallelMap(t *testing.T) {
+	t.Run("保持输入顺序", func(t *testing.T) {
+		input := []int{5, 1, 4, 2, 3}
+		result, err := ParallelMap(context.Background(), input, 3, func(ctx context.Context, n int) (string, error) {
+			// 让后面的元素先完成
+			time.Sleep(time.Duration(n) * time.Millisecond)
+			return strconv.Itoa(n * 10), nil
+		})
+		expected := []string{"50", "10", "40", "20", "30"}
+		if err != nil || !reflect.DeepEqual(result, expected) {
+			t.Errorf("ParallelMap() = %v, %v, 期望 %v", result, err, expected)
+		}
+	})
+
+	t.Run("限制并发数", func(t *testing.T) {
+		var running, peak atomic.Int32
+		input := make([]int, 20)
+		_, err := ParallelMap(context.Background(), input, 4, func(ctx context.Context, n int) (int, error) {
+			cur := running.Add(1)
+			for {
+				old := peak.Load()
+				if cur <= old || peak.CompareAndSwap(old, cur) {
+					break
+				}
+			}
+			time.Sleep(2 * time.Millisecond)
+			running.Add(-1)
+			return n, nil
+		})
+		if err != nil || peak.Load() > 4 || peak.Load() < 2 {
+			t.Errorf("最大并发数 = %v, %v, 期望在 [2, 4] 之间", peak.Load(), err)
+		}
+	})
+
+	t.Run("收集所有错误和 panic", func(t *testing.T) {
+		errOdd := errors.New("odd")
+		result, err := ParallelMap(context.Background(), []int{1, 2, 3, 4}, 0, func(ctx context.Context, n int) (int, error) {
+			if n == 4 {
+				panic("boom")
+			}
+			if n%2 == 1 {
+				return 0, errOdd
+			}
+			return n * 2, nil
+		})
+		if !reflect.DeepEqual(result, []int{0, 4, 0, 0}) {
+			t.Errorf("ParallelMap() = %v, 期望 %v", result, []int{0, 4, 0, 0})
+		}
+		var multi *errorutils.Multi
+		if !errors.As(err, &multi) || multi.Len() != 3 {
+			t.Fatalf("ParallelMap() error = %v, 期望包含 3 个错误的 *errorutils.Multi", err)
+		}
+		if !errors.Is(err, errOdd) {
+			t.Errorf("ParallelMap() error = %v, 期望包含 odd", err)
+		}
+		var panicErr *funcutils.PanicError
+		if !errors.As(err, &panicErr) {
+			t.Errorf("ParallelMap() error = %v, 期望包含 *funcutils.PanicError", err)
+		}
+		if msg := multi.Errors()[0].Error(); msg != "concurrency: element 0: odd" {
+			t.Errorf("第一个错误 = %q, 期望 %q", msg, "concurrency: element 0: odd")
+		}
+	})
+
+	t.Run("ctx 取消后不再处理新元素", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		var calls atomic.Int32
+		result, err := ParallelMap(ctx, make([]int, 100), 2, func(ctx context.Context, n int) (int, error) {
+			if calls.Add(1) == 3 {
+				cancel()
+			}
+			return 1, nil
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("ParallelMap() error = %v, 期望 %v", err, context.Canceled)
+		}
+		if c := calls.Load(); c >= 100 || c < 3 {
+			t.Errorf("fn 调用了 %v 次, 期望在取消后停止", c)
+		}
+		if len(result) != 100 {
+			t.Errorf("len(ParallelMap()) = %v, 期望 %v", len(result), 100)
+		}
+	})
+
+	t.Run("空输入", func(t *testing.T) {
+		result, err := ParallelMap(context.Background(), []int{}, 4, func(ctx context.Context, n int) (int, error) {
+			return n, nil
+		})
+		if result == nil || len(result) != 0 || err != nil {
+			t.Errorf("ParallelMap(空) = %#v, %v, 期望空切片和 nil", result, err)
+		}
+	})
+}
+
+func TestParallelFilter(t *testing.T) {
+	errBad := errors.New("bad")
+	result, err := ParallelFilter(context.Background(), []int{1, 2, 3, 4, 5, 6}, 3, func(ctx context.Context, n int) (bool, error) {
+		if n == 6 {
+			return true, errBad
+		}
+		return n%2 == 0, nil
+	})
+	if !reflect.DeepEqual(result, []int{2, 4}) {
+		t.Errorf("ParallelFilter() = %v, 期望 %v", result, []int{2, 4})
+	}
+	if !errors.Is(err, errBad) {
+		t.Errorf("ParallelFilter() error = %v, 期望包含 bad", err)
+	}
+
+	words, err := ParallelFilter(context.Background(), []string{"a", "", "b"}, 0, func(ctx context.Context, s string) (bool, error) {
+		return s != "", nil
+	})
+	if err != nil || !reflect.DeepEqual(words, []string{"a", "b"}) {
+		t.Errorf("ParallelFilter() = %v, %v, 期望 %v", words, err, []string{"a", "b"})
+	}
+}
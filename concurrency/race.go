@@ -0,0 +1,76 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jiu-u/gogout/errorutils"
+	"github.com/jiu-u/gogout/funcutils"
+)
+
+// ErrNoFuncs 没有提供任何待执行的函数
+var ErrNoFuncs = errors.New("concurrency: no functions given")
+
+// raceResult 单个函数的执行结果
+type raceResult[T any] struct {
+	val T
+	err error
+}
+
+// Race 并发执行所有函数，返回最先成功的结果并取消其余函数
+// 所有函数都失败时以 *errorutils.Multi 返回所有错误；panic 会被转换为 *funcutils.PanicError
+// 返回前不会等待被取消的函数退出，函数应响应 ctx 的取消
+func Race[T any](ctx context.Context, fns ...func(ctx context.Context) (T, error)) (T, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	return firstSuccess(ctx, cancel, fns)
+}
+
+// AnyOf 并发执行所有函数，等待全部返回后给出最先成功的结果
+// 与 Race 不同，其余函数不会被取消；所有函数都失败时以 *errorutils.Multi 返回所有错误
+func AnyOf[T any](ctx context.Context, fns ...func(ctx context.Context) (T, error)) (T, error) {
+	return firstSuccess(ctx, nil, fns)
+}
+
+// firstSuccess 执行 fns 并按完成顺序收集结果，cancel 不为 nil 时在首次成功后立即返回
+func firstSuccess[T any](ctx context.Context, cancel context.CancelFunc, fns []func(ctx context.Context) (T, error)) (T, error) {
+	var zero T
+	if len(fns) == 0 {
+		return zero, ErrNoFuncs
+	}
+
+	results := make(chan raceResult[T], len(fns))
+	for _, fn := range fns {
+		go func() {
+			val, err := funcutils.Try1(func() (T, error) {
+				return fn(ctx)
+			})
+			results <- raceResult[T]{val: val, err: err}
+		}()
+	}
+
+	var (
+		errs  errorutils.Multi
+		found bool
+		first T
+	)
+	for range fns {
+		r := <-results
+		if r.err != nil {
+			errs.Append(r.err)
+			continue
+		}
+		if found {
+			continue
+		}
+		found, first = true, r.val
+		if cancel != nil {
+			cancel()
+			return first, nil
+		}
+	}
+	if found {
+		return first, nil
+	}
+	return zero, errs.ErrorOrNil()
+}
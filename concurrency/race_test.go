@@ -0,0 +1,105 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jiu-u/gogout/errorutils"
+)
+
+// delayed 返回一个延迟 d 后返回 val 和 err 的函数，被取消时返回 ctx.Err()
+func delayed(d time.Duration, val int, err error) func(ctx context.Context) (int, error) {
+	return func(ctx context.Context) (int, error) {
+		select {
+		case <-time.After(d):
+			return val, err
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+}
+
+func TestRace(t *testing.T) {
+	errFail := errors.New("fail")
+	tests := []struct {
+		name    string
+		fns     []func(ctx context.Context) (int, error)
+		want    int
+		wantErr error
+	}{
+		{"最快的成功", []func(ctx context.Context) (int, error){delayed(50*time.Millisecond, 1, nil), delayed(time.Millisecond, 2, nil)}, 2, nil},
+		{"跳过失败", []func(ctx context.Context) (int, error){delayed(time.Millisecond, 0, errFail), delayed(10*time.Millisecond, 3, nil)}, 3, nil},
+		{"全部失败", []func(ctx context.Context) (int, error){delayed(time.Millisecond, 0, errFail), func(ctx context.Context) (int, error) { panic("oops") }}, 0, errFail},
+		{"没有函数", nil, 0, ErrNoFuncs},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Race(context.Background(), tt.fns...)
+			if got != tt.want || !errors.Is(err, tt.wantErr) {
+				t.Errorf("Race() = %v, %v, 期望 %v, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("全部失败时返回 *errorutils.Multi", func(t *testing.T) {
+		_, err := Race(context.Background(), delayed(time.Millisecond, 0, errFail), delayed(time.Millisecond, 0, errFail))
+		var multi *errorutils.Multi
+		if !errors.As(err, &multi) || multi.Len() != 2 {
+			t.Errorf("Race() error = %v, 期望为包含 2 个错误的 *errorutils.Multi", err)
+		}
+	})
+
+	t.Run("取消其余函数", func(t *testing.T) {
+		var cancelled atomic.Bool
+		done := make(chan struct{})
+		_, err := Race(context.Background(),
+			delayed(time.Millisecond, 1, nil),
+			func(ctx context.Context) (int, error) {
+				defer close(done)
+				<-ctx.Done()
+				cancelled.Store(true)
+				return 0, ctx.Err()
+			},
+		)
+		if err != nil {
+			t.Fatalf("Race() 错误 = %v", err)
+		}
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+		}
+		if !cancelled.Load() {
+			t.Error("较慢的函数没有被取消")
+		}
+	})
+}
+
+func TestAnyOf(t *testing.T) {
+	errFail := errors.New("fail")
+	var finished atomic.Int32
+	track := func(fn func(ctx context.Context) (int, error)) func(ctx context.Context) (int, error) {
+		return func(ctx context.Context) (int, error) {
+			defer finished.Add(1)
+			return fn(ctx)
+		}
+	}
+
+	got, err := AnyOf(context.Background(),
+		track(delayed(30*time.Millisecond, 1, nil)),
+		track(delayed(time.Millisecond, 2, nil)),
+		track(delayed(10*time.Millisecond, 0, errFail)),
+	)
+	if got != 2 || err != nil {
+		t.Errorf("AnyOf() = %v, %v, 期望 %v, nil", got, err, 2)
+	}
+	if finished.Load() != 3 {
+		t.Errorf("完成的函数数量 = %v, 期望 %v", finished.Load(), 3)
+	}
+
+	if _, err := AnyOf(context.Background(), delayed(time.Millisecond, 0, errFail)); !errors.Is(err, errFail) {
+		t.Errorf("AnyOf() 错误 = %v, 期望 %v", err, errFail)
+	}
+}
@@ -0,0 +1,145 @@
+package concurrency
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter 令牌桶限流器：每 interval 产生 n 个令牌，桶中最多存放 burst 个令牌
+// 适用于限制对外部 API 的调用频率，并发安全
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // 每纳秒产生的令牌数
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter 创建一个每 interval 允许 n 次操作、突发上限为 burst 的限流器
+// 初始时桶是满的；burst <= 0 时按 1 处理，n 或 interval 非正数时按每秒 1 次处理
+func NewRateLimiter(n int, interval time.Duration, burst int) *RateLimiter {
+	if n <= 0 || interval <= 0 {
+		n, interval = 1, time.Second
+	}
+	burst = max(burst, 1)
+	return &RateLimiter{
+		rate:   float64(n) / float64(interval),
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// refill 根据经过的时间补充令牌，调用方需持有锁
+func (l *RateLimiter) refill(now time.Time) {
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens = min(l.burst, l.tokens+float64(elapsed)*l.rate)
+		l.last = now
+	}
+}
+
+// Allow 尝试立即获取一个令牌，成功返回 true，令牌不足时返回 false 且不等待
+func (l *RateLimiter) Allow() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill(time.Now())
+	if l.tokens >= 1 {
+		l.tokens--
+		return true
+	}
+	return false
+}
+
+// Wait 阻塞直到获取到一个令牌或 ctx 结束，ctx 结束时返回 ctx.Err()
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		l.refill(time.Now())
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - l.tokens) / l.rate)
+		l.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Do 等待获取令牌后执行 fn，ctx 在获取令牌前结束时不执行 fn 并返回 ctx.Err()
+func (l *RateLimiter) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := l.Wait(ctx); err != nil {
+		return err
+	}
+	return fn(ctx)
+}
+
+// PerKeyLimiter 按 key 独立限流的限流器，例如按租户限制对外调用频率
+// 每个 key 在第一次使用时创建独立的令牌桶，参数与 NewRateLimiter 相同
+// 不再使用的 key 需要调用 Remove 释放
+type PerKeyLimiter[K comparable] struct {
+	mu       sync.Mutex
+	limiters map[K]*RateLimiter
+	n        int
+	interval time.Duration
+	burst    int
+}
+
+// NewPerKeyLimiter 创建一个按 key 限流的限流器，每个 key 每 interval 允许 n 次操作，突发上限为 burst
+func NewPerKeyLimiter[K comparable](n int, interval time.Duration, burst int) *PerKeyLimiter[K] {
+	return &PerKeyLimiter[K]{
+		limiters: make(map[K]*RateLimiter),
+		n:        n,
+		interval: interval,
+		burst:    burst,
+	}
+}
+
+// get 返回 key 对应的限流器，不存在时创建
+func (p *PerKeyLimiter[K]) get(key K) *RateLimiter {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	l, ok := p.limiters[key]
+	if !ok {
+		l = NewRateLimiter(p.n, p.interval, p.burst)
+		p.limiters[key] = l
+	}
+	return l
+}
+
+// Allow 尝试立即为 key 获取一个令牌
+func (p *PerKeyLimiter[K]) Allow(key K) bool {
+	return p.get(key).Allow()
+}
+
+// Wait 阻塞直到为 key 获取到一个令牌或 ctx 结束
+func (p *PerKeyLimiter[K]) Wait(ctx context.Context, key K) error {
+	return p.get(key).Wait(ctx)
+}
+
+// Do 为 key 获取令牌后执行 fn
+func (p *PerKeyLimiter[K]) Do(ctx context.Context, key K, fn func(ctx context.Context) error) error {
+	return p.get(key).Do(ctx, fn)
+}
+
+// Remove 删除 key 对应的令牌桶，下次使用该 key 时会重新创建（桶是满的）
+func (p *PerKeyLimiter[K]) Remove(key K) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.limiters, key)
+}
+
+// Len 返回当前保存的 key 数量
+func (p *PerKeyLimiter[K]) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.limiters)
+}
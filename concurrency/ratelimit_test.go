@@ -0,0 +1,74 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	t.Run("突发上限", func(t *testing.T) {
+		l := NewRateLimiter(1, time.Hour, 3)
+		for i := 0; i < 3; i++ {
+			if !l.Allow() {
+				t.Fatalf("第 %d 次 Allow() = false, 期望 true", i+1)
+			}
+		}
+		if l.Allow() {
+			t.Errorf("超过突发上限后 Allow() 应该返回 false")
+		}
+	})
+
+	t.Run("Wait 等待令牌补充", func(t *testing.T) {
+		l := NewRateLimiter(1, 20*time.Millisecond, 1)
+		start := time.Now()
+		for i := 0; i < 3; i++ {
+			if err := l.Wait(context.Background()); err != nil {
+				t.Fatalf("Wait() 返回错误: %v", err)
+			}
+		}
+		if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+			t.Errorf("3 次 Wait() 耗时 %v, 期望至少约 40ms", elapsed)
+		}
+	})
+
+	t.Run("Wait 响应取消", func(t *testing.T) {
+		l := NewRateLimiter(1, time.Hour, 1)
+		l.Allow()
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if err := l.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Wait() = %v, 期望 DeadlineExceeded", err)
+		}
+	})
+
+	t.Run("Do", func(t *testing.T) {
+		l := NewRateLimiter(10, time.Second, 1)
+		called := false
+		err := l.Do(context.Background(), func(ctx context.Context) error {
+			called = true
+			return nil
+		})
+		if err != nil || !called {
+			t.Errorf("Do() = %v, 调用 %v, 期望 nil, true", err, called)
+		}
+	})
+}
+
+func TestPerKeyLimiter(t *testing.T) {
+	l := NewPerKeyLimiter[string](1, time.Hour, 1)
+	if !l.Allow("a") || !l.Allow("b") {
+		t.Fatalf("不同 key 应该独立限流")
+	}
+	if l.Allow("a") {
+		t.Errorf("key a 的令牌应该已用完")
+	}
+	if l.Len() != 2 {
+		t.Errorf("Len() = %v, 期望 %v", l.Len(), 2)
+	}
+	l.Remove("a")
+	if !l.Allow("a") {
+		t.Errorf("Remove() 后 key a 应该重新拥有令牌")
+	}
+}
@@ -0,0 +1,197 @@
+package concurrency
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jiu-u/gogout/funcutils"
+	"github.com/jiu-u/gogout/randutils"
+	"github.com/jiu-u/gogout/timeutils"
+)
+
+// everyConfig Every 的配置
+type everyConfig struct {
+	jitter    float64
+	rand      randutils.Source
+	immediate bool
+	onError   func(error)
+	clock     timeutils.Clock
+}
+
+// EveryOption Every、OnSchedule 及 Scheduler 对应方法的可选配置项
+type EveryOption func(*everyConfig)
+
+// WithJitter 为每次的等待间隔添加随机抖动，实际间隔在 [interval*(1-factor), interval*(1+factor)] 范围内
+// 用于避免多个实例同时执行；factor 会被限制在 [0, 1] 范围内，对 OnSchedule 无效
+func WithJitter(factor float64) EveryOption {
+	return func(c *everyConfig) {
+		c.jitter = min(max(factor, 0), 1)
+	}
+}
+
+// WithJitterSource 设置 WithJitter 使用的随机源，默认为 randutils.Default()，测试中可以使用 randutils.NewSource
+func WithJitterSource(src randutils.Source) EveryOption {
+	return func(c *everyConfig) {
+		c.rand = src
+	}
+}
+
+// WithImmediate 启动时立即执行一次，而不是先等待一个间隔
+func WithImmediate() EveryOption {
+	return func(c *everyConfig) {
+		c.immediate = true
+	}
+}
+
+// WithErrorHandler 设置处理 fn 返回的错误（包括由 panic 转换的 *funcutils.PanicError）的函数
+// 默认忽略错误；错误不会中断周期执行
+func WithErrorHandler(handler func(error)) EveryOption {
+	return func(c *everyConfig) {
+		c.onError = handler
+	}
+}
+
+// WithClock 设置计时使用的时钟，默认为 timeutils.RealClock，测试中可以使用 timeutils.FakeClock
+func WithClock(clock timeutils.Clock) EveryOption {
+	return func(c *everyConfig) {
+		c.clock = clock
+	}
+}
+
+// newEveryConfig 应用所有配置项
+func newEveryConfig(opts []EveryOption) everyConfig {
+	var cfg everyConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	cfg.clock = timeutils.OrRealClock(cfg.clock)
+	if cfg.rand == nil {
+		cfg.rand = randutils.Default()
+	}
+	return cfg
+}
+
+// Every 每隔 interval 执行一次 fn，直到 ctx 结束，返回 ctx.Err()
+// 下一次执行从上一次 fn 返回后开始计时，因此同一个任务不会重叠执行
+// fn 中的 panic 会被恢复并交给 WithErrorHandler 设置的函数处理；interval <= 0 时立即返回 nil
+func Every(ctx context.Context, interval time.Duration, fn func(ctx context.Context) error, opts ...EveryOption) error {
+	if interval <= 0 {
+		return nil
+	}
+	cfg := newEveryConfig(opts)
+
+	run := cfg.runner(ctx, fn)
+
+	if cfg.immediate {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		run()
+	}
+
+	timer := cfg.clock.NewTimer(cfg.jittered(interval))
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C():
+			run()
+			timer.Reset(cfg.jittered(interval))
+		}
+	}
+}
+
+// runner 返回执行一次 fn 的函数，panic 会被恢复，错误交给 onError 处理
+func (c *everyConfig) runner(ctx context.Context, fn func(ctx context.Context) error) func() {
+	return func() {
+		err := funcutils.Try(func() error {
+			return fn(ctx)
+		})
+		if err != nil && c.onError != nil {
+			c.onError(err)
+		}
+	}
+}
+
+// OnSchedule 按 sched 计算的时间执行 fn，直到 ctx 结束（返回 ctx.Err()）或 sched 不再有下一次执行时间（返回 nil）
+// 下一次执行时间在上一次 fn 返回后计算，执行耗时超过间隔时错过的时间点会被跳过而不是补执行
+// 错误和 panic 的处理与 Every 相同，支持 WithImmediate 和 WithErrorHandler
+func OnSchedule(ctx context.Context, sched timeutils.Schedule, fn func(ctx context.Context) error, opts ...EveryOption) error {
+	cfg := newEveryConfig(opts)
+	run := cfg.runner(ctx, fn)
+
+	if cfg.immediate {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		run()
+	}
+
+	var last time.Time
+	for {
+		now := cfg.clock.Now()
+		if now.Before(last) {
+			now = last
+		}
+		next := sched.Next(now)
+		if next.IsZero() {
+			return nil
+		}
+		timer := cfg.clock.NewTimer(next.Sub(now))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C():
+			run()
+			last = next
+		}
+	}
+}
+
+// jittered 为 d 添加 ±jitter 比例的随机抖动
+func (c *everyConfig) jittered(d time.Duration) time.Duration {
+	if c.jitter == 0 {
+		return d
+	}
+	return time.Duration(float64(d) * (1 - c.jitter + 2*c.jitter*randutils.Float64From(c.rand)))
+}
+
+// Scheduler 管理多个周期任务，调用 Stop 或 ctx 结束时停止所有任务
+type Scheduler struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewScheduler 创建一个调度器，ctx 结束时所有任务都会停止
+func NewScheduler(ctx context.Context) *Scheduler {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Scheduler{ctx: ctx, cancel: cancel}
+}
+
+// Every 添加一个每隔 interval 执行一次的任务，选项与 Every 函数相同
+func (s *Scheduler) Every(interval time.Duration, fn func(ctx context.Context) error, opts ...EveryOption) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		Every(s.ctx, interval, fn, opts...)
+	}()
+}
+
+// OnSchedule 添加一个按 sched 计算的时间执行的任务，选项与 OnSchedule 函数相同
+func (s *Scheduler) OnSchedule(sched timeutils.Schedule, fn func(ctx context.Context) error, opts ...EveryOption) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		OnSchedule(s.ctx, sched, fn, opts...)
+	}()
+}
+
+// Stop 停止所有任务，并等待正在执行的任务返回
+func (s *Scheduler) Stop() {
+	s.cancel()
+	s.wg.Wait()
+}
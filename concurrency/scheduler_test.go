@@ -0,0 +1,207 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jiu-u/gogout/randutils"
+	"github.com/jiu-u/gogout/timeutils"
+)
+
+func TestEvery(t *testing.T) {
+	t.Run("周期执行直到取消", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 55*time.Millisecond)
+		defer cancel()
+		var count atomic.Int32
+		err := Every(ctx, 10*time.Millisecond, func(ctx context.Context) error {
+			count.Add(1)
+			return nil
+		})
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Every() = %v, 期望 DeadlineExceeded", err)
+		}
+		if n := count.Load(); n < 3 || n > 6 {
+			t.Errorf("执行次数 = %v, 期望约 5 次", n)
+		}
+	})
+
+	t.Run("立即执行", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		var count atomic.Int32
+		go Every(ctx, time.Hour, func(ctx context.Context) error {
+			count.Add(1)
+			cancel()
+			return nil
+		}, WithImmediate())
+		<-ctx.Done()
+		if count.Load() != 1 {
+			t.Errorf("执行次数 = %v, 期望 %v", count.Load(), 1)
+		}
+	})
+
+	t.Run("错误和 panic 交给处理函数", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 35*time.Millisecond)
+		defer cancel()
+		var errCount, calls atomic.Int32
+		Every(ctx, 10*time.Millisecond, func(ctx context.Context) error {
+			if calls.Add(1)%2 == 0 {
+				panic("oops")
+			}
+			return errors.New("failed")
+		}, WithErrorHandler(func(error) { errCount.Add(1) }), WithJitter(0.1))
+		if calls.Load() < 2 || errCount.Load() != calls.Load() {
+			t.Errorf("执行 %v 次, 错误 %v 次, 期望每次执行都产生错误且执行多次", calls.Load(), errCount.Load())
+		}
+	})
+}
+
+func TestEveryClock(t *testing.T) {
+	clock := timeutils.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	ctx, cancel := context.WithCancel(context.Background())
+	runs := make(chan time.Time)
+	done := make(chan error)
+	go func() {
+		done <- Every(ctx, time.Minute, func(ctx context.Context) error {
+			runs <- clock.Now()
+			return nil
+		}, WithClock(clock))
+	}()
+	for i := 1; i <= 3; i++ {
+		clock.BlockUntil(1)
+		clock.Advance(time.Minute)
+		if got := <-runs; got.Minute() != i {
+			t.Errorf("第 %v 次执行时间 = %v, 期望第 %v 分钟", i, got, i)
+		}
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Every() = %v, 期望 Canceled", err)
+	}
+}
+
+func TestEveryJitter(t *testing.T) {
+	newCfg := func() everyConfig {
+		return newEveryConfig([]EveryOption{WithJitter(0.5), WithJitterSource(randutils.NewSource(42))})
+	}
+	a, b := newCfg(), newCfg()
+	for i := range 20 {
+		da, db := a.jittered(time.Second), b.jittered(time.Second)
+		if da != db {
+			t.Fatalf("第 %v 次 jittered() = %v, %v, 相同种子期望相同", i, da, db)
+		}
+		if da < 500*time.Millisecond || da > 1500*time.Millisecond {
+			t.Fatalf("jittered() = %v, 超出 [500ms, 1.5s]", da)
+		}
+	}
+	if cfg := newEveryConfig(nil); cfg.jittered(time.Second) != time.Second {
+		t.Errorf("未设置抖动时 jittered() = %v, 期望 %v", cfg.jittered(time.Second), time.Second)
+	}
+}
+
+func TestScheduler(t *testing.T) {
+	s := NewScheduler(context.Background())
+	var a, b atomic.Int32
+	s.Every(5*time.Millisecond, func(ctx context.Context) error {
+		a.Add(1)
+		return nil
+	})
+	s.Every(5*time.Millisecond, func(ctx context.Context) error {
+		b.Add(1)
+		return nil
+	}, WithImmediate())
+	time.Sleep(30 * time.Millisecond)
+	s.Stop()
+
+	countA, countB := a.Load(), b.Load()
+	if countA == 0 || countB == 0 {
+		t.Errorf("执行次数 = %v, %v, 期望都大于 0", countA, countB)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if a.Load() != countA || b.Load() != countB {
+		t.Errorf("Stop() 后任务不应继续执行")
+	}
+}
+
+// everyMs 返回每隔 n 毫秒执行一次的调度计划
+func everyMs(n int) timeutils.Schedule {
+	return timeutils.ScheduleFunc(func(after time.Time) time.Time {
+		return after.Add(time.Duration(n) * time.Millisecond)
+	})
+}
+
+func TestOnSchedule(t *testing.T) {
+	t.Run("按计划执行直到取消", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 55*time.Millisecond)
+		defer cancel()
+		var count atomic.Int32
+		err := OnSchedule(ctx, everyMs(10), func(ctx context.Context) error {
+			count.Add(1)
+			return nil
+		})
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("OnSchedule() = %v, 期望 DeadlineExceeded", err)
+		}
+		if n := count.Load(); n < 3 || n > 6 {
+			t.Errorf("执行次数 = %v, 期望约 5 次", n)
+		}
+	})
+
+	t.Run("计划结束后返回", func(t *testing.T) {
+		var calls int
+		start := time.Now()
+		sched := timeutils.ScheduleFunc(func(after time.Time) time.Time {
+			if after.Sub(start) > 20*time.Millisecond {
+				return time.Time{}
+			}
+			return after.Add(5 * time.Millisecond)
+		})
+		err := OnSchedule(context.Background(), sched, func(ctx context.Context) error {
+			calls++
+			return errors.New("failed")
+		}, WithImmediate(), WithErrorHandler(func(error) {}))
+		if err != nil || calls < 2 {
+			t.Errorf("OnSchedule() = %v, 执行 %v 次, 期望 nil 且执行多次", err, calls)
+		}
+	})
+
+	t.Run("使用可控时钟", func(t *testing.T) {
+		start := time.Date(2024, 5, 15, 8, 0, 0, 0, time.UTC)
+		clock := timeutils.NewFakeClock(start)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		runs := make(chan time.Time)
+		go OnSchedule(ctx, timeutils.MustParseSchedule("daily at 09:00", nil), func(ctx context.Context) error {
+			runs <- clock.Now()
+			return nil
+		}, WithClock(clock))
+		clock.BlockUntil(1)
+		clock.Advance(59 * time.Minute)
+		select {
+		case got := <-runs:
+			t.Fatalf("提前执行于 %v", got)
+		default:
+		}
+		clock.Advance(time.Minute)
+		want := time.Date(2024, 5, 15, 9, 0, 0, 0, time.UTC)
+		if got := <-runs; !got.Equal(want) {
+			t.Errorf("执行时间 = %v, 期望 %v", got, want)
+		}
+	})
+
+	t.Run("Scheduler", func(t *testing.T) {
+		s := NewScheduler(context.Background())
+		var count atomic.Int32
+		s.OnSchedule(everyMs(5), func(ctx context.Context) error {
+			count.Add(1)
+			return nil
+		})
+		time.Sleep(30 * time.Millisecond)
+		s.Stop()
+		if count.Load() == 0 {
+			t.Errorf("执行次数 = 0, 期望大于 0")
+		}
+	})
+}
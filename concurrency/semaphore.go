@@ -0,0 +1,120 @@
+package concurrency
+
+import (
+	"container/list"
+	"context"
+	"sync"
+)
+
+// semWaiter 等待获取资源的请求
+type semWaiter struct {
+	n     int64
+	ready chan struct{} // 获取成功时关闭
+}
+
+// Semaphore 带权重的信号量，用于限制对稀缺资源的并发访问
+// 等待者按先进先出的顺序获取资源，避免大请求被小请求饿死
+type Semaphore struct {
+	mu      sync.Mutex
+	size    int64
+	cur     int64
+	waiters list.List
+}
+
+// NewSemaphore 创建总容量为 size 的信号量
+func NewSemaphore(size int64) *Semaphore {
+	return &Semaphore{size: size}
+}
+
+// Acquire 获取 n 个单位的资源，资源不足时阻塞直到获取成功或 ctx 结束
+// 成功返回 nil；ctx 结束时返回 ctx.Err() 且不占用任何资源
+// n 大于总容量时只会在 ctx 结束时返回
+func (s *Semaphore) Acquire(ctx context.Context, n int64) error {
+	s.mu.Lock()
+	if s.size-s.cur >= n && s.waiters.Len() == 0 {
+		s.cur += n
+		s.mu.Unlock()
+		return nil
+	}
+	if n > s.size {
+		s.mu.Unlock()
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	w := semWaiter{n: n, ready: make(chan struct{})}
+	elem := s.waiters.PushBack(w)
+	s.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		select {
+		case <-w.ready:
+			// 在取消的同时已经获取成功，归还资源
+			s.cur -= n
+			s.notifyWaiters()
+		default:
+			isFront := s.waiters.Front() == elem
+			s.waiters.Remove(elem)
+			// 排在队首的等待者离开后，后面的等待者可能已经可以获取资源
+			if isFront && s.size > s.cur {
+				s.notifyWaiters()
+			}
+		}
+		s.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// TryAcquire 尝试立即获取 n 个单位的资源，成功返回 true，失败时不等待
+func (s *Semaphore) TryAcquire(n int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.size-s.cur >= n && s.waiters.Len() == 0 {
+		s.cur += n
+		return true
+	}
+	return false
+}
+
+// Release 归还 n 个单位的资源，归还的数量超过已占用的数量时 panic
+func (s *Semaphore) Release(n int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cur -= n
+	if s.cur < 0 {
+		panic("concurrency: semaphore released more than held")
+	}
+	s.notifyWaiters()
+}
+
+// With 获取 n 个单位的资源后执行 fn，并保证 fn 返回（包括 panic）后归还资源
+// ctx 在获取资源前结束时不执行 fn 并返回 ctx.Err()
+func (s *Semaphore) With(ctx context.Context, n int64, fn func() error) error {
+	if err := s.Acquire(ctx, n); err != nil {
+		return err
+	}
+	defer s.Release(n)
+	return fn()
+}
+
+// notifyWaiters 按顺序唤醒可以获取资源的等待者，调用方需持有锁
+func (s *Semaphore) notifyWaiters() {
+	for {
+		front := s.waiters.Front()
+		if front == nil {
+			return
+		}
+		w := front.Value.(semWaiter)
+		if s.size-s.cur < w.n {
+			// 队首的请求无法满足时不跳过它，保证先进先出
+			return
+		}
+		s.cur += w.n
+		s.waiters.Remove(front)
+		close(w.ready)
+	}
+}
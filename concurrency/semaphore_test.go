@@ -0,0 +1,101 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSemaphore(t *testing.T) {
+	t.Run("TryAcquire", func(t *testing.T) {
+		s := NewSemaphore(3)
+		if !s.TryAcquire(2) {
+			t.Fatalf("TryAcquire(2) = false, 期望 true")
+		}
+		if s.TryAcquire(2) {
+			t.Errorf("容量不足时 TryAcquire(2) 应该返回 false")
+		}
+		s.Release(2)
+		if !s.TryAcquire(3) {
+			t.Errorf("归还后 TryAcquire(3) 应该返回 true")
+		}
+	})
+
+	t.Run("Acquire 等待归还", func(t *testing.T) {
+		s := NewSemaphore(1)
+		s.Acquire(context.Background(), 1)
+		acquired := make(chan struct{})
+		go func() {
+			s.Acquire(context.Background(), 1)
+			close(acquired)
+		}()
+		select {
+		case <-acquired:
+			t.Fatalf("资源被占用时 Acquire() 不应该返回")
+		case <-time.After(10 * time.Millisecond):
+		}
+		s.Release(1)
+		select {
+		case <-acquired:
+		case <-time.After(time.Second):
+			t.Fatalf("归还后 Acquire() 应该返回")
+		}
+	})
+
+	t.Run("Acquire 响应取消", func(t *testing.T) {
+		s := NewSemaphore(1)
+		s.Acquire(context.Background(), 1)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if err := s.Acquire(ctx, 1); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Acquire() = %v, 期望 DeadlineExceeded", err)
+		}
+		s.Release(1)
+		if !s.TryAcquire(1) {
+			t.Errorf("取消的请求不应占用资源")
+		}
+	})
+
+	t.Run("With 限制并发", func(t *testing.T) {
+		s := NewSemaphore(2)
+		var running, peak atomic.Int32
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				s.With(context.Background(), 1, func() error {
+					n := running.Add(1)
+					for {
+						p := peak.Load()
+						if n <= p || peak.CompareAndSwap(p, n) {
+							break
+						}
+					}
+					time.Sleep(2 * time.Millisecond)
+					running.Add(-1)
+					return nil
+				})
+			}()
+		}
+		wg.Wait()
+		if peak.Load() > 2 {
+			t.Errorf("最大并发数 = %v, 期望不超过 %v", peak.Load(), 2)
+		}
+		if !s.TryAcquire(2) {
+			t.Errorf("With() 返回后资源应该全部归还")
+		}
+	})
+
+	t.Run("过量归还 panic", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("Release() 过量归还应该 panic")
+			}
+		}()
+		NewSemaphore(1).Release(1)
+	})
+}
@@ -0,0 +1,155 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/jiu-u/gogout/errorutils"
+	"github.com/jiu-u/gogout/funcutils"
+)
+
+// ErrHookTimeout 关闭钩子在超时时间内没有返回
+var ErrHookTimeout = errors.New("concurrency: shutdown hook timed out")
+
+// shutdownHook 一个已注册的关闭钩子
+type shutdownHook struct {
+	name    string
+	timeout time.Duration
+	fn      func(ctx context.Context) error
+}
+
+// shutdownConfig Shutdown 的配置
+type shutdownConfig struct {
+	signals []os.Signal
+	timeout time.Duration
+}
+
+// ShutdownOption Shutdown 的可选配置项
+type ShutdownOption func(*shutdownConfig)
+
+// WithSignals 设置 Listen 监听的信号，默认为 SIGINT 和 SIGTERM
+// 不传入信号时保留默认值，而不是像 signal.Notify 那样监听所有信号
+func WithSignals(signals ...os.Signal) ShutdownOption {
+	return func(c *shutdownConfig) {
+		if len(signals) > 0 {
+			c.signals = signals
+		}
+	}
+}
+
+// WithHookTimeout 设置钩子的默认超时时间，Register 时 timeout <= 0 的钩子使用该值
+// 默认为 0，即不限制超时（仍受 Trigger 的 ctx 约束）
+func WithHookTimeout(d time.Duration) ShutdownOption {
+	return func(c *shutdownConfig) {
+		c.timeout = d
+	}
+}
+
+// Shutdown 优雅关闭协调器，各组件注册清理钩子，关闭时按注册的相反顺序依次执行
+// 通过 Listen 等待信号或 ctx 结束后自动触发，也可以直接调用 Trigger；钩子只会执行一次
+type Shutdown struct {
+	cfg shutdownConfig
+
+	mu    sync.Mutex
+	hooks []shutdownHook
+
+	once sync.Once
+	done chan struct{}
+	err  error
+}
+
+// NewShutdown 创建一个关闭协调器
+func NewShutdown(opts ...ShutdownOption) *Shutdown {
+	cfg := shutdownConfig{signals: []os.Signal{os.Interrupt, syscall.SIGTERM}}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &Shutdown{cfg: cfg, done: make(chan struct{})}
+}
+
+// Register 注册一个名为 name 的关闭钩子，timeout 为该钩子的超时时间，<= 0 时使用默认超时
+// 后注册的钩子先执行，因此依赖其他组件的组件应在其依赖之后注册
+func (s *Shutdown) Register(name string, timeout time.Duration, fn func(ctx context.Context) error) {
+	if timeout <= 0 {
+		timeout = s.cfg.timeout
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hooks = append(s.hooks, shutdownHook{name: name, timeout: timeout, fn: fn})
+}
+
+// Listen 阻塞直到收到配置的信号或 ctx 结束，然后触发关闭并返回所有钩子的错误
+// 钩子在不受 ctx 取消影响的新上下文中执行，以保证清理能够完成
+func (s *Shutdown) Listen(ctx context.Context) error {
+	sigCtx, stop := signal.NotifyContext(ctx, s.cfg.signals...)
+	defer stop()
+	select {
+	case <-sigCtx.Done():
+	case <-s.done:
+		return s.err
+	}
+	return s.Trigger(context.WithoutCancel(ctx))
+}
+
+// Trigger 按注册的相反顺序执行所有钩子，所有钩子的错误以 *errorutils.Multi 返回，没有错误时返回 nil
+// 某个钩子失败、超时或 panic 不会影响后续钩子的执行；ctx 结束后剩余的钩子会被跳过
+// 多次调用只会执行一次，后续调用等待首次执行完成并返回相同的结果
+func (s *Shutdown) Trigger(ctx context.Context) error {
+	s.once.Do(func() {
+		defer close(s.done)
+		s.mu.Lock()
+		hooks := make([]shutdownHook, len(s.hooks))
+		copy(hooks, s.hooks)
+		s.mu.Unlock()
+
+		var errs errorutils.Multi
+		for i := len(hooks) - 1; i >= 0; i-- {
+			if err := ctx.Err(); err != nil {
+				errs.Append(fmt.Errorf("concurrency: shutdown hook %q: %w", hooks[i].name, err))
+				continue
+			}
+			if err := runHook(ctx, hooks[i]); err != nil {
+				errs.Append(fmt.Errorf("concurrency: shutdown hook %q: %w", hooks[i].name, err))
+			}
+		}
+		s.err = errs.ErrorOrNil()
+	})
+	<-s.done
+	return s.err
+}
+
+// Done 返回一个在所有钩子执行完毕后关闭的 channel
+func (s *Shutdown) Done() <-chan struct{} {
+	return s.done
+}
+
+// runHook 执行单个钩子，超时后不再等待其返回
+func runHook(ctx context.Context, hook shutdownHook) error {
+	if hook.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, hook.timeout)
+		defer cancel()
+	}
+
+	result := make(chan error, 1)
+	go func() {
+		result <- funcutils.Try(func() error {
+			return hook.fn(ctx)
+		})
+	}()
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && hook.timeout > 0 {
+			return ErrHookTimeout
+		}
+		return ctx.Err()
+	}
+}
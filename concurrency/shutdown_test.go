@@ -0,0 +1,100 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/jiu-u/gogout/errorutils"
+)
+
+func TestShutdown(t *testing.T) {
+	t.Run("WithSignals 不传入信号时保留默认值", func(t *testing.T) {
+		expected := []os.Signal{os.Interrupt, syscall.SIGTERM}
+		if s := NewShutdown(WithSignals()); !reflect.DeepEqual(s.cfg.signals, expected) {
+			t.Errorf("signals = %v, 期望 %v", s.cfg.signals, expected)
+		}
+		if s := NewShutdown(WithSignals(syscall.SIGHUP)); !reflect.DeepEqual(s.cfg.signals, []os.Signal{syscall.SIGHUP}) {
+			t.Errorf("signals = %v, 期望 %v", s.cfg.signals, []os.Signal{syscall.SIGHUP})
+		}
+	})
+
+	t.Run("按注册的相反顺序执行", func(t *testing.T) {
+		s := NewShutdown()
+		var mu sync.Mutex
+		var order []string
+		for _, name := range []string{"db", "cache", "http"} {
+			s.Register(name, 0, func(ctx context.Context) error {
+				mu.Lock()
+				order = append(order, name)
+				mu.Unlock()
+				return nil
+			})
+		}
+		if err := s.Trigger(context.Background()); err != nil {
+			t.Fatalf("Trigger() = %v, 期望 nil", err)
+		}
+		want := []string{"http", "cache", "db"}
+		if !reflect.DeepEqual(order, want) {
+			t.Errorf("执行顺序 = %v, 期望 %v", order, want)
+		}
+	})
+
+	t.Run("错误超时和 panic 被合并", func(t *testing.T) {
+		errBoom := errors.New("boom")
+		s := NewShutdown(WithHookTimeout(20 * time.Millisecond))
+		ran := false
+		s.Register("first", 0, func(ctx context.Context) error {
+			ran = true
+			return nil
+		})
+		s.Register("fail", 0, func(ctx context.Context) error { return errBoom })
+		s.Register("slow", 0, func(ctx context.Context) error {
+			time.Sleep(time.Second)
+			return nil
+		})
+		s.Register("panic", time.Second, func(ctx context.Context) error { panic("oops") })
+
+		err := s.Trigger(context.Background())
+		if !errors.Is(err, errBoom) || !errors.Is(err, ErrHookTimeout) {
+			t.Errorf("Trigger() = %v, 期望包含 %v 和 %v", err, errBoom, ErrHookTimeout)
+		}
+		var multi *errorutils.Multi
+		if !errors.As(err, &multi) || multi.Len() != 3 {
+			t.Errorf("Trigger() = %v, 期望为包含 3 个错误的 *errorutils.Multi", err)
+		}
+		if !ran {
+			t.Error("失败的钩子之后的钩子没有执行")
+		}
+		if again := s.Trigger(context.Background()); again != err {
+			t.Errorf("再次 Trigger() = %v, 期望相同的结果 %v", again, err)
+		}
+	})
+
+	t.Run("ctx 结束时触发", func(t *testing.T) {
+		s := NewShutdown()
+		called := false
+		s.Register("hook", 0, func(ctx context.Context) error {
+			called = ctx.Err() == nil
+			return nil
+		})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if err := s.Listen(ctx); err != nil {
+			t.Errorf("Listen() = %v, 期望 nil", err)
+		}
+		if !called {
+			t.Error("钩子未在有效的上下文中执行")
+		}
+		select {
+		case <-s.Done():
+		default:
+			t.Error("Done() 未关闭")
+		}
+	})
+}
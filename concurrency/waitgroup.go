@@ -0,0 +1,71 @@
+package concurrency
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jiu-u/gogout/errorutils"
+	"github.com/jiu-u/gogout/funcutils"
+)
+
+// WaitGroupE 收集错误并支持超时等待的 WaitGroup，零值可直接使用
+// 与 sync.WaitGroup 不同，WaitCtx 和 WaitTimeout 在 goroutine 泄漏时不会永远阻塞
+type WaitGroupE struct {
+	wg   sync.WaitGroup
+	mu   sync.Mutex
+	errs errorutils.Multi
+}
+
+// Go 在新的 goroutine 中执行 fn，返回的错误和发生的 panic 都会被收集
+func (g *WaitGroupE) Go(fn func() error) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if err := funcutils.Try(fn); err != nil {
+			g.mu.Lock()
+			g.errs.Append(err)
+			g.mu.Unlock()
+		}
+	}()
+}
+
+// Wait 等待所有 goroutine 结束，返回收集到的所有错误（*errorutils.Multi），没有错误时返回 nil
+func (g *WaitGroupE) Wait() error {
+	g.wg.Wait()
+	return g.collect()
+}
+
+// WaitCtx 等待所有 goroutine 结束或 ctx 结束
+// ctx 先结束时返回 ctx.Err() 与此前已收集的错误，仍在运行的 goroutine 不会被终止
+func (g *WaitGroupE) WaitCtx(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return g.collect()
+	case <-ctx.Done():
+		return errorutils.Append(ctx.Err(), g.collect())
+	}
+}
+
+// WaitTimeout 最多等待 d，超时返回的错误包含 context.DeadlineExceeded
+func (g *WaitGroupE) WaitTimeout(d time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+	return g.WaitCtx(ctx)
+}
+
+// collect 返回已收集的错误
+func (g *WaitGroupE) collect() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	// 复制一份，WaitCtx 返回后仍在运行的 goroutine 可能继续追加错误
+	var errs errorutils.Multi
+	errs.Append(g.errs.Errors()...)
+	return errs.ErrorOrNil()
+}
@@ -0,0 +1,68 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWaitGroupE(t *testing.T) {
+	t.Run("全部成功", func(t *testing.T) {
+		var g WaitGroupE
+		var count atomic.Int32
+		for i := 0; i < 10; i++ {
+			g.Go(func() error {
+				count.Add(1)
+				return nil
+			})
+		}
+		if err := g.Wait(); err != nil {
+			t.Errorf("Wait() = %v, 期望 nil", err)
+		}
+		if count.Load() != 10 {
+			t.Errorf("执行次数 = %v, 期望 %v", count.Load(), 10)
+		}
+	})
+
+	t.Run("收集所有错误", func(t *testing.T) {
+		var g WaitGroupE
+		err1, err2 := errors.New("e1"), errors.New("e2")
+		g.Go(func() error { return err1 })
+		g.Go(func() error { return err2 })
+		g.Go(func() error { panic("oops") })
+		err := g.Wait()
+		if !errors.Is(err, err1) || !errors.Is(err, err2) {
+			t.Errorf("Wait() = %v, 期望包含 e1 和 e2", err)
+		}
+		if n := len(err.(interface{ Unwrap() []error }).Unwrap()); n != 3 {
+			t.Errorf("错误数量 = %v, 期望 %v", n, 3)
+		}
+	})
+
+	t.Run("超时返回", func(t *testing.T) {
+		var g WaitGroupE
+		block := make(chan struct{})
+		defer close(block)
+		g.Go(func() error {
+			<-block
+			return nil
+		})
+		start := time.Now()
+		if err := g.WaitTimeout(20 * time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("WaitTimeout() = %v, 期望 DeadlineExceeded", err)
+		}
+		if time.Since(start) > time.Second {
+			t.Errorf("WaitTimeout() 没有按时返回")
+		}
+	})
+
+	t.Run("WaitCtx 正常完成", func(t *testing.T) {
+		var g WaitGroupE
+		g.Go(func() error { return nil })
+		if err := g.WaitCtx(context.Background()); err != nil {
+			t.Errorf("WaitCtx() = %v, 期望 nil", err)
+		}
+	})
+}
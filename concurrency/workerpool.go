@@ -0,0 +1,175 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/jiu-u/gogout/errorutils"
+	"github.com/jiu-u/gogout/funcutils"
+)
+
+// ErrPoolClosed 工作池已经关闭，不再接受新任务
+var ErrPoolClosed = errors.New("concurrency: worker pool is closed")
+
+// poolConfig WorkerPool 的配置
+type poolConfig struct {
+	queueSize int
+}
+
+// PoolOption WorkerPool 的可选配置项
+type PoolOption func(*poolConfig)
+
+// WithQueueSize 设置等待执行的任务队列长度，队列满时 Submit 会阻塞
+// 默认与 worker 数量相同
+func WithQueueSize(size int) PoolOption {
+	return func(c *poolConfig) {
+		c.queueSize = size
+	}
+}
+
+// WorkerPool 固定数量 worker 的长期工作池，适用于执行各种异构任务
+// 任务通过 Submit 提交，通过 Wait 等待已提交的任务完成，通过 Shutdown 优雅关闭
+// 任务返回的错误和 panic 会被收集，由 Wait 或 Shutdown 以 *errorutils.Multi 统一返回
+type WorkerPool struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	tasks  chan func(ctx context.Context) error
+
+	workers sync.WaitGroup // 正在运行的 worker
+
+	mu         sync.RWMutex // 保护 closed，与 submitters 配合防止向已关闭的 tasks 发送
+	closed     bool
+	submitters sync.WaitGroup // 正在向 tasks 发送的 Submit
+	closing    chan struct{}  // Shutdown 开始时关闭，唤醒阻塞在队列上的 Submit
+	closeOnce  sync.Once
+
+	stateMu sync.Mutex // 保护 pending 和 errs
+	idle    *sync.Cond // pending 变为 0 时广播
+	pending int        // 已提交但尚未完成的任务数
+	errs    errorutils.Multi
+}
+
+// NewWorkerPool 创建并启动一个包含 workers 个 worker 的工作池，workers <= 0 时按 1 处理
+func NewWorkerPool(workers int, opts ...PoolOption) *WorkerPool {
+	workers = max(workers, 1)
+	cfg := poolConfig{queueSize: workers}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &WorkerPool{
+		ctx:     ctx,
+		cancel:  cancel,
+		tasks:   make(chan func(ctx context.Context) error, max(cfg.queueSize, 0)),
+		closing: make(chan struct{}),
+	}
+	p.idle = sync.NewCond(&p.stateMu)
+	p.workers.Add(workers)
+	for i := 0; i < workers; i++ {
+		go p.worker()
+	}
+	return p
+}
+
+// worker 从队列中取出任务并执行，直到队列被关闭
+func (p *WorkerPool) worker() {
+	defer p.workers.Done()
+	for task := range p.tasks {
+		err := funcutils.Try(func() error {
+			return task(p.ctx)
+		})
+
+		p.stateMu.Lock()
+		p.errs.Append(err)
+		p.pending--
+		if p.pending == 0 {
+			p.idle.Broadcast()
+		}
+		p.stateMu.Unlock()
+	}
+}
+
+// Submit 提交一个任务，队列已满时阻塞直到有空位
+// 工作池已关闭时返回 ErrPoolClosed，阻塞期间工作池开始关闭时同样返回 ErrPoolClosed
+// 任务收到的 ctx 会在 Shutdown 超时（强制停止）时被取消
+func (p *WorkerPool) Submit(task func(ctx context.Context) error) error {
+	p.mu.RLock()
+	if p.closed {
+		p.mu.RUnlock()
+		return ErrPoolClosed
+	}
+	p.submitters.Add(1)
+	p.mu.RUnlock()
+	defer p.submitters.Done()
+
+	p.stateMu.Lock()
+	p.pending++
+	p.stateMu.Unlock()
+	select {
+	case p.tasks <- task:
+		return nil
+	case <-p.closing:
+		p.stateMu.Lock()
+		p.pending--
+		if p.pending == 0 {
+			p.idle.Broadcast()
+		}
+		p.stateMu.Unlock()
+		return ErrPoolClosed
+	}
+}
+
+// Wait 等待所有已提交的任务执行完毕，返回这段时间内收集到的错误（*errorutils.Multi），没有错误时返回 nil
+// 返回后已收集的错误会被清空；Wait 不会关闭工作池
+func (p *WorkerPool) Wait() error {
+	p.stateMu.Lock()
+	for p.pending > 0 {
+		p.idle.Wait()
+	}
+	p.stateMu.Unlock()
+	return p.takeErrors()
+}
+
+// Shutdown 停止接受新任务，并等待队列中已有的任务全部执行完毕
+// 阻塞在 Submit 中的调用会立即返回 ErrPoolClosed
+// 如果 ctx 在任务完成前结束，会取消传给任务的 ctx 并返回 ctx.Err() 与已收集的错误
+// 重复调用是安全的
+func (p *WorkerPool) Shutdown(ctx context.Context) error {
+	p.closeOnce.Do(func() {
+		close(p.closing)
+		p.mu.Lock()
+		p.closed = true
+		p.mu.Unlock()
+		go func() {
+			// 等待正在发送的 Submit 退出后才能关闭 tasks
+			p.submitters.Wait()
+			close(p.tasks)
+		}()
+	})
+
+	done := make(chan struct{})
+	go func() {
+		p.workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		p.cancel()
+		return p.takeErrors()
+	case <-ctx.Done():
+		p.cancel()
+		return errorutils.Append(ctx.Err(), p.takeErrors())
+	}
+}
+
+// takeErrors 取出并清空已收集的错误
+func (p *WorkerPool) takeErrors() error {
+	p.stateMu.Lock()
+	defer p.stateMu.Unlock()
+	errs := p.errs
+	p.errs = errorutils.Multi{}
+	return errs.ErrorOrNil()
+}
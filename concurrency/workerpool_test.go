@@ -0,0 +1,164 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jiu-u/gogout/errorutils"
+)
+
+func TestWorkerPool(t *testing.T) {
+	t.Run("执行所有任务", func(t *testing.T) {
+		pool := NewWorkerPool(4)
+		var count atomic.Int32
+		for i := 0; i < 100; i++ {
+			if err := pool.Submit(func(ctx context.Context) error {
+				count.Add(1)
+				return nil
+			}); err != nil {
+				t.Fatalf("Submit() 返回错误: %v", err)
+			}
+		}
+		if err := pool.Wait(); err != nil {
+			t.Errorf("Wait() = %v, 期望 nil", err)
+		}
+		if count.Load() != 100 {
+			t.Errorf("执行的任务数 = %v, 期望 %v", count.Load(), 100)
+		}
+		if err := pool.Shutdown(context.Background()); err != nil {
+			t.Errorf("Shutdown() = %v, 期望 nil", err)
+		}
+	})
+
+	t.Run("限制并发数", func(t *testing.T) {
+		pool := NewWorkerPool(3, WithQueueSize(10))
+		var running, peak atomic.Int32
+		for i := 0; i < 20; i++ {
+			pool.Submit(func(ctx context.Context) error {
+				n := running.Add(1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				time.Sleep(2 * time.Millisecond)
+				running.Add(-1)
+				return nil
+			})
+		}
+		pool.Shutdown(context.Background())
+		if peak.Load() > 3 {
+			t.Errorf("最大并发数 = %v, 期望不超过 %v", peak.Load(), 3)
+		}
+	})
+
+	t.Run("收集错误和 panic", func(t *testing.T) {
+		pool := NewWorkerPool(2)
+		errBoom := errors.New("boom")
+		pool.Submit(func(ctx context.Context) error { return errBoom })
+		pool.Submit(func(ctx context.Context) error { panic("oops") })
+		pool.Submit(func(ctx context.Context) error { return nil })
+
+		err := pool.Wait()
+		if !errors.Is(err, errBoom) {
+			t.Errorf("Wait() = %v, 期望包含 boom", err)
+		}
+		var multi *errorutils.Multi
+		if !errors.As(err, &multi) || multi.Len() != 2 {
+			t.Errorf("Wait() = %v, 期望为包含 2 个错误的 *errorutils.Multi", err)
+		}
+		if err := pool.Wait(); err != nil {
+			t.Errorf("第二次 Wait() = %v, 错误应已被清空", err)
+		}
+		pool.Shutdown(context.Background())
+	})
+
+	t.Run("关闭后拒绝新任务", func(t *testing.T) {
+		pool := NewWorkerPool(1)
+		pool.Shutdown(context.Background())
+		if err := pool.Submit(func(ctx context.Context) error { return nil }); !errors.Is(err, ErrPoolClosed) {
+			t.Errorf("Submit() = %v, 期望 ErrPoolClosed", err)
+		}
+		if err := pool.Shutdown(context.Background()); err != nil {
+			t.Errorf("重复 Shutdown() = %v, 期望 nil", err)
+		}
+	})
+
+	t.Run("关闭时执行完队列中的任务", func(t *testing.T) {
+		pool := NewWorkerPool(1, WithQueueSize(5))
+		var count atomic.Int32
+		for i := 0; i < 5; i++ {
+			pool.Submit(func(ctx context.Context) error {
+				time.Sleep(time.Millisecond)
+				count.Add(1)
+				return nil
+			})
+		}
+		pool.Shutdown(context.Background())
+		if count.Load() != 5 {
+			t.Errorf("执行的任务数 = %v, 期望 %v", count.Load(), 5)
+		}
+	})
+
+	t.Run("关闭超时取消任务", func(t *testing.T) {
+		pool := NewWorkerPool(1)
+		cancelled := make(chan struct{})
+		pool.Submit(func(ctx context.Context) error {
+			<-ctx.Done()
+			close(cancelled)
+			return ctx.Err()
+		})
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		if err := pool.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Shutdown() = %v, 期望 DeadlineExceeded", err)
+		}
+		select {
+		case <-cancelled:
+		case <-time.After(time.Second):
+			t.Errorf("Shutdown() 超时后任务的 ctx 应该被取消")
+		}
+	})
+
+	t.Run("关闭时不会被阻塞的 Submit 卡住", func(t *testing.T) {
+		pool := NewWorkerPool(1, WithQueueSize(1))
+		waitCtx := func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		pool.Submit(waitCtx)
+		pool.Submit(waitCtx)
+		submitErr := make(chan error, 1)
+		go func() {
+			submitErr <- pool.Submit(waitCtx)
+		}()
+		time.Sleep(20 * time.Millisecond)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+		shutdownErr := make(chan error, 1)
+		go func() {
+			shutdownErr <- pool.Shutdown(ctx)
+		}()
+		select {
+		case err := <-shutdownErr:
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("Shutdown() = %v, 期望 DeadlineExceeded", err)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("Shutdown() 在 ctx 超时后仍未返回")
+		}
+		select {
+		case err := <-submitErr:
+			if !errors.Is(err, ErrPoolClosed) {
+				t.Errorf("阻塞的 Submit() = %v, 期望 ErrPoolClosed", err)
+			}
+		case <-time.After(time.Second):
+			t.Errorf("阻塞的 Submit() 在关闭后仍未返回")
+		}
+	})
+}
@@ -0,0 +1,157 @@
+package convutils
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unsafe"
+
+	"github.com/jiu-u/gogout/mathutils"
+)
+
+// 以下 To 系列函数都会先去掉 s 首尾的空白字符，解析失败（包括空字符串和溢出）时返回默认值 def
+
+// ToInt 将十进制字符串转换为 int
+func ToInt(s string, def int) int {
+	return ParseIntOr(s, def)
+}
+
+// ToInt64 将十进制字符串转换为 int64
+func ToInt64(s string, def int64) int64 {
+	return ParseIntOr(s, def)
+}
+
+// ToFloat 将字符串转换为 float64，支持 strconv.ParseFloat 接受的所有格式
+func ToFloat(s string, def float64) float64 {
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+// ToBool 将字符串转换为 bool，不区分大小写
+// 除 strconv.ParseBool 接受的值外，还支持 yes/no、y/n、on/off
+func ToBool(s string, def bool) bool {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "1", "t", "true", "y", "yes", "on":
+		return true
+	case "0", "f", "false", "n", "no", "off":
+		return false
+	}
+	return def
+}
+
+// ParseIntOr 将十进制字符串转换为任意整数类型 T，超出 T 的取值范围时同样返回 def
+func ParseIntOr[T mathutils.Integer](s string, def T) T {
+	v, err := parseInt[T](s)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+// parseInt 按 T 的位数和符号解析十进制字符串
+func parseInt[T mathutils.Integer](s string) (T, error) {
+	s = strings.TrimSpace(s)
+	var zero T
+	bits := int(unsafe.Sizeof(zero)) * 8
+	if signed := zero-1 < 0; signed {
+		v, err := strconv.ParseInt(s, 10, bits)
+		return T(v), err
+	}
+	v, err := strconv.ParseUint(s, 10, bits)
+	return T(v), err
+}
+
+// ToStringAny 将任意值转换为字符串
+// nil 转换为空字符串；字符串、[]byte、数字和 bool 直接格式化（浮点数使用最短的精确表示）；
+// 实现了 error 或 fmt.Stringer 的值使用其方法；nil 指针与 fmt.Sprint 一样输出 "<nil>"，不会调用其方法；
+// 非 nil 指针转换其指向的值；其他类型使用 fmt.Sprint
+func ToStringAny(v any) string {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return "<nil>"
+	}
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	case []byte:
+		return string(x)
+	case bool:
+		return strconv.FormatBool(x)
+	case int:
+		return strconv.Itoa(x)
+	case int8:
+		return strconv.FormatInt(int64(x), 10)
+	case int16:
+		return strconv.FormatInt(int64(x), 10)
+	case int32:
+		return strconv.FormatInt(int64(x), 10)
+	case int64:
+		return strconv.FormatInt(x, 10)
+	case uint:
+		return strconv.FormatUint(uint64(x), 10)
+	case uint8:
+		return strconv.FormatUint(uint64(x), 10)
+	case uint16:
+		return strconv.FormatUint(uint64(x), 10)
+	case uint32:
+		return strconv.FormatUint(uint64(x), 10)
+	case uint64:
+		return strconv.FormatUint(x, 10)
+	case float32:
+		return strconv.FormatFloat(float64(x), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case error:
+		return x.Error()
+	case fmt.Stringer:
+		return x.String()
+	}
+	if rv.Kind() == reflect.Pointer {
+		return ToStringAny(rv.Elem().Interface())
+	}
+	return fmt.Sprint(v)
+}
+
+// IntsToStrings 将整数切片转换为十进制字符串切片
+func IntsToStrings[T mathutils.Integer](ints []T) []string {
+	result := make([]string, len(ints))
+	var zero T
+	signed := zero-1 < 0
+	for i, v := range ints {
+		if signed {
+			result[i] = strconv.FormatInt(int64(v), 10)
+		} else {
+			result[i] = strconv.FormatUint(uint64(v), 10)
+		}
+	}
+	return result
+}
+
+// StringsToInts 将字符串切片转换为 int 切片，见 StringsToIntegers
+func StringsToInts(ss []string) ([]int, error) {
+	return StringsToIntegers[int](ss)
+}
+
+// StringsToIntegers 将字符串切片转换为整数切片，返回的切片与 ss 等长
+// 无法解析的元素在结果中为零值，并且不会中断转换；所有失败以 errors.Join 合并返回，
+// 每个错误的格式为 "convutils: element i: ..."，可以通过 errors.As 取得 *strconv.NumError
+func StringsToIntegers[T mathutils.Integer](ss []string) ([]T, error) {
+	result := make([]T, len(ss))
+	var errs []error
+	for i, s := range ss {
+		v, err := parseInt[T](s)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("convutils: element %d: %w", i, err))
+			continue
+		}
+		result[i] = v
+	}
+	return result, errors.Join(errs...)
+}
@@ -0,0 +1,193 @@
+package convutils
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestToInt(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		def      int
+		expected int
+	}{
+		{"正数", "42", -1, 42},
+		{"负数", "-7", 0, -7},
+		{"带加号", "+8", 0, 8},
+		{"首尾空白", "  13\n", 0, 13},
+		{"空字符串", "", 5, 5},
+		{"非数字", "abc", 5, 5},
+		{"小数", "1.5", 5, 5},
+		{"溢出", "99999999999999999999", 5, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := ToInt(tt.s, tt.def); result != tt.expected {
+				t.Errorf("ToInt(%q, %v) = %v, 期望 %v", tt.s, tt.def, result, tt.expected)
+			}
+		})
+	}
+
+	if result := ToInt64("9223372036854775807", 0); result != math.MaxInt64 {
+		t.Errorf("ToInt64() = %v, 期望 %v", result, int64(math.MaxInt64))
+	}
+}
+
+func TestParseIntOr(t *testing.T) {
+	if result := ParseIntOr[int8]("127", 0); result != 127 {
+		t.Errorf("ParseIntOr[int8](127) = %v, 期望 %v", result, 127)
+	}
+	if result := ParseIntOr[int8]("128", -1); result != -1 {
+		t.Errorf("ParseIntOr[int8](128) = %v, 期望默认值 %v", result, -1)
+	}
+	if result := ParseIntOr[uint16]("-1", 9); result != 9 {
+		t.Errorf("ParseIntOr[uint16](-1) = %v, 期望默认值 %v", result, 9)
+	}
+	if result := ParseIntOr[uint64]("18446744073709551615", 0); result != math.MaxUint64 {
+		t.Errorf("ParseIntOr[uint64]() = %v, 期望 %v", result, uint64(math.MaxUint64))
+	}
+	type port uint16
+	if result := ParseIntOr[port]("8080", 80); result != 8080 {
+		t.Errorf("ParseIntOr[port]() = %v, 期望 %v", result, 8080)
+	}
+}
+
+func TestToFloat(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		expected float64
+	}{
+		{"小数", "3.14", 3.14},
+		{"科学计数法", "1e3", 1000},
+		{"整数", " 2 ", 2},
+		{"非法", "pi", -1},
+		{"空字符串", "", -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := ToFloat(tt.s, -1); result != tt.expected {
+				t.Errorf("ToFloat(%q) = %v, 期望 %v", tt.s, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestToBool(t *testing.T) {
+	tests := []struct {
+		s        string
+		def      bool
+		expected bool
+	}{
+		{"true", false, true},
+		{"TRUE", false, true},
+		{"1", false, true},
+		{"yes", false, true},
+		{" On ", false, true},
+		{"y", false, true},
+		{"false", true, false},
+		{"0", true, false},
+		{"NO", true, false},
+		{"off", true, false},
+		{"", true, true},
+		{"maybe", false, false},
+		{"maybe", true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			if result := ToBool(tt.s, tt.def); result != tt.expected {
+				t.Errorf("ToBool(%q, %v) = %v, 期望 %v", tt.s, tt.def, result, tt.expected)
+			}
+		})
+	}
+}
+
+type celsius float64
+
+func (c celsius) String() string {
+	return strconv.FormatFloat(float64(c), 'f', 1, 64) + "°C"
+}
+
+func TestToStringAny(t *testing.T) {
+	s := "ptr"
+	var nilPtr *int
+	tests := []struct {
+		name     string
+		v        any
+		expected string
+	}{
+		{"nil", nil, ""},
+		{"字符串", "abc", "abc"},
+		{"字节切片", []byte("xyz"), "xyz"},
+		{"bool", true, "true"},
+		{"int", -42, "-42"},
+		{"int8", int8(-8), "-8"},
+		{"uint64", uint64(math.MaxUint64), "18446744073709551615"},
+		{"float64", 0.1, "0.1"},
+		{"float32", float32(0.1), "0.1"},
+		{"大浮点数不使用科学计数法", 1e21, "1000000000000000000000"},
+		{"error", errors.New("boom"), "boom"},
+		{"Stringer", celsius(21.5), "21.5°C"},
+		{"Duration", 90 * time.Second, "1m30s"},
+		{"指针", &s, "ptr"},
+		{"nil 指针", nilPtr, "<nil>"},
+		{"值接收者 Stringer 的 nil 指针", (*time.Time)(nil), "<nil>"},
+		{"nil error 指针", (*strconv.NumError)(nil), "<nil>"},
+		{"切片", []int{1, 2}, "[1 2]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := ToStringAny(tt.v); result != tt.expected {
+				t.Errorf("ToStringAny(%#v) = %q, 期望 %q", tt.v, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIntsToStrings(t *testing.T) {
+	result := IntsToStrings([]int64{1, -2, 30})
+	expected := []string{"1", "-2", "30"}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("IntsToStrings() = %v, 期望 %v", result, expected)
+	}
+	if result := IntsToStrings([]uint8{}); len(result) != 0 {
+		t.Errorf("IntsToStrings() 空切片 = %v, 期望空切片", result)
+	}
+}
+
+func TestStringsToInts(t *testing.T) {
+	t.Run("全部成功", func(t *testing.T) {
+		result, err := StringsToInts([]string{"1", " 2 ", "-3"})
+		if err != nil || !reflect.DeepEqual(result, []int{1, 2, -3}) {
+			t.Errorf("StringsToInts() = %v, %v, 期望 [1 2 -3], nil", result, err)
+		}
+	})
+
+	t.Run("收集所有错误", func(t *testing.T) {
+		result, err := StringsToInts([]string{"1", "x", "3", "4.5"})
+		if !reflect.DeepEqual(result, []int{1, 0, 3, 0}) {
+			t.Errorf("StringsToInts() = %v, 期望 [1 0 3 0]", result)
+		}
+		expected := "convutils: element 1: strconv.ParseInt: parsing \"x\": invalid syntax\n" +
+			"convutils: element 3: strconv.ParseInt: parsing \"4.5\": invalid syntax"
+		if err == nil || err.Error() != expected {
+			t.Errorf("StringsToInts() 错误 = %v, 期望 %v", err, expected)
+		}
+		var numErr *strconv.NumError
+		if !errors.As(err, &numErr) || !errors.Is(err, strconv.ErrSyntax) {
+			t.Errorf("StringsToInts() 错误应可以取得 *strconv.NumError")
+		}
+	})
+
+	t.Run("溢出", func(t *testing.T) {
+		_, err := StringsToIntegers[uint8]([]string{"256"})
+		if !errors.Is(err, strconv.ErrRange) {
+			t.Errorf("StringsToIntegers[uint8]() 错误 = %v, 期望 %v", err, strconv.ErrRange)
+		}
+	})
+}
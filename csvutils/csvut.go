@@ -0,0 +1,441 @@
+package csvutils
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrNotStruct T 不是结构体或结构体指针
+	ErrNotStruct = errors.New("csvutils: type must be a struct or pointer to struct")
+	// ErrUnsupportedType 字段类型无法与 CSV 单元格相互转换
+	ErrUnsupportedType = errors.New("csvutils: unsupported field type")
+)
+
+var (
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// config Marshal 和 Unmarshal 的配置
+type config struct {
+	comma    rune
+	noHeader bool
+}
+
+// Option Marshal、Unmarshal、Encoder 和 Decoder 的可选配置项
+type Option func(*config)
+
+// WithDelimiter 设置字段分隔符，默认为 ','
+func WithDelimiter(r rune) Option {
+	return func(c *config) {
+		c.comma = r
+	}
+}
+
+// WithoutHeader 不使用表头：Marshal 不输出表头行，Unmarshal 将第一行作为数据，并按字段声明顺序对应各列
+func WithoutHeader() Option {
+	return func(c *config) {
+		c.noHeader = true
+	}
+}
+
+// newConfig 应用所有配置项
+func newConfig(opts []Option) config {
+	cfg := config{comma: ','}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// field 结构体中与一列对应的字段
+type field struct {
+	name  string
+	index []int
+}
+
+// structFields 返回类型 T 对应的结构体类型及其列，T 为结构体指针时 isPtr 为 true
+// 列名由 csv 标签（逗号之前的部分）决定，没有标签时使用字段名，标签为 "-" 时忽略该字段；只处理导出字段
+func structFields[T any]() (st reflect.Type, fields []field, isPtr bool, err error) {
+	st = reflect.TypeOf((*T)(nil)).Elem()
+	if st.Kind() == reflect.Pointer {
+		st, isPtr = st.Elem(), true
+	}
+	if st.Kind() != reflect.Struct {
+		return nil, nil, false, fmt.Errorf("%w: %s", ErrNotStruct, reflect.TypeOf((*T)(nil)).Elem())
+	}
+	for _, f := range reflect.VisibleFields(st) {
+		if !f.IsExported() || (f.Anonymous && indirectType(f.Type).Kind() == reflect.Struct) {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("csv"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = f.Name
+		}
+		if !supported(f.Type) {
+			return nil, nil, false, fmt.Errorf("%w: field %s of type %s", ErrUnsupportedType, f.Name, f.Type)
+		}
+		fields = append(fields, field{name: name, index: f.Index})
+	}
+	return st, fields, isPtr, nil
+}
+
+// indirectType 返回指针指向的类型，非指针类型原样返回
+func indirectType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+	return t
+}
+
+// supported 判断类型 t 能否与单元格相互转换：基本类型、同时实现了 TextMarshaler 和 TextUnmarshaler 的类型，以及它们的指针
+func supported(t reflect.Type) bool {
+	if t.Implements(textMarshalerType) && reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Pointer:
+		return t.Elem().Kind() != reflect.Pointer && supported(t.Elem())
+	}
+	return false
+}
+
+// Marshal 将 items 编码为 CSV，第一行为表头，每个元素占一行，列的顺序为字段的声明顺序
+// T 必须为结构体或结构体指针，列名由 csv 标签决定（见 Unmarshal）；nil 指针编码为空单元格，
+// 实现了 encoding.TextMarshaler 的字段（如 time.Time）使用其方法，浮点数使用最短的精确表示
+func Marshal[T any](items []T, opts ...Option) ([]byte, error) {
+	var buf bytes.Buffer
+	enc, err := NewEncoder[T](&buf, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if err := enc.Encode(item); err != nil {
+			return nil, err
+		}
+	}
+	if err := enc.Flush(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Encoder 逐行将 T 编码为 CSV 并写入 io.Writer，用于输出大量数据；表头在第一次 Encode 或 Flush 时写入
+type Encoder[T any] struct {
+	w           *csv.Writer
+	fields      []field
+	writeHeader bool
+	record      []string
+}
+
+// NewEncoder 创建写入 w 的 Encoder，T 不是结构体或包含不支持的字段类型时返回错误
+func NewEncoder[T any](w io.Writer, opts ...Option) (*Encoder[T], error) {
+	cfg := newConfig(opts)
+	_, fields, _, err := structFields[T]()
+	if err != nil {
+		return nil, err
+	}
+	cw := csv.NewWriter(w)
+	cw.Comma = cfg.comma
+	return &Encoder[T]{
+		w:           cw,
+		fields:      fields,
+		writeHeader: !cfg.noHeader,
+		record:      make([]string, len(fields)),
+	}, nil
+}
+
+// Encode 写入一行，nil 指针写入一个所有单元格都为空的行
+// 写入是带缓冲的，结束时必须调用 Flush
+func (e *Encoder[T]) Encode(item T) error {
+	if err := e.header(); err != nil {
+		return err
+	}
+	v := reflect.ValueOf(&item).Elem()
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	for i, f := range e.fields {
+		e.record[i] = ""
+		if !v.IsValid() {
+			continue
+		}
+		fv, err := v.FieldByIndexErr(f.index)
+		if err != nil {
+			// 经过 nil 的嵌入指针
+			continue
+		}
+		s, err := formatValue(fv)
+		if err != nil {
+			return fmt.Errorf("csvutils: column %q: %w", f.name, err)
+		}
+		e.record[i] = s
+	}
+	return e.w.Write(e.record)
+}
+
+// Flush 将缓冲的数据写入底层的 io.Writer，并返回写入过程中发生的错误
+func (e *Encoder[T]) Flush() error {
+	if err := e.header(); err != nil {
+		return err
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// header 在需要时写入表头
+func (e *Encoder[T]) header() error {
+	if !e.writeHeader {
+		return nil
+	}
+	e.writeHeader = false
+	for i, f := range e.fields {
+		e.record[i] = f.name
+	}
+	return e.w.Write(e.record)
+}
+
+// formatValue 将字段的值格式化为单元格
+func formatValue(v reflect.Value) (string, error) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+	if v.Type().Implements(textMarshalerType) {
+		text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), err
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()), nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnsupportedType, v.Type())
+}
+
+// Unmarshal 从 r 中读取 CSV 并解码为 []T，T 必须为结构体或结构体指针
+// 默认第一行为表头，各列按名称对应到字段，名称由 csv:"name" 标签决定，没有标签时使用字段名，标签为 "-" 时忽略该字段；
+// 表头中没有对应字段的列会被忽略，没有对应列的字段保持零值；使用 WithoutHeader 时按字段声明顺序对应各列
+// 空单元格解码为零值（指针字段为 nil），实现了 encoding.TextUnmarshaler 的字段使用其方法
+// 遇到无法解析的单元格时返回错误，错误信息包含行号和列名；数据量大时可以使用 Decoder 逐行处理
+func Unmarshal[T any](r io.Reader, opts ...Option) ([]T, error) {
+	dec, err := NewDecoder[T](r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	var result []T
+	err = dec.Each(func(item T) error {
+		result = append(result, item)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Decoder 从 io.Reader 中逐行读取 CSV 并解码为 T，不需要将整个文件读入内存，规则与 Unmarshal 相同
+type Decoder[T any] struct {
+	r       *csv.Reader
+	st      reflect.Type
+	isPtr   bool
+	fields  []field
+	header  []string
+	columns []*field // 每一列对应的字段，nil 表示忽略该列
+	started bool
+	noHead  bool
+}
+
+// NewDecoder 创建从 r 读取的 Decoder，T 不是结构体或包含不支持的字段类型时返回错误
+// 表头在第一次调用 Decode 时读取
+func NewDecoder[T any](r io.Reader, opts ...Option) (*Decoder[T], error) {
+	cfg := newConfig(opts)
+	st, fields, isPtr, err := structFields[T]()
+	if err != nil {
+		return nil, err
+	}
+	cr := csv.NewReader(r)
+	cr.Comma = cfg.comma
+	cr.ReuseRecord = true
+	return &Decoder[T]{r: cr, st: st, isPtr: isPtr, fields: fields, noHead: cfg.noHeader}, nil
+}
+
+// Header 返回读取到的表头，在第一次调用 Decode 之前或使用 WithoutHeader 时返回 nil
+func (d *Decoder[T]) Header() []string {
+	return d.header
+}
+
+// Decode 读取并返回下一行，没有更多数据时返回 io.EOF
+func (d *Decoder[T]) Decode() (T, error) {
+	var zero T
+	if err := d.start(); err != nil {
+		return zero, err
+	}
+	record, err := d.r.Read()
+	if err != nil {
+		return zero, err
+	}
+	pv := reflect.New(d.st)
+	v := pv.Elem()
+	for i, cell := range record {
+		if i >= len(d.columns) || d.columns[i] == nil || cell == "" {
+			continue
+		}
+		f := d.columns[i]
+		fv, err := fieldByIndexAlloc(v, f.index)
+		if err == nil {
+			err = parseValue(fv, cell)
+		}
+		if err != nil {
+			line, _ := d.r.FieldPos(i)
+			return zero, fmt.Errorf("csvutils: line %d, column %q: %w", line, f.name, err)
+		}
+	}
+	if d.isPtr {
+		return pv.Interface().(T), nil
+	}
+	return v.Interface().(T), nil
+}
+
+// Each 依次解码每一行并交给 fn 处理，直到数据结束（返回 nil）、解码失败或 fn 返回错误（返回该错误）
+func (d *Decoder[T]) Each(fn func(item T) error) error {
+	for {
+		item, err := d.Decode()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+}
+
+// start 在第一次解码前读取表头，确定每一列对应的字段
+func (d *Decoder[T]) start() error {
+	if d.started {
+		return nil
+	}
+	d.started = true
+	if d.noHead {
+		d.columns = make([]*field, len(d.fields))
+		for i := range d.fields {
+			d.columns[i] = &d.fields[i]
+		}
+		return nil
+	}
+
+	header, err := d.r.Read()
+	if err != nil {
+		return err
+	}
+	byName := make(map[string]*field, len(d.fields))
+	for i := range d.fields {
+		if _, ok := byName[d.fields[i].name]; !ok {
+			byName[d.fields[i].name] = &d.fields[i]
+		}
+	}
+	d.header = slices.Clone(header)
+	d.columns = make([]*field, len(header))
+	for i, name := range d.header {
+		if i == 0 {
+			// 去掉 Excel 等工具写入的 UTF-8 BOM
+			name = strings.TrimPrefix(name, "\ufeff")
+			d.header[0] = name
+		}
+		d.columns[i] = byName[strings.TrimSpace(name)]
+	}
+	return nil
+}
+
+// fieldByIndexAlloc 与 reflect.Value.FieldByIndex 相同，但会为路径上 nil 的嵌入指针分配内存
+// 未导出的嵌入指针无法分配，此时返回错误
+func fieldByIndexAlloc(v reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, fmt.Errorf("cannot set embedded pointer to unexported struct %s", v.Type().Elem())
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, nil
+}
+
+// parseValue 将单元格 s 解析到字段 v 中，s 不为空
+func parseValue(v reflect.Value, s string) error {
+	if v.Kind() == reflect.Pointer {
+		p := reflect.New(v.Type().Elem())
+		if err := parseValue(p.Elem(), s); err != nil {
+			return err
+		}
+		v.Set(p)
+		return nil
+	}
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(s))
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(strings.TrimSpace(s), 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(strings.TrimSpace(s), 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(strings.TrimSpace(s), v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedType, v.Type())
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+package csvutils
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type user struct {
+	ID      int       `csv:"id"`
+	Name    string    `csv:"name"`
+	Score   float64   `csv:"score"`
+	Active  bool      `csv:"active"`
+	Email   *string   `csv:"email"`
+	Created time.Time `csv:"created"`
+	Secret  string    `csv:"-"`
+	note    string
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+var (
+	created = time.Date(2024, 5, 1, 8, 30, 0, 0, time.UTC)
+	users   = []user{
+		{ID: 1, Name: "Alice", Score: 9.5, Active: true, Email: strPtr("a@example.com"), Created: created},
+		{ID: 2, Name: "Bob, Jr.", Score: 7, Email: nil, Created: created},
+	}
+	usersCSV = "id,name,score,active,email,created\n" +
+		"1,Alice,9.5,true,a@example.com,2024-05-01T08:30:00Z\n" +
+		"2,\"Bob, Jr.\",7,false,,2024-05-01T08:30:00Z\n"
+)
+
+func TestMarshal(t *testing.T) {
+	t.Run("带表头", func(t *testing.T) {
+		data, err := Marshal(users)
+		if err != nil || string(data) != usersCSV {
+			t.Errorf("Marshal() = %q, %v, 期望 %q", data, err, usersCSV)
+		}
+	})
+
+	t.Run("自定义分隔符且不输出表头", func(t *testing.T) {
+		type row struct {
+			A int
+			B string
+		}
+		data, err := Marshal([]row{{1, "x"}, {2, "y;z"}}, WithDelimiter(';'), WithoutHeader())
+		expected := "1;x\n2;\"y;z\"\n"
+		if err != nil || string(data) != expected {
+			t.Errorf("Marshal() = %q, %v, 期望 %q", data, err, expected)
+		}
+	})
+
+	t.Run("空切片只输出表头", func(t *testing.T) {
+		data, err := Marshal([]user{})
+		expected := "id,name,score,active,email,created\n"
+		if err != nil || string(data) != expected {
+			t.Errorf("Marshal() = %q, %v, 期望 %q", data, err, expected)
+		}
+	})
+
+	t.Run("结构体指针和嵌入字段", func(t *testing.T) {
+		type base struct {
+			ID int `csv:"id"`
+		}
+		type item struct {
+			*base
+			Qty uint8 `csv:"qty"`
+		}
+		data, err := Marshal([]*item{{base: &base{ID: 3}, Qty: 5}, {Qty: 1}, nil})
+		expected := "id,qty\n3,5\n,1\n,\n"
+		if err != nil || string(data) != expected {
+			t.Errorf("Marshal() = %q, %v, 期望 %q", data, err, expected)
+		}
+	})
+
+	t.Run("不支持的类型", func(t *testing.T) {
+		if _, err := Marshal([]int{1}); !errors.Is(err, ErrNotStruct) {
+			t.Errorf("Marshal([]int) error = %v, 期望 %v", err, ErrNotStruct)
+		}
+		type bad struct {
+			Tags []string
+		}
+		if _, err := Marshal([]bad{{}}); !errors.Is(err, ErrUnsupportedType) {
+			t.Errorf("Marshal() error = %v, 期望 %v", err, ErrUnsupportedType)
+		}
+	})
+}
+
+func TestUnmarshal(t *testing.T) {
+	t.Run("往返", func(t *testing.T) {
+		result, err := Unmarshal[user](strings.NewReader(usersCSV))
+		if err != nil || !reflect.DeepEqual(result, users) {
+			t.Errorf("Unmarshal() = %+v, %v, 期望 %+v", result, err, users)
+		}
+	})
+
+	t.Run("按表头名称对应列", func(t *testing.T) {
+		input := "\ufeffextra, name ,id\nignored,Carol,7\n"
+		result, err := Unmarshal[user](strings.NewReader(input))
+		expected := []user{{ID: 7, Name: "Carol"}}
+		if err != nil || !reflect.DeepEqual(result, expected) {
+			t.Errorf("Unmarshal() = %+v, %v, 期望 %+v", result, err, expected)
+		}
+	})
+
+	t.Run("无表头和自定义分隔符", func(t *testing.T) {
+		type row struct {
+			A int
+			B *int
+			C string
+		}
+		result, err := Unmarshal[*row](strings.NewReader("1\t2\tx\n3\t\ty\n"), WithDelimiter('\t'), WithoutHeader())
+		two := 2
+		expected := []*row{{A: 1, B: &two, C: "x"}, {A: 3, C: "y"}}
+		if err != nil || !reflect.DeepEqual(result, expected) {
+			t.Errorf("Unmarshal() = %+v, %v, 期望 %+v", result, err, expected)
+		}
+	})
+
+	t.Run("嵌入的结构体指针", func(t *testing.T) {
+		type Base struct {
+			ID int `csv:"id"`
+		}
+		type item struct {
+			*Base
+			Qty int `csv:"qty"`
+		}
+		result, err := Unmarshal[item](strings.NewReader("id,qty\n4,2\n"))
+		if err != nil || len(result) != 1 || result[0].Base == nil || result[0].ID != 4 || result[0].Qty != 2 {
+			t.Errorf("Unmarshal() = %+v, %v, 期望 ID 4 和 Qty 2", result, err)
+		}
+
+		type base struct {
+			ID int `csv:"id"`
+		}
+		type hidden struct {
+			*base
+		}
+		if _, err := Unmarshal[hidden](strings.NewReader("id\n4\n")); err == nil {
+			t.Errorf("Unmarshal() 未导出的嵌入指针 error = nil, 期望错误")
+		}
+	})
+
+	t.Run("空输入", func(t *testing.T) {
+		result, err := Unmarshal[user](strings.NewReader(""))
+		if err != nil || len(result) != 0 {
+			t.Errorf("Unmarshal() = %v, %v, 期望空结果和 nil", result, err)
+		}
+	})
+
+	t.Run("解析失败包含行号和列名", func(t *testing.T) {
+		_, err := Unmarshal[user](strings.NewReader("id,name\n1,a\nx,b\n"))
+		var numErr *strconv.NumError
+		if !errors.As(err, &numErr) || !strings.Contains(err.Error(), `line 3, column "id"`) {
+			t.Errorf("Unmarshal() error = %v, 期望包含行号和 *strconv.NumError", err)
+		}
+	})
+
+	t.Run("溢出", func(t *testing.T) {
+		type row struct {
+			N int8
+		}
+		if _, err := Unmarshal[row](strings.NewReader("N\n300\n")); err == nil {
+			t.Errorf("Unmarshal() error = nil, 期望溢出错误")
+		}
+	})
+}
+
+func TestDecoder(t *testing.T) {
+	dec, err := NewDecoder[user](strings.NewReader(usersCSV))
+	if err != nil {
+		t.Fatalf("NewDecoder() error = %v", err)
+	}
+	if dec.Header() != nil {
+		t.Errorf("Decode 之前 Header() = %v, 期望 nil", dec.Header())
+	}
+	first, err := dec.Decode()
+	if err != nil || !reflect.DeepEqual(first, users[0]) {
+		t.Errorf("Decode() = %+v, %v, 期望 %+v", first, err, users[0])
+	}
+	expectedHeader := []string{"id", "name", "score", "active", "email", "created"}
+	if !reflect.DeepEqual(dec.Header(), expectedHeader) {
+		t.Errorf("Header() = %v, 期望 %v", dec.Header(), expectedHeader)
+	}
+
+	var names []string
+	if err := dec.Each(func(u user) error {
+		names = append(names, u.Name)
+		return nil
+	}); err != nil || !reflect.DeepEqual(names, []string{"Bob, Jr."}) {
+		t.Errorf("Each() = %v, %v, 期望 %v", names, err, []string{"Bob, Jr."})
+	}
+	if _, err := dec.Decode(); err != io.EOF {
+		t.Errorf("结束后 Decode() error = %v, 期望 io.EOF", err)
+	}
+
+	t.Run("fn 返回错误时停止", func(t *testing.T) {
+		dec, _ := NewDecoder[user](strings.NewReader(usersCSV))
+		errStop := errors.New("stop")
+		var count int
+		err := dec.Each(func(user) error {
+			count++
+			return errStop
+		})
+		if err != errStop || count != 1 {
+			t.Errorf("Each() = %v, 调用 %v 次, 期望 %v 和 1 次", err, count, errStop)
+		}
+	})
+}
+
+func TestEncoder(t *testing.T) {
+	var sb strings.Builder
+	enc, err := NewEncoder[user](&sb)
+	if err != nil {
+		t.Fatalf("NewEncoder() error = %v", err)
+	}
+	for _, u := range users {
+		if err := enc.Encode(u); err != nil {
+			t.Fatalf("Encode() error = %v", err)
+		}
+	}
+	if err := enc.Flush(); err != nil || sb.String() != usersCSV {
+		t.Errorf("Encoder 输出 = %q, %v, 期望 %q", sb.String(), err, usersCSV)
+	}
+}
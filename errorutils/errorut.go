@@ -0,0 +1,128 @@
+package errorutils
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Multi 聚合多个错误的错误类型，零值可直接使用，非并发安全
+// 通过 Unwrap() []error 支持 errors.Is 和 errors.As 检查其中的每一个错误
+type Multi struct {
+	errs []error
+}
+
+// Append 追加错误，nil 会被忽略，*Multi 会被展开
+func (m *Multi) Append(errs ...error) {
+	for _, err := range errs {
+		switch e := err.(type) {
+		case nil:
+		case *Multi:
+			if e != nil {
+				m.errs = append(m.errs, e.errs...)
+			}
+		default:
+			m.errs = append(m.errs, err)
+		}
+	}
+}
+
+// Errors 返回聚合的所有错误，m 为 nil 时返回 nil
+func (m *Multi) Errors() []error {
+	if m == nil {
+		return nil
+	}
+	return m.errs
+}
+
+// Len 返回聚合的错误数量
+func (m *Multi) Len() int {
+	if m == nil {
+		return 0
+	}
+	return len(m.errs)
+}
+
+// ErrorOrNil 没有错误时返回 nil，否则返回 m 本身
+// 返回 error 的函数应当使用它，避免返回包含 nil *Multi 的非 nil 接口
+func (m *Multi) ErrorOrNil() error {
+	if m == nil || len(m.errs) == 0 {
+		return nil
+	}
+	return m
+}
+
+// Error 实现 error，只有一个错误时返回它的信息，多个错误时格式为 "3 errors: a; b; c"
+func (m *Multi) Error() string {
+	if m == nil {
+		return "no errors"
+	}
+	switch len(m.errs) {
+	case 0:
+		return "no errors"
+	case 1:
+		return m.errs[0].Error()
+	}
+	var sb strings.Builder
+	sb.WriteString(strconv.Itoa(len(m.errs)))
+	sb.WriteString(" errors: ")
+	for i, err := range m.errs {
+		if i > 0 {
+			sb.WriteString("; ")
+		}
+		sb.WriteString(err.Error())
+	}
+	return sb.String()
+}
+
+// Unwrap 返回聚合的所有错误，供 errors.Is 和 errors.As 使用
+func (m *Multi) Unwrap() []error {
+	if m == nil {
+		return nil
+	}
+	return m.errs
+}
+
+// Append 将 errs 追加到 err 中并返回结果，err 不是 *Multi 时会创建新的 *Multi 并包含 err
+// 所有参数都为 nil 时返回 nil，便于在循环中写 err = errorutils.Append(err, e)
+func Append(err error, errs ...error) error {
+	m, ok := err.(*Multi)
+	if !ok || m == nil {
+		m = &Multi{}
+		m.Append(err)
+	}
+	m.Append(errs...)
+	return m.ErrorOrNil()
+}
+
+// Errors 返回 err 中聚合的所有错误：*Multi 返回其展开结果，其他非 nil 错误返回只包含它自身的切片，nil 返回 nil
+func Errors(err error) []error {
+	var m Multi
+	m.Append(err)
+	return m.errs
+}
+
+// CollectErrors 对 items 中的每个元素执行 fn，将所有返回的错误聚合为 *Multi 返回，没有错误时返回 nil
+// 与遇到第一个错误就返回不同，所有元素都会被处理
+func CollectErrors[T any](items []T, fn func(T) error) error {
+	var m Multi
+	for _, item := range items {
+		m.Append(fn(item))
+	}
+	return m.ErrorOrNil()
+}
+
+// CollectValues 对 items 中的每个元素执行 fn，返回所有成功的结果和聚合的错误
+// 结果按 items 的顺序排列，失败的元素不会出现在结果中
+func CollectValues[T, R any](items []T, fn func(T) (R, error)) ([]R, error) {
+	var m Multi
+	results := make([]R, 0, len(items))
+	for _, item := range items {
+		r, err := fn(item)
+		if err != nil {
+			m.Append(err)
+			continue
+		}
+		results = append(results, r)
+	}
+	return results, m.ErrorOrNil()
+}
@@ -0,0 +1,195 @@
+package errorutils
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+var (
+	errA = errors.New("a")
+	errB = errors.New("b")
+	errC = errors.New("c")
+)
+
+func TestMulti(t *testing.T) {
+	t.Run("零值可直接使用", func(t *testing.T) {
+		var m Multi
+		if m.Len() != 0 || m.ErrorOrNil() != nil {
+			t.Errorf("零值 Multi: Len() = %v, ErrorOrNil() = %v, 期望 0 和 nil", m.Len(), m.ErrorOrNil())
+		}
+		if result := m.Error(); result != "no errors" {
+			t.Errorf("Error() = %q, 期望 %q", result, "no errors")
+		}
+	})
+
+	t.Run("nil 指针", func(t *testing.T) {
+		var m *Multi
+		if m.Len() != 0 || m.Errors() != nil || m.ErrorOrNil() != nil || m.Unwrap() != nil {
+			t.Errorf("nil *Multi: Len() = %v, Errors() = %v, ErrorOrNil() = %v", m.Len(), m.Errors(), m.ErrorOrNil())
+		}
+		if result := m.Error(); result != "no errors" {
+			t.Errorf("nil *Multi: Error() = %q, 期望 %q", result, "no errors")
+		}
+		if errors.Is(m, errA) {
+			t.Errorf("errors.Is(nil *Multi, a) = true, 期望 false")
+		}
+	})
+
+	t.Run("忽略 nil 并展开 *Multi", func(t *testing.T) {
+		var inner Multi
+		inner.Append(errB, errC)
+		var m Multi
+		m.Append(nil, errA, nil, &inner, (*Multi)(nil))
+		expected := []error{errA, errB, errC}
+		if result := m.Errors(); !reflect.DeepEqual(result, expected) {
+			t.Errorf("Errors() = %v, 期望 %v", result, expected)
+		}
+	})
+
+	t.Run("保留包装的错误", func(t *testing.T) {
+		joined := errors.Join(errA, errB)
+		var m Multi
+		m.Append(joined)
+		if m.Len() != 1 {
+			t.Errorf("Len() = %v, 期望 %v", m.Len(), 1)
+		}
+	})
+
+	t.Run("错误信息", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			errs     []error
+			expected string
+		}{
+			{"一个错误", []error{errA}, "a"},
+			{"多个错误", []error{errA, errB, errC}, "3 errors: a; b; c"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				var m Multi
+				m.Append(tt.errs...)
+				if result := m.Error(); result != tt.expected {
+					t.Errorf("Error() = %q, 期望 %q", result, tt.expected)
+				}
+			})
+		}
+	})
+
+	t.Run("errors.Is 和 errors.As", func(t *testing.T) {
+		pathErr := &fs.PathError{Op: "open", Path: "x", Err: fs.ErrNotExist}
+		var m Multi
+		m.Append(errA, fmt.Errorf("wrap: %w", pathErr))
+		err := m.ErrorOrNil()
+		if !errors.Is(err, errA) || !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("errors.Is(%v) = false, 期望 true", err)
+		}
+		if errors.Is(err, errB) {
+			t.Errorf("errors.Is(%v, b) = true, 期望 false", err)
+		}
+		var target *fs.PathError
+		if !errors.As(err, &target) || target != pathErr {
+			t.Errorf("errors.As() = %v, 期望 %v", target, pathErr)
+		}
+	})
+}
+
+func TestAppend(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		errs     []error
+		expected []error
+	}{
+		{"全部为 nil", nil, []error{nil, nil}, nil},
+		{"nil 加错误", nil, []error{errA}, []error{errA}},
+		{"普通错误加错误", errA, []error{errB}, []error{errA, errB}},
+		{"只有 err", errA, nil, []error{errA}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Append(tt.err, tt.errs...)
+			if tt.expected == nil {
+				if result != nil {
+					t.Errorf("Append() = %v, 期望 nil", result)
+				}
+				return
+			}
+			if got := Errors(result); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Append() = %v, 期望 %v", got, tt.expected)
+			}
+		})
+	}
+
+	t.Run("在循环中追加到同一个 *Multi", func(t *testing.T) {
+		var err error
+		for _, e := range []error{errA, nil, errB, errC} {
+			err = Append(err, e)
+		}
+		m, ok := err.(*Multi)
+		if !ok || m.Len() != 3 {
+			t.Errorf("Append() = %#v, 期望包含 3 个错误的 *Multi", err)
+		}
+	})
+}
+
+func TestErrors(t *testing.T) {
+	var m Multi
+	m.Append(errA, errB)
+	tests := []struct {
+		name     string
+		err      error
+		expected []error
+	}{
+		{"nil", nil, nil},
+		{"普通错误", errA, []error{errA}},
+		{"Multi", &m, []error{errA, errB}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := Errors(tt.err); !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Errors(%v) = %v, 期望 %v", tt.err, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCollectErrors(t *testing.T) {
+	var calls int
+	err := CollectErrors([]int{1, 2, 3, 4}, func(n int) error {
+		calls++
+		if n%2 == 0 {
+			return fmt.Errorf("even %d", n)
+		}
+		return nil
+	})
+	if calls != 4 {
+		t.Errorf("fn 调用了 %v 次, 期望 %v", calls, 4)
+	}
+	if err == nil || err.Error() != "2 errors: even 2; even 4" {
+		t.Errorf("CollectErrors() = %v, 期望 %q", err, "2 errors: even 2; even 4")
+	}
+
+	if err := CollectErrors([]int{1, 3}, func(int) error { return nil }); err != nil {
+		t.Errorf("CollectErrors() = %v, 期望 nil", err)
+	}
+}
+
+func TestCollectValues(t *testing.T) {
+	results, err := CollectValues([]string{"1", "x", "3", "y"}, strconv.Atoi)
+	if expected := []int{1, 3}; !reflect.DeepEqual(results, expected) {
+		t.Errorf("CollectValues() = %v, 期望 %v", results, expected)
+	}
+	var numErr *strconv.NumError
+	if len(Errors(err)) != 2 || !errors.As(err, &numErr) {
+		t.Errorf("CollectValues() err = %v, 期望包含 2 个 *strconv.NumError", err)
+	}
+
+	results, err = CollectValues([]string{}, strconv.Atoi)
+	if len(results) != 0 || err != nil {
+		t.Errorf("CollectValues(空) = %v, %v, 期望空切片和 nil", results, err)
+	}
+}
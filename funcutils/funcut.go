@@ -0,0 +1,640 @@
+package funcutils
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/jiu-u/gogout/backoff"
+	"github.com/jiu-u/gogout/timeutils"
+)
+
+// Compose2 组合两个函数，返回的函数先调用 g 再调用 f，即 f(g(x))
+// 与数学中的函数组合 f ∘ g 顺序一致
+func Compose2[A, B, C any](f func(B) C, g func(A) B) func(A) C {
+	return func(a A) C {
+		return f(g(a))
+	}
+}
+
+// Compose3 组合三个函数，返回的函数计算 f(g(h(x)))
+func Compose3[A, B, C, D any](f func(C) D, g func(B) C, h func(A) B) func(A) D {
+	return func(a A) D {
+		return f(g(h(a)))
+	}
+}
+
+// Pipe2 按从左到右的顺序串联两个函数，返回的函数计算 g(f(x))
+// 常用于把多个小的转换函数拼成一个传给 sliceutils.Map
+func Pipe2[A, B, C any](f func(A) B, g func(B) C) func(A) C {
+	return func(a A) C {
+		return g(f(a))
+	}
+}
+
+// Pipe3 按从左到右的顺序串联三个函数，返回的函数计算 h(g(f(x)))
+func Pipe3[A, B, C, D any](f func(A) B, g func(B) C, h func(C) D) func(A) D {
+	return func(a A) D {
+		return h(g(f(a)))
+	}
+}
+
+// memoConfig Memoize 的配置
+type memoConfig struct {
+	maxSize int
+	ttl     time.Duration
+}
+
+// MemoOption Memoize 的可选配置项
+type MemoOption func(*memoConfig)
+
+// WithMaxSize 限制缓存的最大条目数，超出时淘汰最近最少使用（LRU）的条目
+// maxSize <= 0 表示不限制
+func WithMaxSize(maxSize int) MemoOption {
+	return func(c *memoConfig) {
+		c.maxSize = maxSize
+	}
+}
+
+// WithTTL 设置缓存条目的有效期，过期的条目会在下次访问时重新计算
+// ttl <= 0 表示永不过期
+func WithTTL(ttl time.Duration) MemoOption {
+	return func(c *memoConfig) {
+		c.ttl = ttl
+	}
+}
+
+// memoEntry 缓存条目
+type memoEntry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+// memoCache Memoize 使用的并发安全缓存，支持 LRU 淘汰和过期时间
+type memoCache[K comparable, V any] struct {
+	mu    sync.Mutex
+	cfg   memoConfig
+	items map[K]*list.Element
+	order *list.List // 头部为最近使用的条目
+}
+
+func newMemoCache[K comparable, V any](opts []MemoOption) *memoCache[K, V] {
+	c := &memoCache[K, V]{
+		items: make(map[K]*list.Element),
+		order: list.New(),
+	}
+	for _, opt := range opts {
+		opt(&c.cfg)
+	}
+	return c
+}
+
+func (c *memoCache[K, V]) get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero V
+	elem, ok := c.items[key]
+	if !ok {
+		return zero, false
+	}
+	entry := elem.Value.(*memoEntry[K, V])
+	if c.cfg.ttl > 0 && time.Now().After(entry.expiresAt) {
+		c.order.Remove(elem)
+		delete(c.items, key)
+		return zero, false
+	}
+	c.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+func (c *memoCache[K, V]) put(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expiresAt time.Time
+	if c.cfg.ttl > 0 {
+		expiresAt = time.Now().Add(c.cfg.ttl)
+	}
+	if elem, ok := c.items[key]; ok {
+		entry := elem.Value.(*memoEntry[K, V])
+		entry.value, entry.expiresAt = value, expiresAt
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&memoEntry[K, V]{key: key, value: value, expiresAt: expiresAt})
+	if c.cfg.maxSize > 0 && c.order.Len() > c.cfg.maxSize {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*memoEntry[K, V]).key)
+	}
+}
+
+// Memoize 返回 fn 的带缓存版本，相同参数只计算一次，适用于开销较大的纯函数
+// 可以通过 WithMaxSize 限制缓存大小（LRU 淘汰），通过 WithTTL 设置过期时间
+// 返回的函数并发安全；同一个参数的并发首次调用可能会各自执行一次 fn
+func Memoize[K comparable, V any](fn func(K) V, opts ...MemoOption) func(K) V {
+	cache := newMemoCache[K, V](opts)
+	return func(key K) V {
+		if v, ok := cache.get(key); ok {
+			return v
+		}
+		v := fn(key)
+		cache.put(key, v)
+		return v
+	}
+}
+
+// MemoizeErr 与 Memoize 相同，但用于可能失败的函数
+// 只有成功的结果会被缓存，返回错误时下次调用会重新执行 fn
+func MemoizeErr[K comparable, V any](fn func(K) (V, error), opts ...MemoOption) func(K) (V, error) {
+	cache := newMemoCache[K, V](opts)
+	return func(key K) (V, error) {
+		if v, ok := cache.get(key); ok {
+			return v, nil
+		}
+		v, err := fn(key)
+		if err != nil {
+			return v, err
+		}
+		cache.put(key, v)
+		return v, nil
+	}
+}
+
+// OnceFunc 返回一个只会执行一次 fn 的函数，并发安全
+// 如果 fn 发生 panic，之后的每次调用都会以相同的值 panic
+func OnceFunc(fn func()) func() {
+	return sync.OnceFunc(fn)
+}
+
+// OnceValue 返回一个惰性计算并缓存 fn 结果的函数，fn 只会在第一次调用时执行
+// 适用于编译后的正则表达式、解析后的模板等懒加载单例
+func OnceValue[T any](fn func() T) func() T {
+	return sync.OnceValue(fn)
+}
+
+// onceConfig OnceValueErr 的配置
+type onceConfig struct {
+	retryOnError bool
+}
+
+// OnceOption OnceValueErr 的可选配置项
+type OnceOption func(*onceConfig)
+
+// WithRetryOnError 设置 fn 返回错误时不缓存结果，下一次调用会重新执行 fn
+// 默认情况下错误与值一样只计算一次并被缓存
+func WithRetryOnError() OnceOption {
+	return func(c *onceConfig) {
+		c.retryOnError = true
+	}
+}
+
+// OnceValueErr 返回一个惰性计算并缓存 fn 结果和错误的函数，并发安全
+// 默认错误也会被缓存；使用 WithRetryOnError 时只有成功的结果会被缓存，
+// 此时 fn 的调用是串行的，不会有两个 goroutine 同时执行 fn
+func OnceValueErr[T any](fn func() (T, error), opts ...OnceOption) func() (T, error) {
+	var cfg onceConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if !cfg.retryOnError {
+		return sync.OnceValues(fn)
+	}
+
+	var (
+		mu    sync.Mutex
+		done  bool
+		value T
+	)
+	return func() (T, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if done {
+			return value, nil
+		}
+		v, err := fn()
+		if err != nil {
+			return v, err
+		}
+		value, done = v, true
+		return value, nil
+	}
+}
+
+// debounceConfig Debounce 的配置
+type debounceConfig struct {
+	clock timeutils.Clock
+}
+
+// DebounceOption Debounce 和 DebounceArg 的可选配置项
+type DebounceOption func(*debounceConfig)
+
+// WithDebounceClock 设置计时使用的时钟，默认为 timeutils.RealClock，测试中可以使用 timeutils.FakeClock
+func WithDebounceClock(clock timeutils.Clock) DebounceOption {
+	return func(c *debounceConfig) {
+		c.clock = clock
+	}
+}
+
+// Debounce 返回 fn 的防抖版本：连续调用时只在最后一次调用之后静默 wait 时间才执行一次 fn
+// 适用于文件监听、自动保存等会产生突发事件的场景
+// cancel 取消尚未执行的调用；fn 在独立的 goroutine 中执行
+func Debounce(fn func(), wait time.Duration, opts ...DebounceOption) (debounced func(), cancel func()) {
+	d, c := DebounceArg(func(struct{}) { fn() }, wait, opts...)
+	return func() { d(struct{}{}) }, c
+}
+
+// DebounceArg 与 Debounce 相同，但可以携带参数，执行 fn 时使用最后一次调用的参数
+func DebounceArg[T any](fn func(T), wait time.Duration, opts ...DebounceOption) (debounced func(T), cancel func()) {
+	var cfg debounceConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	clock := timeutils.OrRealClock(cfg.clock)
+
+	var (
+		mu    sync.Mutex
+		timer timeutils.Timer
+		gen   uint64 // 每次调用或取消时递增，已触发但尚未拿到锁的旧定时器据此放弃执行
+		last  T
+	)
+
+	debounced = func(arg T) {
+		mu.Lock()
+		defer mu.Unlock()
+		last = arg
+		if timer != nil {
+			timer.Stop()
+		}
+		gen++
+		mine := gen
+		timer = clock.AfterFunc(wait, func() {
+			mu.Lock()
+			if mine != gen {
+				mu.Unlock()
+				return
+			}
+			v := last
+			timer = nil
+			mu.Unlock()
+			fn(v)
+		})
+	}
+
+	cancel = func() {
+		mu.Lock()
+		defer mu.Unlock()
+		gen++
+		if timer != nil {
+			timer.Stop()
+			timer = nil
+		}
+	}
+	return debounced, cancel
+}
+
+// throttleConfig Throttle 的配置
+type throttleConfig struct {
+	trailing bool
+}
+
+// ThrottleOption Throttle 的可选配置项
+type ThrottleOption func(*throttleConfig)
+
+// WithTrailing 设置在间隔期内被忽略的调用会在间隔结束时补执行一次
+func WithTrailing() ThrottleOption {
+	return func(c *throttleConfig) {
+		c.trailing = true
+	}
+}
+
+// Throttle 返回 fn 的节流版本，保证每个 interval 内最多执行一次 fn
+// 间隔期外的调用会在调用方的 goroutine 中立即执行；间隔期内的调用默认被丢弃，
+// 使用 WithTrailing 时会在间隔结束后于独立的 goroutine 中补执行一次
+func Throttle(fn func(), interval time.Duration, opts ...ThrottleOption) func() {
+	var cfg throttleConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var (
+		mu    sync.Mutex
+		last  time.Time
+		timer *time.Timer
+	)
+	return func() {
+		mu.Lock()
+		now := time.Now()
+		elapsed := now.Sub(last)
+		if last.IsZero() || elapsed >= interval {
+			last = now
+			mu.Unlock()
+			fn()
+			return
+		}
+		if cfg.trailing && timer == nil {
+			timer = time.AfterFunc(interval-elapsed, func() {
+				mu.Lock()
+				last = time.Now()
+				timer = nil
+				mu.Unlock()
+				fn()
+			})
+		}
+		mu.Unlock()
+	}
+}
+
+// BackoffStrategy 重试的退避策略，Next 返回第 attempt 次（从 1 开始）失败后到下一次尝试之间的等待时间
+type BackoffStrategy interface {
+	Next(attempt int) time.Duration
+}
+
+// BackoffFunc 函数形式的 BackoffStrategy
+type BackoffFunc func(attempt int) time.Duration
+
+// Next 实现 BackoffStrategy
+func (f BackoffFunc) Next(attempt int) time.Duration {
+	return f(attempt)
+}
+
+// ConstantBackoff 每次等待固定的时间 d
+//
+// Deprecated: 使用 backoff.Constant
+func ConstantBackoff(d time.Duration) BackoffStrategy {
+	return backoff.Constant(d)
+}
+
+// ExponentialBackoff 指数退避：第 n 次失败后等待 base * 2^(n-1)，最长不超过 maxDelay
+// maxDelay <= 0 表示不限制
+//
+// Deprecated: 使用 backoff.Exponential(base, 2)，需要上限时再用 backoff.MaxInterval 包装
+func ExponentialBackoff(base, maxDelay time.Duration) BackoffStrategy {
+	s := backoff.Exponential(base, 2)
+	if maxDelay > 0 {
+		s = backoff.MaxInterval(s, maxDelay)
+	}
+	return s
+}
+
+// Jitter 为退避策略添加随机抖动，等待时间在 [d*(1-factor), d*(1+factor)] 范围内均匀分布
+// 用于避免大量客户端同时重试；factor 会被限制在 [0, 1] 范围内
+//
+// Deprecated: 使用 backoff.Jitter
+func Jitter(b BackoffStrategy, factor float64) BackoffStrategy {
+	return backoff.Jitter(b, factor)
+}
+
+// retryConfig Retry 的配置
+type retryConfig struct {
+	retryIf func(error) bool
+	clock   timeutils.Clock
+}
+
+// RetryOption Retry 的可选配置项
+type RetryOption func(*retryConfig)
+
+// RetryIf 设置判断错误是否可以重试的函数，返回 false 时 Retry 立即返回该错误
+// 默认所有错误都会重试
+func RetryIf(predicate func(error) bool) RetryOption {
+	return func(c *retryConfig) {
+		c.retryIf = predicate
+	}
+}
+
+// WithRetryClock 设置等待退避时间使用的时钟，默认为 timeutils.RealClock，测试中可以使用 timeutils.FakeClock
+func WithRetryClock(clock timeutils.Clock) RetryOption {
+	return func(c *retryConfig) {
+		c.clock = clock
+	}
+}
+
+// Retry 最多执行 attempts 次 fn，直到成功、遇到不可重试的错误或 ctx 被取消
+// 两次尝试之间按 strategy 等待，strategy 为 nil 时立即重试，返回负数（如 backoff.Stop）时停止重试；attempts <= 0 时按 1 次处理
+// 全部失败时返回最后一次的错误；等待期间 ctx 被取消时返回 ctx.Err() 与最后一次错误的组合
+func Retry(ctx context.Context, attempts int, strategy BackoffStrategy, fn func(ctx context.Context) error, opts ...RetryOption) error {
+	_, err := RetryValue(ctx, attempts, strategy, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	}, opts...)
+	return err
+}
+
+// RetryValue 与 Retry 相同，但 fn 返回一个结果，成功时返回该结果
+func RetryValue[T any](ctx context.Context, attempts int, strategy BackoffStrategy, fn func(ctx context.Context) (T, error), opts ...RetryOption) (T, error) {
+	var cfg retryConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	attempts = max(attempts, 1)
+	clock := timeutils.OrRealClock(cfg.clock)
+
+	var (
+		result T
+		err    error
+	)
+	for attempt := 1; ; attempt++ {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return result, errors.Join(ctxErr, err)
+		}
+		result, err = fn(ctx)
+		if err == nil {
+			return result, nil
+		}
+		if attempt >= attempts || (cfg.retryIf != nil && !cfg.retryIf(err)) {
+			return result, err
+		}
+
+		var delay time.Duration
+		if strategy != nil {
+			delay = strategy.Next(attempt)
+		}
+		if delay < 0 {
+			return result, err
+		}
+		if delay == 0 {
+			continue
+		}
+		timer := clock.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result, errors.Join(ctx.Err(), err)
+		case <-timer.C():
+		}
+	}
+}
+
+// If 根据 cond 返回 thenValue 或 elseValue，相当于三元表达式 cond ? thenValue : elseValue
+// 注意：两个参数都会在调用前求值，需要惰性求值时请使用 IfFunc
+func If[T any](cond bool, thenValue, elseValue T) T {
+	if cond {
+		return thenValue
+	}
+	return elseValue
+}
+
+// IfFunc 根据 cond 调用 thenFn 或 elseFn 并返回其结果，只有被选中的函数会执行
+func IfFunc[T any](cond bool, thenFn, elseFn func() T) T {
+	if cond {
+		return thenFn()
+	}
+	return elseFn()
+}
+
+// Must 在 err 不为 nil 时 panic，否则返回 v
+// 用于初始化阶段不可能失败或失败即应终止的调用，例如 Must(template.ParseFiles(...))
+func Must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Try 执行 fn 并返回其错误，fn 中发生的 panic 会被恢复并转换为 *PanicError 返回
+// panic 的值是 error 时可以通过 errors.Is/As 访问
+func Try(fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = panicToError(r)
+		}
+	}()
+	return fn()
+}
+
+// Try1 与 Try 相同，但 fn 返回一个结果；发生 panic 时返回零值和对应的错误
+func Try1[T any](fn func() (T, error)) (result T, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			var zero T
+			result, err = zero, panicToError(r)
+		}
+	}()
+	return fn()
+}
+
+// PanicError 由 panic 恢复而来的错误，保存 panic 的值和发生时的调用栈
+type PanicError struct {
+	// Value panic 的值
+	Value any
+	// Stack 发生 panic 时 goroutine 的调用栈
+	Stack []byte
+}
+
+// Error 实现 error 接口
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap panic 的值是 error 时返回它，使 errors.Is/As 可以穿透 PanicError
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// panicToError 将 recover 得到的值转换为 *PanicError，需要在 defer 的函数中直接调用以保留调用栈
+func panicToError(r any) error {
+	return &PanicError{Value: r, Stack: debug.Stack()}
+}
+
+// Tap 对 v 执行副作用函数 fn（如记录日志、上报指标），然后原样返回 v
+// 便于在 Map/Reduce 等管道中插入调试代码而无需改写结构
+func Tap[T any](v T, fn func(T)) T {
+	fn(v)
+	return v
+}
+
+// TapFunc 返回一个对参数执行 fn 后原样返回的函数，可以直接传给 sliceutils.Map
+func TapFunc[T any](fn func(T)) func(T) T {
+	return func(v T) T {
+		fn(v)
+		return v
+	}
+}
+
+// Identity 原样返回参数，可作为 Map、GroupBy 等函数的默认映射
+func Identity[T any](v T) T {
+	return v
+}
+
+// Constant 返回一个总是返回 v 的函数
+func Constant[T any](v T) func() T {
+	return func() T {
+		return v
+	}
+}
+
+// Noop 什么也不做，可作为默认回调或占位的取消函数
+func Noop() {}
+
+// Noop1 接收一个参数但什么也不做，可作为 sliceutils.ForEach 等函数的占位回调
+func Noop1[T any](T) {}
+
+// NoopErr 什么也不做并返回 nil，可作为 func() error 类型的默认钩子
+func NoopErr() error {
+	return nil
+}
+
+// Not 返回对谓词 p 取反的谓词
+func Not[T any](p func(T) bool) func(T) bool {
+	return func(v T) bool {
+		return !p(v)
+	}
+}
+
+// And 返回所有谓词都满足时才为 true 的谓词，按顺序短路求值
+// 没有谓词时总是返回 true
+func And[T any](preds ...func(T) bool) func(T) bool {
+	return func(v T) bool {
+		for _, p := range preds {
+			if !p(v) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or 返回任一谓词满足即为 true 的谓词，按顺序短路求值
+// 没有谓词时总是返回 false
+func Or[T any](preds ...func(T) bool) func(T) bool {
+	return func(v T) bool {
+		for _, p := range preds {
+			if p(v) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Safely 执行 fn，恢复其中发生的 panic 并以 *PanicError（包含调用栈）的形式返回
+// 用于隔离插件回调、用户传入的函数等不可信代码，避免整个进程崩溃
+func Safely(fn func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = panicToError(r)
+		}
+	}()
+	fn()
+	return nil
+}
+
+// SafelyValue 与 Safely 相同，但 fn 返回一个结果；发生 panic 时返回零值和 *PanicError
+func SafelyValue[T any](fn func() T) (result T, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			var zero T
+			result, err = zero, panicToError(r)
+		}
+	}()
+	return fn(), nil
+}
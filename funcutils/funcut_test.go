@@ -0,0 +1,698 @@
+package funcutils
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jiu-u/gogout/backoff"
+	"github.com/jiu-u/gogout/timeutils"
+)
+
+func TestCompose(t *testing.T) {
+	double := func(i int) int { return i * 2 }
+	inc := func(i int) int { return i + 1 }
+
+	t.Run("Compose2 先调用右侧函数", func(t *testing.T) {
+		fn := Compose2(double, inc)
+		if result := fn(3); result != 8 {
+			t.Errorf("Compose2() = %v, 期望 %v", result, 8)
+		}
+	})
+
+	t.Run("Compose3 类型转换", func(t *testing.T) {
+		fn := Compose3(strings.ToUpper, strconv.Itoa, inc)
+		if result := fn(9); result != "10" {
+			t.Errorf("Compose3() = %v, 期望 %v", result, "10")
+		}
+	})
+}
+
+func TestPipe(t *testing.T) {
+	double := func(i int) int { return i * 2 }
+	inc := func(i int) int { return i + 1 }
+
+	t.Run("Pipe2 从左到右", func(t *testing.T) {
+		fn := Pipe2(double, inc)
+		if result := fn(3); result != 7 {
+			t.Errorf("Pipe2() = %v, 期望 %v", result, 7)
+		}
+	})
+
+	t.Run("Pipe3 类型转换", func(t *testing.T) {
+		fn := Pipe3(strings.TrimSpace, strconv.Quote, func(s string) int { return len(s) })
+		if result := fn("  go  "); result != 4 {
+			t.Errorf("Pipe3() = %v, 期望 %v", result, 4)
+		}
+	})
+}
+
+func TestMemoize(t *testing.T) {
+	t.Run("相同参数只计算一次", func(t *testing.T) {
+		calls := 0
+		square := Memoize(func(i int) int {
+			calls++
+			return i * i
+		})
+		for i := 0; i < 3; i++ {
+			if result := square(4); result != 16 {
+				t.Errorf("Memoize() = %v, 期望 %v", result, 16)
+			}
+		}
+		square(5)
+		if calls != 2 {
+			t.Errorf("fn 调用次数 = %v, 期望 %v", calls, 2)
+		}
+	})
+
+	t.Run("LRU 淘汰", func(t *testing.T) {
+		calls := map[int]int{}
+		fn := Memoize(func(i int) int {
+			calls[i]++
+			return i
+		}, WithMaxSize(2))
+		fn(1)
+		fn(2)
+		fn(1) // 1 成为最近使用
+		fn(3) // 淘汰 2
+		fn(1)
+		fn(2)
+		if calls[1] != 1 || calls[2] != 2 || calls[3] != 1 {
+			t.Errorf("fn 调用次数 = %v, 期望 map[1:1 2:2 3:1]", calls)
+		}
+	})
+
+	t.Run("TTL 过期", func(t *testing.T) {
+		calls := 0
+		fn := Memoize(func(s string) int {
+			calls++
+			return len(s)
+		}, WithTTL(20*time.Millisecond))
+		fn("a")
+		fn("a")
+		time.Sleep(30 * time.Millisecond)
+		fn("a")
+		if calls != 2 {
+			t.Errorf("fn 调用次数 = %v, 期望 %v", calls, 2)
+		}
+	})
+
+	t.Run("并发调用", func(t *testing.T) {
+		var count atomic.Int32
+		fn := Memoize(func(i int) int {
+			count.Add(1)
+			return i
+		}, WithMaxSize(10))
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				if result := fn(i % 5); result != i%5 {
+					t.Errorf("Memoize() = %v, 期望 %v", result, i%5)
+				}
+			}(i)
+		}
+		wg.Wait()
+		if count.Load() < 5 {
+			t.Errorf("fn 调用次数 = %v, 至少应为 %v", count.Load(), 5)
+		}
+	})
+}
+
+func TestMemoizeErr(t *testing.T) {
+	calls := 0
+	fail := true
+	fn := MemoizeErr(func(s string) (int, error) {
+		calls++
+		if fail {
+			return 0, errors.New("temporary")
+		}
+		return len(s), nil
+	})
+
+	if _, err := fn("abc"); err == nil {
+		t.Fatalf("MemoizeErr() 应该返回错误")
+	}
+	fail = false
+	for i := 0; i < 2; i++ {
+		if result, err := fn("abc"); err != nil || result != 3 {
+			t.Errorf("MemoizeErr() = (%v, %v), 期望 (3, nil)", result, err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("fn 调用次数 = %v, 期望 %v（错误不应被缓存）", calls, 2)
+	}
+}
+
+func TestOnceFunc(t *testing.T) {
+	calls := 0
+	fn := OnceFunc(func() { calls++ })
+	fn()
+	fn()
+	if calls != 1 {
+		t.Errorf("fn 调用次数 = %v, 期望 %v", calls, 1)
+	}
+}
+
+func TestOnceValue(t *testing.T) {
+	var calls atomic.Int32
+	fn := OnceValue(func() int {
+		calls.Add(1)
+		return 42
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if result := fn(); result != 42 {
+				t.Errorf("OnceValue() = %v, 期望 %v", result, 42)
+			}
+		}()
+	}
+	wg.Wait()
+	if calls.Load() != 1 {
+		t.Errorf("fn 调用次数 = %v, 期望 %v", calls.Load(), 1)
+	}
+}
+
+func TestOnceValueErr(t *testing.T) {
+	t.Run("默认缓存错误", func(t *testing.T) {
+		calls := 0
+		fn := OnceValueErr(func() (int, error) {
+			calls++
+			return 0, errors.New("failed")
+		})
+		fn()
+		if _, err := fn(); err == nil {
+			t.Errorf("OnceValueErr() 应该返回缓存的错误")
+		}
+		if calls != 1 {
+			t.Errorf("fn 调用次数 = %v, 期望 %v", calls, 1)
+		}
+	})
+
+	t.Run("出错时重试", func(t *testing.T) {
+		calls := 0
+		fn := OnceValueErr(func() (int, error) {
+			calls++
+			if calls < 3 {
+				return 0, errors.New("failed")
+			}
+			return calls, nil
+		}, WithRetryOnError())
+
+		for i := 0; i < 2; i++ {
+			if _, err := fn(); err == nil {
+				t.Errorf("第 %d 次调用应该返回错误", i+1)
+			}
+		}
+		for i := 0; i < 2; i++ {
+			if result, err := fn(); err != nil || result != 3 {
+				t.Errorf("OnceValueErr() = (%v, %v), 期望 (3, nil)", result, err)
+			}
+		}
+		if calls != 3 {
+			t.Errorf("fn 调用次数 = %v, 期望 %v", calls, 3)
+		}
+	})
+}
+
+func TestDebounce(t *testing.T) {
+	t.Run("使用可控时钟", func(t *testing.T) {
+		clock := timeutils.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		calls := 0
+		debounced, _ := Debounce(func() { calls++ }, time.Second, WithDebounceClock(clock))
+		debounced()
+		clock.Advance(900 * time.Millisecond)
+		debounced()
+		clock.Advance(900 * time.Millisecond)
+		if calls != 0 {
+			t.Fatalf("静默期未满时 fn 调用次数 = %v, 期望 %v", calls, 0)
+		}
+		clock.Advance(100 * time.Millisecond)
+		if calls != 1 {
+			t.Errorf("fn 调用次数 = %v, 期望 %v", calls, 1)
+		}
+	})
+
+	t.Run("合并连续调用", func(t *testing.T) {
+		var calls atomic.Int32
+		debounced, _ := Debounce(func() { calls.Add(1) }, 30*time.Millisecond)
+		for i := 0; i < 5; i++ {
+			debounced()
+			time.Sleep(5 * time.Millisecond)
+		}
+		time.Sleep(80 * time.Millisecond)
+		if calls.Load() != 1 {
+			t.Errorf("fn 调用次数 = %v, 期望 %v", calls.Load(), 1)
+		}
+	})
+
+	t.Run("取消", func(t *testing.T) {
+		var calls atomic.Int32
+		debounced, cancel := Debounce(func() { calls.Add(1) }, 20*time.Millisecond)
+		debounced()
+		cancel()
+		time.Sleep(50 * time.Millisecond)
+		if calls.Load() != 0 {
+			t.Errorf("fn 调用次数 = %v, 期望 %v", calls.Load(), 0)
+		}
+	})
+
+	t.Run("已触发的旧定时器不会重复执行", func(t *testing.T) {
+		clock := &firedClock{}
+		var got []string
+		debounced, cancel := DebounceArg(func(s string) { got = append(got, s) }, time.Second, WithDebounceClock(clock))
+		debounced("a")
+		debounced("b") // 第一个定时器已触发，Stop 返回 false
+		clock.funcs[0]()
+		if len(got) != 0 {
+			t.Fatalf("旧定时器执行了 fn(%v), 期望不执行", got)
+		}
+		clock.funcs[1]()
+		if len(got) != 1 || got[0] != "b" {
+			t.Errorf("fn 调用 = %v, 期望 [b]", got)
+		}
+
+		debounced("c")
+		cancel()
+		clock.funcs[2]()
+		if len(got) != 1 {
+			t.Errorf("取消后 fn 调用 = %v, 期望 [b]", got)
+		}
+	})
+
+	t.Run("使用最后一次的参数", func(t *testing.T) {
+		result := make(chan string, 1)
+		debounced, _ := DebounceArg(func(s string) { result <- s }, 20*time.Millisecond)
+		debounced("a")
+		debounced("b")
+		debounced("c")
+		select {
+		case v := <-result:
+			if v != "c" {
+				t.Errorf("DebounceArg() 参数 = %v, 期望 %v", v, "c")
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("DebounceArg() 没有执行")
+		}
+	})
+}
+
+// firedClock 记录 AfterFunc 的函数但不执行，Stop 总是返回 false，模拟定时器已经触发、回调还在等待的情况
+type firedClock struct {
+	timeutils.Clock
+	funcs []func()
+}
+
+func (c *firedClock) AfterFunc(d time.Duration, f func()) timeutils.Timer {
+	c.funcs = append(c.funcs, f)
+	return firedTimer{}
+}
+
+type firedTimer struct {
+	timeutils.Timer
+}
+
+func (firedTimer) Stop() bool { return false }
+
+func TestThrottle(t *testing.T) {
+	t.Run("间隔内只执行一次", func(t *testing.T) {
+		var calls atomic.Int32
+		throttled := Throttle(func() { calls.Add(1) }, 50*time.Millisecond)
+		for i := 0; i < 5; i++ {
+			throttled()
+		}
+		if calls.Load() != 1 {
+			t.Errorf("fn 调用次数 = %v, 期望 %v", calls.Load(), 1)
+		}
+		time.Sleep(60 * time.Millisecond)
+		throttled()
+		if calls.Load() != 2 {
+			t.Errorf("fn 调用次数 = %v, 期望 %v", calls.Load(), 2)
+		}
+	})
+
+	t.Run("尾调用", func(t *testing.T) {
+		var calls atomic.Int32
+		throttled := Throttle(func() { calls.Add(1) }, 30*time.Millisecond, WithTrailing())
+		for i := 0; i < 5; i++ {
+			throttled()
+		}
+		if calls.Load() != 1 {
+			t.Errorf("fn 调用次数 = %v, 期望 %v", calls.Load(), 1)
+		}
+		time.Sleep(60 * time.Millisecond)
+		if calls.Load() != 2 {
+			t.Errorf("fn 调用次数 = %v, 期望 %v（应补执行一次）", calls.Load(), 2)
+		}
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	t.Run("固定间隔", func(t *testing.T) {
+		b := ConstantBackoff(time.Second)
+		if b.Next(1) != time.Second || b.Next(5) != time.Second {
+			t.Errorf("ConstantBackoff() 间隔不固定")
+		}
+	})
+
+	t.Run("指数退避", func(t *testing.T) {
+		b := ExponentialBackoff(100*time.Millisecond, time.Second)
+		expected := []time.Duration{100, 200, 400, 800, 1000, 1000}
+		for i, e := range expected {
+			if d := b.Next(i + 1); d != e*time.Millisecond {
+				t.Errorf("Next(%d) = %v, 期望 %v", i+1, d, e*time.Millisecond)
+			}
+		}
+		if d := b.Next(100); d != time.Second {
+			t.Errorf("Next(100) = %v, 期望 %v", d, time.Second)
+		}
+	})
+
+	t.Run("抖动", func(t *testing.T) {
+		b := Jitter(ConstantBackoff(time.Second), 0.5)
+		for i := 0; i < 100; i++ {
+			if d := b.Next(1); d < 500*time.Millisecond || d > 1500*time.Millisecond {
+				t.Fatalf("Jitter() = %v, 超出范围 [500ms, 1.5s]", d)
+			}
+		}
+	})
+}
+
+func TestRetry(t *testing.T) {
+	errTemp := errors.New("temporary")
+	errFatal := errors.New("fatal")
+
+	t.Run("重试后成功", func(t *testing.T) {
+		calls := 0
+		err := Retry(context.Background(), 5, ConstantBackoff(time.Millisecond), func(ctx context.Context) error {
+			calls++
+			if calls < 3 {
+				return errTemp
+			}
+			return nil
+		})
+		if err != nil || calls != 3 {
+			t.Errorf("Retry() = %v, 调用次数 %v, 期望 nil, 3", err, calls)
+		}
+	})
+
+	t.Run("使用可控时钟", func(t *testing.T) {
+		clock := timeutils.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		var calls atomic.Int32
+		done := make(chan error)
+		go func() {
+			done <- Retry(context.Background(), 3, ConstantBackoff(time.Hour), func(ctx context.Context) error {
+				calls.Add(1)
+				return errTemp
+			}, WithRetryClock(clock))
+		}()
+		for want := int32(1); want <= 2; want++ {
+			clock.BlockUntil(1)
+			if calls.Load() != want {
+				t.Fatalf("调用次数 = %v, 期望 %v", calls.Load(), want)
+			}
+			clock.Advance(time.Hour)
+		}
+		if err := <-done; !errors.Is(err, errTemp) || calls.Load() != 3 {
+			t.Errorf("Retry() = %v, 调用次数 %v, 期望 errTemp, 3", err, calls.Load())
+		}
+	})
+
+	t.Run("次数用尽返回最后的错误", func(t *testing.T) {
+		calls := 0
+		err := Retry(context.Background(), 3, nil, func(ctx context.Context) error {
+			calls++
+			return errTemp
+		})
+		if !errors.Is(err, errTemp) || calls != 3 {
+			t.Errorf("Retry() = %v, 调用次数 %v, 期望 errTemp, 3", err, calls)
+		}
+	})
+
+	t.Run("不可重试的错误", func(t *testing.T) {
+		calls := 0
+		err := Retry(context.Background(), 5, nil, func(ctx context.Context) error {
+			calls++
+			return errFatal
+		}, RetryIf(func(err error) bool { return !errors.Is(err, errFatal) }))
+		if !errors.Is(err, errFatal) || calls != 1 {
+			t.Errorf("Retry() = %v, 调用次数 %v, 期望 errFatal, 1", err, calls)
+		}
+	})
+
+	t.Run("退避策略要求停止", func(t *testing.T) {
+		calls := 0
+		stopAfterTwo := BackoffFunc(func(attempt int) time.Duration {
+			if attempt >= 2 {
+				return -1
+			}
+			return time.Millisecond
+		})
+		err := Retry(context.Background(), 10, stopAfterTwo, func(ctx context.Context) error {
+			calls++
+			return errTemp
+		})
+		if !errors.Is(err, errTemp) || calls != 2 {
+			t.Errorf("Retry() = %v, 调用次数 %v, 期望 errTemp, 2", err, calls)
+		}
+	})
+
+	t.Run("使用 backoff 包的策略", func(t *testing.T) {
+		calls := 0
+		err := Retry(context.Background(), 10, backoff.MaxAttempts(backoff.Exponential(time.Millisecond, 2), 3), func(ctx context.Context) error {
+			calls++
+			return errTemp
+		})
+		if !errors.Is(err, errTemp) || calls != 3 {
+			t.Errorf("Retry() = %v, 调用次数 %v, 期望 errTemp, 3", err, calls)
+		}
+	})
+
+	t.Run("等待期间取消", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		err := Retry(ctx, 5, ConstantBackoff(time.Hour), func(ctx context.Context) error {
+			return errTemp
+		})
+		if !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, errTemp) {
+			t.Errorf("Retry() = %v, 期望同时包含 DeadlineExceeded 和 errTemp", err)
+		}
+	})
+
+	t.Run("返回结果", func(t *testing.T) {
+		calls := 0
+		result, err := RetryValue(context.Background(), 3, nil, func(ctx context.Context) (string, error) {
+			calls++
+			if calls == 1 {
+				return "", errTemp
+			}
+			return "ok", nil
+		})
+		if err != nil || result != "ok" {
+			t.Errorf("RetryValue() = (%v, %v), 期望 (ok, nil)", result, err)
+		}
+	})
+}
+
+func TestIf(t *testing.T) {
+	if result := If(true, "yes", "no"); result != "yes" {
+		t.Errorf("If() = %v, 期望 %v", result, "yes")
+	}
+	if result := If(false, 1, 2); result != 2 {
+		t.Errorf("If() = %v, 期望 %v", result, 2)
+	}
+}
+
+func TestIfFunc(t *testing.T) {
+	elseCalled := false
+	result := IfFunc(true, func() int { return 1 }, func() int {
+		elseCalled = true
+		return 2
+	})
+	if result != 1 {
+		t.Errorf("IfFunc() = %v, 期望 %v", result, 1)
+	}
+	if elseCalled {
+		t.Errorf("IfFunc() 不应该调用未选中的函数")
+	}
+}
+
+func TestMust(t *testing.T) {
+	if result := Must(strconv.Atoi("42")); result != 42 {
+		t.Errorf("Must() = %v, 期望 %v", result, 42)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Must() 在出错时应该 panic")
+		}
+	}()
+	Must(strconv.Atoi("x"))
+}
+
+func TestTry(t *testing.T) {
+	errBoom := errors.New("boom")
+	tests := []struct {
+		name      string
+		fn        func() error
+		expectErr bool
+		is        error
+	}{
+		{name: "正常返回", fn: func() error { return nil }},
+		{name: "返回错误", fn: func() error { return errBoom }, expectErr: true, is: errBoom},
+		{name: "panic 字符串", fn: func() error { panic("oops") }, expectErr: true},
+		{name: "panic 错误", fn: func() error { panic(errBoom) }, expectErr: true, is: errBoom},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Try(tt.fn)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("Try() 错误 = %v, 期望出错 %v", err, tt.expectErr)
+			}
+			if tt.is != nil && !errors.Is(err, tt.is) {
+				t.Errorf("Try() 错误 = %v, 期望包含 %v", err, tt.is)
+			}
+		})
+	}
+
+	t.Run("Try1", func(t *testing.T) {
+		result, err := Try1(func() (int, error) { return 1, nil })
+		if err != nil || result != 1 {
+			t.Errorf("Try1() = (%v, %v), 期望 (1, nil)", result, err)
+		}
+		result, err = Try1(func() (int, error) {
+			var m map[string]int
+			m["x"] = 1
+			return 2, nil
+		})
+		if err == nil || result != 0 {
+			t.Errorf("Try1() = (%v, %v), 期望 (0, 错误)", result, err)
+		}
+	})
+}
+
+func TestTap(t *testing.T) {
+	var seen []int
+	result := Tap(5, func(v int) { seen = append(seen, v) })
+	if result != 5 || len(seen) != 1 || seen[0] != 5 {
+		t.Errorf("Tap() = %v, 记录 %v, 期望 5, [5]", result, seen)
+	}
+
+	logged := 0
+	fn := Pipe2(TapFunc(func(int) { logged++ }), strconv.Itoa)
+	if result := fn(7); result != "7" || logged != 1 {
+		t.Errorf("TapFunc() = %v, 调用次数 %v, 期望 7, 1", result, logged)
+	}
+}
+
+func TestIdentityAndConstant(t *testing.T) {
+	if result := Identity("x"); result != "x" {
+		t.Errorf("Identity() = %v, 期望 %v", result, "x")
+	}
+	fn := Constant(3)
+	if fn() != 3 || fn() != 3 {
+		t.Errorf("Constant() 应该总是返回 3")
+	}
+}
+
+func TestNoop(t *testing.T) {
+	// 只需保证可以作为对应类型的回调使用
+	var f func() = Noop
+	var g func(string) = Noop1[string]
+	var h func() error = NoopErr
+	f()
+	g("x")
+	if err := h(); err != nil {
+		t.Errorf("NoopErr() = %v, 期望 nil", err)
+	}
+}
+
+func TestPredicates(t *testing.T) {
+	isEven := func(i int) bool { return i%2 == 0 }
+	isPositive := func(i int) bool { return i > 0 }
+
+	tests := []struct {
+		name     string
+		pred     func(int) bool
+		input    int
+		expected bool
+	}{
+		{name: "Not", pred: Not(isEven), input: 3, expected: true},
+		{name: "And 全部满足", pred: And(isEven, isPositive), input: 4, expected: true},
+		{name: "And 部分满足", pred: And(isEven, isPositive), input: -4, expected: false},
+		{name: "And 为空", pred: And[int](), input: 1, expected: true},
+		{name: "Or 部分满足", pred: Or(isEven, isPositive), input: 3, expected: true},
+		{name: "Or 都不满足", pred: Or(isEven, isPositive), input: -3, expected: false},
+		{name: "Or 为空", pred: Or[int](), input: 1, expected: false},
+		{name: "组合", pred: And(isPositive, Not(isEven)), input: 5, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.pred(tt.input); result != tt.expected {
+				t.Errorf("结果 = %v, 期望 %v", result, tt.expected)
+			}
+		})
+	}
+
+	t.Run("短路求值", func(t *testing.T) {
+		called := false
+		pred := Or(isEven, func(int) bool {
+			called = true
+			return true
+		})
+		pred(2)
+		if called {
+			t.Errorf("Or() 应该在第一个谓词满足时短路")
+		}
+	})
+}
+
+func TestSafely(t *testing.T) {
+	t.Run("正常执行", func(t *testing.T) {
+		if err := Safely(func() {}); err != nil {
+			t.Errorf("Safely() = %v, 期望 nil", err)
+		}
+	})
+
+	t.Run("恢复 panic 并保留调用栈", func(t *testing.T) {
+		err := Safely(func() { panic("plugin crashed") })
+		var pe *PanicError
+		if !errors.As(err, &pe) {
+			t.Fatalf("Safely() = %v, 期望 *PanicError", err)
+		}
+		if pe.Value != "plugin crashed" {
+			t.Errorf("PanicError.Value = %v, 期望 %v", pe.Value, "plugin crashed")
+		}
+		if !strings.Contains(string(pe.Stack), "TestSafely") {
+			t.Errorf("PanicError.Stack 应该包含 panic 发生的位置")
+		}
+		if err.Error() != "panic: plugin crashed" {
+			t.Errorf("Error() = %q, 期望 %q", err.Error(), "panic: plugin crashed")
+		}
+	})
+
+	t.Run("SafelyValue", func(t *testing.T) {
+		result, err := SafelyValue(func() int { return 7 })
+		if err != nil || result != 7 {
+			t.Errorf("SafelyValue() = (%v, %v), 期望 (7, nil)", result, err)
+		}
+
+		errBoom := errors.New("boom")
+		result, err = SafelyValue(func() int { panic(errBoom) })
+		if !errors.Is(err, errBoom) || result != 0 {
+			t.Errorf("SafelyValue() = (%v, %v), 期望 (0, boom)", result, err)
+		}
+	})
+}
@@ -0,0 +1,197 @@
+package jsonutils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// MustMarshal 与 json.Marshal 相同，失败时 panic，用于测试和确定可以序列化的值
+func MustMarshal(v any) []byte {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("jsonutils: marshal %T: %v", v, err))
+	}
+	return data
+}
+
+// Pretty 返回 v 以两个空格缩进的 JSON 字符串，不转义 HTML 字符，便于日志和调试输出
+// v 为 []byte 或 json.RawMessage 且内容是合法 JSON 时对其重新缩进；序列化失败时返回 fmt 的 %+v 格式
+func Pretty(v any) string {
+	if raw, ok := rawJSON(v); ok {
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, raw, "", "  "); err == nil {
+			return buf.String()
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Sprintf("%+v", v)
+	}
+	// Encode 会在末尾添加换行
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// rawJSON 如果 v 是原始 JSON 字节则返回它
+func rawJSON(v any) ([]byte, bool) {
+	switch x := v.(type) {
+	case json.RawMessage:
+		return x, true
+	case []byte:
+		return x, json.Valid(x)
+	}
+	return nil, false
+}
+
+// Equal 判断 a 和 b 是否表示相同的 JSON 值，忽略对象键的顺序和空白
+// 数字按数值比较（1、1.0 和 1e0 相等）；任意一方不是合法 JSON 时返回 false
+func Equal(a, b []byte) bool {
+	va, err := decode(a)
+	if err != nil {
+		return false
+	}
+	vb, err := decode(b)
+	if err != nil {
+		return false
+	}
+	return equalValue(va, vb)
+}
+
+// decode 解码 JSON，数字保留为 json.Number
+func decode(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	// 不允许有多余的内容
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("jsonutils: unexpected data after top-level value")
+	}
+	return v, nil
+}
+
+// equalValue 递归比较两个解码后的 JSON 值
+func equalValue(a, b any) bool {
+	switch x := a.(type) {
+	case map[string]any:
+		y, ok := b.(map[string]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for k, v := range x {
+			w, ok := y[k]
+			if !ok || !equalValue(v, w) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		y, ok := b.([]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !equalValue(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	case json.Number:
+		y, ok := b.(json.Number)
+		return ok && equalNumber(x, y)
+	default:
+		// string、bool 和 nil
+		return a == b
+	}
+}
+
+// equalNumber 按数值比较两个 JSON 数字，使用任意精度避免大整数的精度损失
+func equalNumber(a, b json.Number) bool {
+	if a == b {
+		return true
+	}
+	x, _, errA := big.ParseFloat(string(a), 10, 256, big.ToNearestEven)
+	y, _, errB := big.ParseFloat(string(b), 10, 256, big.ToNearestEven)
+	return errA == nil && errB == nil && x.Cmp(y) == 0
+}
+
+// Get 按路径从 JSON 中取值，路径由 "." 分隔，数组下标可以写作 ".0" 或 "[0]"，例如 "items.0.name"、"items[0].name"
+// 取到的值为 encoding/json 解码到 any 的结果（map[string]any、[]any、float64、string、bool 或 nil）
+// 路径为空时返回整个文档；data 不是合法 JSON 或路径不存在时返回 nil 和 false；无法表示包含 "." 的键
+func Get(data []byte, path string) (any, bool) {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, false
+	}
+	return lookup(v, path)
+}
+
+// GetAs 与 Get 相同，但将取到的值转换为 T，类型不匹配时返回零值和 false
+// 数字按原文转换，不经过 float64，因此大整数（如 int64、uint64）不会丢失精度
+func GetAs[T any](data []byte, path string) (T, bool) {
+	var zero T
+	root, err := decode(data)
+	if err != nil {
+		return zero, false
+	}
+	v, ok := lookup(root, path)
+	if !ok {
+		return zero, false
+	}
+	// 通过重新编解码支持结构体、具体的数字类型等，json.Number 会按原文编码
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return zero, false
+	}
+	var t T
+	if err := json.Unmarshal(raw, &t); err != nil {
+		return zero, false
+	}
+	return t, true
+}
+
+// lookup 在解码后的值中按路径查找
+func lookup(v any, path string) (any, bool) {
+	for _, key := range splitPath(path) {
+		switch node := v.(type) {
+		case map[string]any:
+			next, ok := node[key]
+			if !ok {
+				return nil, false
+			}
+			v = next
+		case []any:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, false
+			}
+			v = node[i]
+		default:
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// splitPath 将 "a.b[0].c" 拆分为 ["a", "b", "0", "c"]
+func splitPath(path string) []string {
+	path = strings.NewReplacer("[", ".", "]", "").Replace(path)
+	var keys []string
+	for _, k := range strings.Split(path, ".") {
+		if k != "" {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
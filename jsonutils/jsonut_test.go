@@ -0,0 +1,155 @@
+package jsonutils
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestMustMarshal(t *testing.T) {
+	if result := string(MustMarshal(map[string]int{"a": 1})); result != `{"a":1}` {
+		t.Errorf("MustMarshal() = %v, 期望 %v", result, `{"a":1}`)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MustMarshal() 无法序列化的值期望 panic")
+		}
+	}()
+	MustMarshal(make(chan int))
+}
+
+func TestPretty(t *testing.T) {
+	tests := []struct {
+		name     string
+		v        any
+		expected string
+	}{
+		{"结构体", struct {
+			Name string   `json:"name"`
+			Tags []string `json:"tags"`
+		}{"a<b>", []string{"x"}}, "{\n  \"name\": \"a<b>\",\n  \"tags\": [\n    \"x\"\n  ]\n}"},
+		{"原始 JSON", []byte(`{"a":[1,2]}`), "{\n  \"a\": [\n    1,\n    2\n  ]\n}"},
+		{"RawMessage", json.RawMessage(`[true]`), "[\n  true\n]"},
+		{"非 JSON 字节按 base64 编码", []byte("hi"), `"aGk="`},
+		{"标量", 42, "42"},
+		{"无法序列化", make(chan int), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Pretty(tt.v)
+			if tt.expected == "" {
+				if result == "" {
+					t.Errorf("Pretty() 序列化失败时应返回 fmt 格式")
+				}
+				return
+			}
+			if result != tt.expected {
+				t.Errorf("Pretty() = %q, 期望 %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		expected bool
+	}{
+		{"键顺序不同", `{"a":1,"b":2}`, `{"b":2,"a":1}`, true},
+		{"空白不同", "{ \"a\" : [1, 2] }", `{"a":[1,2]}`, true},
+		{"数字格式不同", `[1, 1.0, 1e0]`, `[1.00, 1, 10e-1]`, true},
+		{"大整数不丢失精度", `9007199254740993`, `9007199254740992`, false},
+		{"嵌套对象", `{"a":{"b":[{"c":null}]}}`, `{"a":{"b":[{"c":null}]}}`, true},
+		{"数组顺序不同", `[1,2]`, `[2,1]`, false},
+		{"缺少键", `{"a":1}`, `{"a":1,"b":2}`, false},
+		{"类型不同", `{"a":"1"}`, `{"a":1}`, false},
+		{"null 与缺少", `{"a":null}`, `{}`, false},
+		{"非法 JSON", `{"a":`, `{"a":1}`, false},
+		{"多余内容", `{} {}`, `{}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := Equal([]byte(tt.a), []byte(tt.b)); result != tt.expected {
+				t.Errorf("Equal(%s, %s) = %v, 期望 %v", tt.a, tt.b, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	data := []byte(`{
+		"user": {"name": "alice", "age": 30, "tags": ["admin", "dev"]},
+		"items": [{"id": 1}, {"id": 2, "meta": null}]
+	}`)
+	tests := []struct {
+		name     string
+		path     string
+		expected any
+		ok       bool
+	}{
+		{"嵌套字段", "user.name", "alice", true},
+		{"数字", "user.age", float64(30), true},
+		{"数组下标", "user.tags.1", "dev", true},
+		{"方括号下标", "items[1].id", float64(2), true},
+		{"null 值", "items.1.meta", nil, true},
+		{"对象", "items.0", map[string]any{"id": float64(1)}, true},
+		{"不存在的键", "user.email", nil, false},
+		{"下标越界", "items.5", nil, false},
+		{"非数字下标", "items.x", nil, false},
+		{"穿过标量", "user.name.first", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, ok := Get(data, tt.path)
+			if ok != tt.ok || !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Get(%q) = %v, %v, 期望 %v, %v", tt.path, result, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+
+	if result, ok := Get(data, ""); !ok || result.(map[string]any)["user"] == nil {
+		t.Errorf("Get() 空路径应返回整个文档")
+	}
+	if _, ok := Get([]byte(`{`), "a"); ok {
+		t.Errorf("Get() 非法 JSON ok = true, 期望 false")
+	}
+}
+
+func TestGetAs(t *testing.T) {
+	data := []byte(`{"user": {"name": "alice", "age": 30, "tags": ["admin"]}}`)
+
+	if name, ok := GetAs[string](data, "user.name"); !ok || name != "alice" {
+		t.Errorf("GetAs[string]() = %v, %v, 期望 alice, true", name, ok)
+	}
+	if age, ok := GetAs[int](data, "user.age"); !ok || age != 30 {
+		t.Errorf("GetAs[int]() = %v, %v, 期望 30, true", age, ok)
+	}
+	type user struct {
+		Name string   `json:"name"`
+		Tags []string `json:"tags"`
+	}
+	expected := user{Name: "alice", Tags: []string{"admin"}}
+	if u, ok := GetAs[user](data, "user"); !ok || !reflect.DeepEqual(u, expected) {
+		t.Errorf("GetAs[user]() = %+v, %v, 期望 %+v, true", u, ok, expected)
+	}
+	if _, ok := GetAs[int](data, "user.name"); ok {
+		t.Errorf("GetAs[int]() 类型不匹配 ok = true, 期望 false")
+	}
+	if v, ok := GetAs[any](data, "user.age"); !ok || v != float64(30) {
+		t.Errorf("GetAs[any]() = %#v, %v, 期望 float64(30), true", v, ok)
+	}
+
+	big := []byte(`{"id": 9007199254740993, "max": 18446744073709551615}`)
+	if id, ok := GetAs[int64](big, "id"); !ok || id != 9007199254740993 {
+		t.Errorf("GetAs[int64]() = %v, %v, 期望 9007199254740993, true", id, ok)
+	}
+	if m, ok := GetAs[uint64](big, "max"); !ok || m != math.MaxUint64 {
+		t.Errorf("GetAs[uint64]() = %v, %v, 期望 %v, true", m, ok, uint64(math.MaxUint64))
+	}
+	if _, ok := GetAs[int]([]byte(`{"a": 1} x`), "a"); ok {
+		t.Errorf("GetAs[int]() 非法 JSON ok = true, 期望 false")
+	}
+}
@@ -0,0 +1,520 @@
+package mathutils
+
+import (
+	"cmp"
+	"errors"
+	"math"
+	"math/big"
+	"slices"
+	"strconv"
+	"sync"
+)
+
+// ErrEmptyInput 输入数据为空，无法计算统计量
+var ErrEmptyInput = errors.New("mathutils: empty input")
+
+// ErrOutOfRange 分位数参数超出有效范围
+var ErrOutOfRange = errors.New("mathutils: quantile out of range")
+
+// Signed 有符号整数类型
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// Unsigned 无符号整数类型
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Integer 整数类型
+type Integer interface {
+	Signed | Unsigned
+}
+
+// Float 浮点数类型
+type Float interface {
+	~float32 | ~float64
+}
+
+// Number 整数和浮点数类型
+type Number interface {
+	Integer | Float
+}
+
+// Clamp 将 v 限制在闭区间 [lo, hi] 内
+// 小于 lo 时返回 lo，大于 hi 时返回 hi；如果 lo > hi，两者会被交换
+func Clamp[T cmp.Ordered](v, lo, hi T) T {
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+
+// InRange 判断 v 是否位于闭区间 [lo, hi] 内
+// 如果 lo > hi，两者会被交换
+func InRange[T cmp.Ordered](v, lo, hi T) bool {
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	return v >= lo && v <= hi
+}
+
+// Min 返回参数中的最小值，没有参数时返回零值
+// 浮点数中包含 NaN 时返回 NaN
+func Min[T cmp.Ordered](values ...T) T {
+	var result T
+	if len(values) == 0 {
+		return result
+	}
+	result = values[0]
+	for _, v := range values[1:] {
+		result = min(result, v)
+	}
+	return result
+}
+
+// Max 返回参数中的最大值，没有参数时返回零值
+// 浮点数中包含 NaN 时返回 NaN
+func Max[T cmp.Ordered](values ...T) T {
+	var result T
+	if len(values) == 0 {
+		return result
+	}
+	result = values[0]
+	for _, v := range values[1:] {
+		result = max(result, v)
+	}
+	return result
+}
+
+// Sum 返回参数之和，没有参数时返回 0
+// 注意：整数求和可能溢出，需要检查时请使用 AddChecked
+func Sum[T Number](values ...T) T {
+	var sum T
+	for _, v := range values {
+		sum += v
+	}
+	return sum
+}
+
+// Mean 返回算术平均值，输入为空时返回 ErrEmptyInput
+func Mean[T Number](data []T) (float64, error) {
+	if len(data) == 0 {
+		return 0, ErrEmptyInput
+	}
+	sum := 0.0
+	for _, v := range data {
+		sum += float64(v)
+	}
+	return sum / float64(len(data)), nil
+}
+
+// Median 返回中位数，元素个数为偶数时取中间两个数的平均值
+// 不修改原始切片；输入为空时返回 ErrEmptyInput
+func Median[T Number](data []T) (float64, error) {
+	if len(data) == 0 {
+		return 0, ErrEmptyInput
+	}
+	sorted := slices.Clone(data)
+	slices.Sort(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return float64(sorted[mid]), nil
+	}
+	return (float64(sorted[mid-1]) + float64(sorted[mid])) / 2, nil
+}
+
+// Mode 返回出现次数最多的值（众数）
+// 有多个众数时返回其中最小的一个，保证结果确定；输入为空时返回 ErrEmptyInput
+func Mode[T Number](data []T) (float64, error) {
+	if len(data) == 0 {
+		return 0, ErrEmptyInput
+	}
+	counts := make(map[T]int, len(data))
+	var mode T
+	best := 0
+	for _, v := range data {
+		counts[v]++
+		c := counts[v]
+		if c > best || (c == best && v < mode) {
+			mode, best = v, c
+		}
+	}
+	return float64(mode), nil
+}
+
+// Variance 返回总体方差（除以 n），输入为空时返回 ErrEmptyInput
+func Variance[T Number](data []T) (float64, error) {
+	mean, err := Mean(data)
+	if err != nil {
+		return 0, err
+	}
+	sum := 0.0
+	for _, v := range data {
+		d := float64(v) - mean
+		sum += d * d
+	}
+	return sum / float64(len(data)), nil
+}
+
+// StdDev 返回总体标准差，即 Variance 的平方根；输入为空时返回 ErrEmptyInput
+func StdDev[T Number](data []T) (float64, error) {
+	variance, err := Variance(data)
+	if err != nil {
+		return 0, err
+	}
+	return math.Sqrt(variance), nil
+}
+
+// Quantile 返回 q 分位数（0 <= q <= 1），不修改原始切片
+// 使用线性插值（与 NumPy 默认方法及 Excel PERCENTILE.INC 相同）：
+// 将数据升序排列后，位置 h = q * (n - 1)，结果为 x[floor(h)] + (h - floor(h)) * (x[floor(h)+1] - x[floor(h)])
+// 输入为空时返回 ErrEmptyInput，q 超出 [0, 1] 或为 NaN 时返回 ErrOutOfRange
+func Quantile[T Number](data []T, q float64) (float64, error) {
+	result, err := Quantiles(data, q)
+	if err != nil {
+		return 0, err
+	}
+	return result[0], nil
+}
+
+// Percentile 返回 p 百分位数（0 <= p <= 100），例如 p = 95 即 p95 延迟
+// 插值方法与 Quantile 相同；p 超出 [0, 100] 时返回 ErrOutOfRange
+func Percentile[T Number](data []T, p float64) (float64, error) {
+	return Quantile(data, p/100)
+}
+
+// Quantiles 一次计算多个分位数，只排序一次，结果与 qs 的顺序对应
+// 例如 Quantiles(latencies, 0.5, 0.95, 0.99) 返回 p50、p95、p99
+func Quantiles[T Number](data []T, qs ...float64) ([]float64, error) {
+	if len(data) == 0 {
+		return nil, ErrEmptyInput
+	}
+	for _, q := range qs {
+		if !(q >= 0 && q <= 1) {
+			return nil, ErrOutOfRange
+		}
+	}
+
+	sorted := slices.Clone(data)
+	slices.Sort(sorted)
+
+	result := make([]float64, len(qs))
+	for i, q := range qs {
+		h := q * float64(len(sorted)-1)
+		lo := int(math.Floor(h))
+		if lo >= len(sorted)-1 {
+			result[i] = float64(sorted[len(sorted)-1])
+			continue
+		}
+		lower, upper := float64(sorted[lo]), float64(sorted[lo+1])
+		result[i] = lower + (h-float64(lo))*(upper-lower)
+	}
+	return result, nil
+}
+
+// roundMode 十进制舍入方式
+type roundMode int
+
+const (
+	roundHalfAwayFromZero roundMode = iota
+	roundHalfEven
+	roundFloor
+	roundCeil
+)
+
+// RoundTo 将 v 四舍五入到 decimals 位小数（0.5 远离零舍入），decimals 为负数时舍入到十位、百位等
+// 计算基于 v 的最短十进制表示进行，避免乘除法带来的误差，例如 RoundTo(1.005, 2) == 1.01
+func RoundTo(v float64, decimals int) float64 {
+	return roundDecimal(v, decimals, roundHalfAwayFromZero)
+}
+
+// RoundHalfEvenTo 使用银行家舍入（四舍六入五成双）将 v 舍入到 decimals 位小数
+// 例如 RoundHalfEvenTo(2.345, 2) == 2.34，RoundHalfEvenTo(2.355, 2) == 2.36
+func RoundHalfEvenTo(v float64, decimals int) float64 {
+	return roundDecimal(v, decimals, roundHalfEven)
+}
+
+// FloorTo 将 v 向下（负无穷方向）舍入到 decimals 位小数
+func FloorTo(v float64, decimals int) float64 {
+	return roundDecimal(v, decimals, roundFloor)
+}
+
+// CeilTo 将 v 向上（正无穷方向）舍入到 decimals 位小数
+func CeilTo(v float64, decimals int) float64 {
+	return roundDecimal(v, decimals, roundCeil)
+}
+
+// roundDecimal 按十进制精确舍入，NaN 和 ±Inf 原样返回
+func roundDecimal(v float64, decimals int, mode roundMode) float64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return v
+	}
+
+	// 使用能够精确还原 v 的最短十进制表示，例如 1.005 而不是 1.00499999999999989...
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(v, 'g', -1, 64))
+	if !ok {
+		return v
+	}
+	scale := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(Abs(decimals))), nil))
+	if decimals >= 0 {
+		r.Mul(r, scale)
+	} else {
+		r.Quo(r, scale)
+	}
+
+	// q 为向零截断的整数部分，rem 为余数（与被除数同号）
+	q, rem := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if rem.Sign() != 0 {
+		// cmpHalf 比较 |rem| 与 denom/2 的大小
+		cmpHalf := new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2)).Cmp(r.Denom())
+		awayFromZero := false
+		switch mode {
+		case roundHalfAwayFromZero:
+			awayFromZero = cmpHalf >= 0
+		case roundHalfEven:
+			awayFromZero = cmpHalf > 0 || (cmpHalf == 0 && q.Bit(0) == 1)
+		case roundFloor:
+			awayFromZero = rem.Sign() < 0
+		case roundCeil:
+			awayFromZero = rem.Sign() > 0
+		}
+		if awayFromZero {
+			q.Add(q, big.NewInt(int64(rem.Sign())))
+		}
+	}
+
+	result := new(big.Rat).SetInt(q)
+	if decimals >= 0 {
+		result.Quo(result, scale)
+	} else {
+		result.Mul(result, scale)
+	}
+	f, _ := result.Float64()
+	if f == 0 && math.Signbit(v) {
+		return math.Copysign(0, -1)
+	}
+	return f
+}
+
+// GCD 返回 a 和 b 的最大公约数（非负数），GCD(0, 0) == 0
+func GCD[T Integer](a, b T) T {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// LCM 返回 a 和 b 的最小公倍数（非负数），任一参数为 0 时返回 0
+// 注意：结果超出 T 的表示范围时会溢出
+func LCM[T Integer](a, b T) T {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	l := a / GCD(a, b) * b
+	if l < 0 {
+		return -l
+	}
+	return l
+}
+
+// DivMod 返回向下取整的商和对应的余数，满足 a == q*b + r，余数与 b 同号
+// 与 Python 的 divmod 一致，例如 DivMod(-7, 2) == (-4, 1)；b 为 0 时 panic
+func DivMod[T Integer](a, b T) (q, r T) {
+	q, r = a/b, a%b
+	if r != 0 && (r < 0) != (b < 0) {
+		q--
+		r += b
+	}
+	return q, r
+}
+
+// CeilDiv 返回 a / b 向上取整的结果，用于计算分页数、分块数等
+// 替代 (a + b - 1) / b 的写法，且对负数和接近上限的值同样正确；b 为 0 时 panic
+func CeilDiv[T Integer](a, b T) T {
+	q := a / b
+	if a%b != 0 && (a < 0) == (b < 0) {
+		q++
+	}
+	return q
+}
+
+// Abs 返回 v 的绝对值，整数无需转换为 float64 再调用 math.Abs
+// 注意：有符号整数的最小值（如 math.MinInt64）没有对应的正数，结果仍为其本身
+func Abs[T Signed | Float](v T) T {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// Sign 返回 v 的符号：负数返回 -1，正数返回 1，0 和 NaN 返回 0
+func Sign[T Signed | Float](v T) int {
+	switch {
+	case v < 0:
+		return -1
+	case v > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// SafeDiv 返回 a / b，b 为 0 时返回 fallback 而不是 panic（整数）或得到 ±Inf/NaN（浮点数）
+// 适用于根据外部计数器计算比例、百分比等场景
+func SafeDiv[T Number](a, b, fallback T) T {
+	if b == 0 {
+		return fallback
+	}
+	return a / b
+}
+
+// SafeMod 返回 a % b，b 为 0 时返回 fallback 而不是 panic
+func SafeMod[T Integer](a, b, fallback T) T {
+	if b == 0 {
+		return fallback
+	}
+	return a % b
+}
+
+// Lerp 在 a 和 b 之间线性插值：t = 0 时返回 a，t = 1 时返回 b
+// t 超出 [0, 1] 时按同样的比例外推
+func Lerp(a, b, t float64) float64 {
+	return a + (b-a)*t
+}
+
+// MapRange 将 v 从区间 [inLo, inHi] 线性映射到区间 [outLo, outHi]
+// 例如 MapRange(75, 0, 100, 0, 1) == 0.75；v 超出输入区间时按同样的比例外推
+// inLo == inHi 时输入区间没有宽度，返回 outLo
+func MapRange(v, inLo, inHi, outLo, outHi float64) float64 {
+	if inLo == inHi {
+		return outLo
+	}
+	return Lerp(outLo, outHi, (v-inLo)/(inHi-inLo))
+}
+
+// MapRangeClamped 与 MapRange 相同，但结果会被限制在输出区间内，适用于进度条等场景
+func MapRangeClamped(v, inLo, inHi, outLo, outHi float64) float64 {
+	return Clamp(MapRange(v, inLo, inHi, outLo, outHi), outLo, outHi)
+}
+
+// AddChecked 返回 a + b，第二个返回值表示结果是否有效
+// 发生溢出（回绕）时返回 false，适用于计数器、金额等不允许回绕的场景
+func AddChecked[T Integer](a, b T) (T, bool) {
+	c := a + b
+	if (b > 0 && c < a) || (b < 0 && c > a) {
+		return c, false
+	}
+	return c, true
+}
+
+// SubChecked 返回 a - b，发生溢出（包括无符号整数结果小于 0）时第二个返回值为 false
+func SubChecked[T Integer](a, b T) (T, bool) {
+	c := a - b
+	if (b > 0 && c > a) || (b < 0 && c < a) {
+		return c, false
+	}
+	return c, true
+}
+
+// MulChecked 返回 a * b，发生溢出时第二个返回值为 false
+func MulChecked[T Integer](a, b T) (T, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	c := a * b
+	// 符号检查用于发现 MinInt * -1 这类除法校验无法识别的溢出
+	if c/b != a || (c < 0) != ((a < 0) != (b < 0)) {
+		return c, false
+	}
+	return c, true
+}
+
+// RunningStats 流式统计累加器，使用 Welford 算法在线计算均值和方差，
+// 无需保存所有样本，适用于长时间运行的进程统计指标
+// 零值可直接使用，并发安全
+type RunningStats struct {
+	mu    sync.Mutex
+	count int
+	mean  float64
+	m2    float64 // 与均值之差的平方和
+	min   float64
+	max   float64
+}
+
+// Add 添加一个样本
+func (s *RunningStats) Add(value float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.count++
+	if s.count == 1 {
+		s.min, s.max = value, value
+	} else {
+		s.min = min(s.min, value)
+		s.max = max(s.max, value)
+	}
+	delta := value - s.mean
+	s.mean += delta / float64(s.count)
+	s.m2 += delta * (value - s.mean)
+}
+
+// Count 返回样本数量
+func (s *RunningStats) Count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.count
+}
+
+// Mean 返回均值，没有样本时返回 0
+func (s *RunningStats) Mean() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mean
+}
+
+// Min 返回最小值，没有样本时返回 0
+func (s *RunningStats) Min() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.min
+}
+
+// Max 返回最大值，没有样本时返回 0
+func (s *RunningStats) Max() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.max
+}
+
+// Variance 返回总体方差，没有样本时返回 0，与 Variance 函数的结果一致
+func (s *RunningStats) Variance() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.count == 0 {
+		return 0
+	}
+	return s.m2 / float64(s.count)
+}
+
+// StdDev 返回总体标准差，没有样本时返回 0
+func (s *RunningStats) StdDev() float64 {
+	return math.Sqrt(s.Variance())
+}
+
+// Reset 清空所有已添加的样本
+func (s *RunningStats) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.count, s.mean, s.m2, s.min, s.max = 0, 0, 0, 0, 0
+}
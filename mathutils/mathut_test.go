@@ -0,0 +1,593 @@
+package mathutils
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestClamp(t *testing.T) {
+	tests := []struct {
+		name     string
+		v        int
+		lo       int
+		hi       int
+		expected int
+	}{
+		{name: "区间内", v: 5, lo: 1, hi: 10, expected: 5},
+		{name: "小于下限", v: -3, lo: 1, hi: 10, expected: 1},
+		{name: "大于上限", v: 100, lo: 1, hi: 10, expected: 10},
+		{name: "等于边界", v: 10, lo: 1, hi: 10, expected: 10},
+		{name: "上下限颠倒", v: 100, lo: 10, hi: 1, expected: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := Clamp(tt.v, tt.lo, tt.hi); result != tt.expected {
+				t.Errorf("Clamp() = %v, 期望 %v", result, tt.expected)
+			}
+		})
+	}
+
+	t.Run("浮点数", func(t *testing.T) {
+		if result := Clamp(1.5, 0.0, 1.0); result != 1.0 {
+			t.Errorf("Clamp() = %v, 期望 %v", result, 1.0)
+		}
+	})
+
+	t.Run("字符串", func(t *testing.T) {
+		if result := Clamp("m", "a", "f"); result != "f" {
+			t.Errorf("Clamp() = %v, 期望 %v", result, "f")
+		}
+	})
+}
+
+func TestInRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		v        float64
+		lo       float64
+		hi       float64
+		expected bool
+	}{
+		{name: "区间内", v: 0.5, lo: 0, hi: 1, expected: true},
+		{name: "下边界", v: 0, lo: 0, hi: 1, expected: true},
+		{name: "上边界", v: 1, lo: 0, hi: 1, expected: true},
+		{name: "区间外", v: 1.1, lo: 0, hi: 1, expected: false},
+		{name: "上下限颠倒", v: 0.5, lo: 1, hi: 0, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := InRange(tt.v, tt.lo, tt.hi); result != tt.expected {
+				t.Errorf("InRange() = %v, 期望 %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	tests := []struct {
+		name        string
+		values      []int
+		expectedMin int
+		expectedMax int
+	}{
+		{name: "多个值", values: []int{3, 1, 4, 1, 5}, expectedMin: 1, expectedMax: 5},
+		{name: "负数", values: []int{-2, -8, -1}, expectedMin: -8, expectedMax: -1},
+		{name: "单个值", values: []int{7}, expectedMin: 7, expectedMax: 7},
+		{name: "没有参数", values: nil, expectedMin: 0, expectedMax: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := Min(tt.values...); result != tt.expectedMin {
+				t.Errorf("Min() = %v, 期望 %v", result, tt.expectedMin)
+			}
+			if result := Max(tt.values...); result != tt.expectedMax {
+				t.Errorf("Max() = %v, 期望 %v", result, tt.expectedMax)
+			}
+		})
+	}
+
+	t.Run("NaN", func(t *testing.T) {
+		if result := Min(1.0, math.NaN(), 0.5); !math.IsNaN(result) {
+			t.Errorf("Min() = %v, 期望 NaN", result)
+		}
+	})
+
+	t.Run("字符串", func(t *testing.T) {
+		if result := Max("apple", "cherry", "banana"); result != "cherry" {
+			t.Errorf("Max() = %v, 期望 %v", result, "cherry")
+		}
+	})
+}
+
+func TestSum(t *testing.T) {
+	if result := Sum(1, 2, 3, 4); result != 10 {
+		t.Errorf("Sum() = %v, 期望 %v", result, 10)
+	}
+	if result := Sum(0.5, 0.25); result != 0.75 {
+		t.Errorf("Sum() = %v, 期望 %v", result, 0.75)
+	}
+	if result := Sum[uint8](); result != 0 {
+		t.Errorf("Sum() = %v, 期望 %v", result, 0)
+	}
+}
+
+func TestStatistics(t *testing.T) {
+	tests := []struct {
+		name     string
+		fn       func([]float64) (float64, error)
+		data     []float64
+		expected float64
+	}{
+		{name: "Mean", fn: Mean[float64], data: []float64{1, 2, 3, 4}, expected: 2.5},
+		{name: "Median 奇数个", fn: Median[float64], data: []float64{5, 1, 3}, expected: 3},
+		{name: "Median 偶数个", fn: Median[float64], data: []float64{4, 1, 3, 2}, expected: 2.5},
+		{name: "Mode", fn: Mode[float64], data: []float64{1, 2, 2, 3, 3, 3}, expected: 3},
+		{name: "Mode 多个众数取最小", fn: Mode[float64], data: []float64{5, 5, 2, 2, 9}, expected: 2},
+		{name: "Variance", fn: Variance[float64], data: []float64{2, 4, 4, 4, 5, 5, 7, 9}, expected: 4},
+		{name: "StdDev", fn: StdDev[float64], data: []float64{2, 4, 4, 4, 5, 5, 7, 9}, expected: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.fn(tt.data)
+			if err != nil {
+				t.Fatalf("%s() 返回错误: %v", tt.name, err)
+			}
+			if math.Abs(result-tt.expected) > 1e-9 {
+				t.Errorf("%s() = %v, 期望 %v", tt.name, result, tt.expected)
+			}
+		})
+	}
+
+	t.Run("整数输入", func(t *testing.T) {
+		result, err := Mean([]int{1, 2})
+		if err != nil || result != 1.5 {
+			t.Errorf("Mean() = (%v, %v), 期望 (1.5, nil)", result, err)
+		}
+	})
+
+	t.Run("不修改原切片", func(t *testing.T) {
+		data := []int{3, 1, 2}
+		if _, err := Median(data); err != nil {
+			t.Fatalf("Median() 返回错误: %v", err)
+		}
+		if data[0] != 3 || data[1] != 1 || data[2] != 2 {
+			t.Errorf("原切片被修改: %v", data)
+		}
+	})
+
+	t.Run("空输入", func(t *testing.T) {
+		fns := map[string]func([]int) (float64, error){
+			"Mean": Mean[int], "Median": Median[int], "Mode": Mode[int],
+			"Variance": Variance[int], "StdDev": StdDev[int],
+		}
+		for name, fn := range fns {
+			if _, err := fn(nil); !errors.Is(err, ErrEmptyInput) {
+				t.Errorf("%s() 错误 = %v, 期望 ErrEmptyInput", name, err)
+			}
+		}
+	})
+}
+
+func TestPercentile(t *testing.T) {
+	data := []int{15, 20, 35, 40, 50}
+	tests := []struct {
+		name     string
+		p        float64
+		expected float64
+	}{
+		{name: "最小值", p: 0, expected: 15},
+		{name: "中位数", p: 50, expected: 35},
+		{name: "插值", p: 40, expected: 29},
+		{name: "p95", p: 95, expected: 48},
+		{name: "最大值", p: 100, expected: 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Percentile(data, tt.p)
+			if err != nil {
+				t.Fatalf("Percentile() 返回错误: %v", err)
+			}
+			if math.Abs(result-tt.expected) > 1e-9 {
+				t.Errorf("Percentile() = %v, 期望 %v", result, tt.expected)
+			}
+		})
+	}
+
+	t.Run("单个元素", func(t *testing.T) {
+		if result, err := Quantile([]float64{7}, 0.99); err != nil || result != 7 {
+			t.Errorf("Quantile() = (%v, %v), 期望 (7, nil)", result, err)
+		}
+	})
+
+	t.Run("多个分位数", func(t *testing.T) {
+		result, err := Quantiles([]float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, 0.5, 0.9, 0.99)
+		if err != nil {
+			t.Fatalf("Quantiles() 返回错误: %v", err)
+		}
+		expected := []float64{5.5, 9.1, 9.91}
+		for i := range expected {
+			if math.Abs(result[i]-expected[i]) > 1e-9 {
+				t.Errorf("Quantiles()[%d] = %v, 期望 %v", i, result[i], expected[i])
+			}
+		}
+	})
+
+	t.Run("非法参数", func(t *testing.T) {
+		if _, err := Percentile(data, 101); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("Percentile() 错误 = %v, 期望 ErrOutOfRange", err)
+		}
+		if _, err := Quantile(data, math.NaN()); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("Quantile() 错误 = %v, 期望 ErrOutOfRange", err)
+		}
+		if _, err := Percentile([]int{}, 50); !errors.Is(err, ErrEmptyInput) {
+			t.Errorf("Percentile() 错误 = %v, 期望 ErrEmptyInput", err)
+		}
+	})
+}
+
+func TestRoundTo(t *testing.T) {
+	tests := []struct {
+		name     string
+		fn       func(float64, int) float64
+		v        float64
+		decimals int
+		expected float64
+	}{
+		{name: "RoundTo 基本", fn: RoundTo, v: 3.14159, decimals: 2, expected: 3.14},
+		{name: "RoundTo 二进制误差", fn: RoundTo, v: 1.005, decimals: 2, expected: 1.01},
+		{name: "RoundTo 负数远离零", fn: RoundTo, v: -2.5, decimals: 0, expected: -3},
+		{name: "RoundTo 负精度", fn: RoundTo, v: 1234.5, decimals: -2, expected: 1200},
+		{name: "RoundHalfEvenTo 舍", fn: RoundHalfEvenTo, v: 2.345, decimals: 2, expected: 2.34},
+		{name: "RoundHalfEvenTo 入", fn: RoundHalfEvenTo, v: 2.355, decimals: 2, expected: 2.36},
+		{name: "RoundHalfEvenTo 整数", fn: RoundHalfEvenTo, v: 2.5, decimals: 0, expected: 2},
+		{name: "RoundHalfEvenTo 非中点", fn: RoundHalfEvenTo, v: 2.51, decimals: 0, expected: 3},
+		{name: "FloorTo", fn: FloorTo, v: 2.789, decimals: 1, expected: 2.7},
+		{name: "FloorTo 负数", fn: FloorTo, v: -2.781, decimals: 2, expected: -2.79},
+		{name: "CeilTo", fn: CeilTo, v: 2.701, decimals: 1, expected: 2.8},
+		{name: "CeilTo 精确值不变", fn: CeilTo, v: 0.3, decimals: 1, expected: 0.3},
+		{name: "CeilTo 负数", fn: CeilTo, v: -2.789, decimals: 1, expected: -2.7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.fn(tt.v, tt.decimals); result != tt.expected {
+				t.Errorf("结果 = %v, 期望 %v", result, tt.expected)
+			}
+		})
+	}
+
+	t.Run("特殊值", func(t *testing.T) {
+		if result := RoundTo(math.NaN(), 2); !math.IsNaN(result) {
+			t.Errorf("RoundTo(NaN) = %v, 期望 NaN", result)
+		}
+		if result := RoundTo(math.Inf(1), 2); !math.IsInf(result, 1) {
+			t.Errorf("RoundTo(+Inf) = %v, 期望 +Inf", result)
+		}
+	})
+}
+
+func TestGCDAndLCM(t *testing.T) {
+	tests := []struct {
+		name        string
+		a, b        int
+		expectedGCD int
+		expectedLCM int
+	}{
+		{name: "基本", a: 12, b: 18, expectedGCD: 6, expectedLCM: 36},
+		{name: "互质", a: 7, b: 9, expectedGCD: 1, expectedLCM: 63},
+		{name: "负数", a: -4, b: 6, expectedGCD: 2, expectedLCM: 12},
+		{name: "包含0", a: 0, b: 5, expectedGCD: 5, expectedLCM: 0},
+		{name: "都为0", a: 0, b: 0, expectedGCD: 0, expectedLCM: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := GCD(tt.a, tt.b); result != tt.expectedGCD {
+				t.Errorf("GCD() = %v, 期望 %v", result, tt.expectedGCD)
+			}
+			if result := LCM(tt.a, tt.b); result != tt.expectedLCM {
+				t.Errorf("LCM() = %v, 期望 %v", result, tt.expectedLCM)
+			}
+		})
+	}
+
+	t.Run("无符号整数", func(t *testing.T) {
+		if result := GCD[uint](48, 180); result != 12 {
+			t.Errorf("GCD() = %v, 期望 %v", result, 12)
+		}
+	})
+}
+
+func TestDivMod(t *testing.T) {
+	tests := []struct {
+		name      string
+		a, b      int
+		expectedQ int
+		expectedR int
+	}{
+		{name: "正数", a: 7, b: 2, expectedQ: 3, expectedR: 1},
+		{name: "被除数为负", a: -7, b: 2, expectedQ: -4, expectedR: 1},
+		{name: "除数为负", a: 7, b: -2, expectedQ: -4, expectedR: -1},
+		{name: "都为负", a: -7, b: -2, expectedQ: 3, expectedR: -1},
+		{name: "整除", a: -6, b: 3, expectedQ: -2, expectedR: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, r := DivMod(tt.a, tt.b)
+			if q != tt.expectedQ || r != tt.expectedR {
+				t.Errorf("DivMod() = (%v, %v), 期望 (%v, %v)", q, r, tt.expectedQ, tt.expectedR)
+			}
+		})
+	}
+}
+
+func TestCeilDiv(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     int
+		expected int
+	}{
+		{name: "整除", a: 10, b: 5, expected: 2},
+		{name: "有余数", a: 11, b: 5, expected: 3},
+		{name: "被除数为0", a: 0, b: 5, expected: 0},
+		{name: "被除数为负", a: -11, b: 5, expected: -2},
+		{name: "都为负", a: -11, b: -5, expected: 3},
+		{name: "接近上限", a: math.MaxInt, b: 2, expected: math.MaxInt/2 + 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := CeilDiv(tt.a, tt.b); result != tt.expected {
+				t.Errorf("CeilDiv() = %v, 期望 %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAbsAndSign(t *testing.T) {
+	tests := []struct {
+		name         string
+		v            int
+		expectedAbs  int
+		expectedSign int
+	}{
+		{name: "正数", v: 5, expectedAbs: 5, expectedSign: 1},
+		{name: "负数", v: -5, expectedAbs: 5, expectedSign: -1},
+		{name: "零", v: 0, expectedAbs: 0, expectedSign: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := Abs(tt.v); result != tt.expectedAbs {
+				t.Errorf("Abs() = %v, 期望 %v", result, tt.expectedAbs)
+			}
+			if result := Sign(tt.v); result != tt.expectedSign {
+				t.Errorf("Sign() = %v, 期望 %v", result, tt.expectedSign)
+			}
+		})
+	}
+
+	t.Run("浮点数", func(t *testing.T) {
+		if result := Abs(-2.5); result != 2.5 {
+			t.Errorf("Abs() = %v, 期望 %v", result, 2.5)
+		}
+		if result := Sign(-0.1); result != -1 {
+			t.Errorf("Sign() = %v, 期望 %v", result, -1)
+		}
+		if result := Sign(math.NaN()); result != 0 {
+			t.Errorf("Sign(NaN) = %v, 期望 %v", result, 0)
+		}
+	})
+
+	t.Run("自定义类型", func(t *testing.T) {
+		type Celsius int8
+		if result := Abs(Celsius(-40)); result != 40 {
+			t.Errorf("Abs() = %v, 期望 %v", result, 40)
+		}
+	})
+}
+
+func TestSafeDiv(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     float64
+		fallback float64
+		expected float64
+	}{
+		{name: "正常除法", a: 1, b: 4, fallback: -1, expected: 0.25},
+		{name: "除数为0", a: 1, b: 0, fallback: -1, expected: -1},
+		{name: "0除以0", a: 0, b: 0, fallback: 0, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := SafeDiv(tt.a, tt.b, tt.fallback); result != tt.expected {
+				t.Errorf("SafeDiv() = %v, 期望 %v", result, tt.expected)
+			}
+		})
+	}
+
+	t.Run("整数", func(t *testing.T) {
+		if result := SafeDiv(7, 2, 0); result != 3 {
+			t.Errorf("SafeDiv() = %v, 期望 %v", result, 3)
+		}
+		if result := SafeDiv(7, 0, 0); result != 0 {
+			t.Errorf("SafeDiv() = %v, 期望 %v", result, 0)
+		}
+	})
+}
+
+func TestSafeMod(t *testing.T) {
+	if result := SafeMod(7, 3, -1); result != 1 {
+		t.Errorf("SafeMod() = %v, 期望 %v", result, 1)
+	}
+	if result := SafeMod(7, 0, -1); result != -1 {
+		t.Errorf("SafeMod() = %v, 期望 %v", result, -1)
+	}
+}
+
+func TestLerp(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b, t  float64
+		expected float64
+	}{
+		{name: "起点", a: 10, b: 20, t: 0, expected: 10},
+		{name: "终点", a: 10, b: 20, t: 1, expected: 20},
+		{name: "中点", a: 10, b: 20, t: 0.5, expected: 15},
+		{name: "外推", a: 10, b: 20, t: 1.5, expected: 25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := Lerp(tt.a, tt.b, tt.t); result != tt.expected {
+				t.Errorf("Lerp() = %v, 期望 %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMapRange(t *testing.T) {
+	tests := []struct {
+		name            string
+		v               float64
+		inLo, inHi      float64
+		outLo, outHi    float64
+		expected        float64
+		expectedClamped float64
+	}{
+		{name: "归一化", v: 75, inLo: 0, inHi: 100, outLo: 0, outHi: 1, expected: 0.75, expectedClamped: 0.75},
+		{name: "反向区间", v: 25, inLo: 0, inHi: 100, outLo: 100, outHi: 0, expected: 75, expectedClamped: 75},
+		{name: "超出输入区间", v: 150, inLo: 0, inHi: 100, outLo: 0, outHi: 10, expected: 15, expectedClamped: 10},
+		{name: "低于输入区间", v: -50, inLo: 0, inHi: 100, outLo: 0, outHi: 10, expected: -5, expectedClamped: 0},
+		{name: "输入区间宽度为0", v: 5, inLo: 1, inHi: 1, outLo: 3, outHi: 9, expected: 3, expectedClamped: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := MapRange(tt.v, tt.inLo, tt.inHi, tt.outLo, tt.outHi); result != tt.expected {
+				t.Errorf("MapRange() = %v, 期望 %v", result, tt.expected)
+			}
+			if result := MapRangeClamped(tt.v, tt.inLo, tt.inHi, tt.outLo, tt.outHi); result != tt.expectedClamped {
+				t.Errorf("MapRangeClamped() = %v, 期望 %v", result, tt.expectedClamped)
+			}
+		})
+	}
+}
+
+func TestCheckedArithmetic(t *testing.T) {
+	tests := []struct {
+		name       string
+		fn         func(int8, int8) (int8, bool)
+		a, b       int8
+		expected   int8
+		expectedOK bool
+	}{
+		{name: "加法", fn: AddChecked[int8], a: 100, b: 27, expected: 127, expectedOK: true},
+		{name: "加法上溢", fn: AddChecked[int8], a: 100, b: 28, expectedOK: false},
+		{name: "加法下溢", fn: AddChecked[int8], a: -100, b: -29, expectedOK: false},
+		{name: "减法", fn: SubChecked[int8], a: -100, b: 28, expected: -128, expectedOK: true},
+		{name: "减法下溢", fn: SubChecked[int8], a: -100, b: 29, expectedOK: false},
+		{name: "减法上溢", fn: SubChecked[int8], a: 100, b: -28, expectedOK: false},
+		{name: "乘法", fn: MulChecked[int8], a: -8, b: 16, expected: -128, expectedOK: true},
+		{name: "乘法溢出", fn: MulChecked[int8], a: 16, b: 8, expectedOK: false},
+		{name: "最小值乘以-1", fn: MulChecked[int8], a: math.MinInt8, b: -1, expectedOK: false},
+		{name: "-1乘以最小值", fn: MulChecked[int8], a: -1, b: math.MinInt8, expectedOK: false},
+		{name: "乘以0", fn: MulChecked[int8], a: math.MinInt8, b: 0, expected: 0, expectedOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, ok := tt.fn(tt.a, tt.b)
+			if ok != tt.expectedOK {
+				t.Fatalf("ok = %v, 期望 %v", ok, tt.expectedOK)
+			}
+			if ok && result != tt.expected {
+				t.Errorf("结果 = %v, 期望 %v", result, tt.expected)
+			}
+		})
+	}
+
+	t.Run("无符号整数", func(t *testing.T) {
+		if _, ok := AddChecked[uint8](200, 56); ok {
+			t.Errorf("AddChecked() 应该溢出")
+		}
+		if _, ok := SubChecked[uint8](1, 2); ok {
+			t.Errorf("SubChecked() 应该溢出")
+		}
+		if result, ok := MulChecked[uint8](15, 17); !ok || result != 255 {
+			t.Errorf("MulChecked() = (%v, %v), 期望 (255, true)", result, ok)
+		}
+		if _, ok := MulChecked[uint64](math.MaxUint32+1, math.MaxUint32+1); ok {
+			t.Errorf("MulChecked() 应该溢出")
+		}
+	})
+}
+
+func TestRunningStats(t *testing.T) {
+	data := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+
+	var stats RunningStats
+	for _, v := range data {
+		stats.Add(v)
+	}
+
+	if stats.Count() != len(data) {
+		t.Errorf("Count() = %v, 期望 %v", stats.Count(), len(data))
+	}
+	if stats.Mean() != 5 {
+		t.Errorf("Mean() = %v, 期望 %v", stats.Mean(), 5)
+	}
+	if stats.Min() != 2 || stats.Max() != 9 {
+		t.Errorf("Min(), Max() = %v, %v, 期望 2, 9", stats.Min(), stats.Max())
+	}
+	expectedVariance, _ := Variance(data)
+	if math.Abs(stats.Variance()-expectedVariance) > 1e-9 {
+		t.Errorf("Variance() = %v, 期望 %v", stats.Variance(), expectedVariance)
+	}
+	if math.Abs(stats.StdDev()-2) > 1e-9 {
+		t.Errorf("StdDev() = %v, 期望 %v", stats.StdDev(), 2)
+	}
+
+	t.Run("负数最大值", func(t *testing.T) {
+		var s RunningStats
+		s.Add(-3)
+		s.Add(-1)
+		if s.Max() != -1 || s.Min() != -3 {
+			t.Errorf("Min(), Max() = %v, %v, 期望 -3, -1", s.Min(), s.Max())
+		}
+	})
+
+	t.Run("重置", func(t *testing.T) {
+		stats.Reset()
+		if stats.Count() != 0 || stats.Mean() != 0 || stats.StdDev() != 0 {
+			t.Errorf("Reset() 后统计量未清空")
+		}
+	})
+
+	t.Run("并发添加", func(t *testing.T) {
+		var s RunningStats
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					s.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+		if s.Count() != 1000 || s.Mean() != 1 {
+			t.Errorf("Count(), Mean() = %v, %v, 期望 1000, 1", s.Count(), s.Mean())
+		}
+	})
+}
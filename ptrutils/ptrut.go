@@ -0,0 +1,50 @@
+package ptrutils
+
+// Ptr 返回指向 v 副本的指针，便于为字面量和常量取地址
+func Ptr[T any](v T) *T {
+	return &v
+}
+
+// Deref 返回指针指向的值，p 为 nil 时返回零值
+func Deref[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
+
+// DerefOr 返回指针指向的值，p 为 nil 时返回 def
+func DerefOr[T any](p *T, def T) T {
+	if p == nil {
+		return def
+	}
+	return *p
+}
+
+// Equal 判断两个指针指向的值是否相等，两者都为 nil 时相等，只有一个为 nil 时不相等
+func Equal[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// ToPtrSlice 返回由指向每个元素副本的指针组成的切片
+func ToPtrSlice[T any](slice []T) []*T {
+	result := make([]*T, len(slice))
+	for i := range slice {
+		v := slice[i]
+		result[i] = &v
+	}
+	return result
+}
+
+// FromPtrSlice 返回由指针指向的值组成的切片，nil 指针对应零值
+func FromPtrSlice[T any](slice []*T) []T {
+	result := make([]T, len(slice))
+	for i, p := range slice {
+		result[i] = Deref(p)
+	}
+	return result
+}
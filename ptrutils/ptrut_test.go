@@ -0,0 +1,80 @@
+package ptrutils
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPtr(t *testing.T) {
+	p := Ptr(42)
+	if p == nil || *p != 42 {
+		t.Errorf("Ptr() = %v, 期望指向 %v", p, 42)
+	}
+	v := "a"
+	sp := Ptr(v)
+	*sp = "b"
+	if v != "a" {
+		t.Errorf("Ptr() 应指向副本, 原值被修改为 %v", v)
+	}
+}
+
+func TestDeref(t *testing.T) {
+	tests := []struct {
+		name   string
+		p      *int
+		want   int
+		wantOr int
+	}{
+		{"非 nil", Ptr(5), 5, 5},
+		{"nil", nil, 0, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Deref(tt.p); got != tt.want {
+				t.Errorf("Deref() = %v, 期望 %v", got, tt.want)
+			}
+			if got := DerefOr(tt.p, -1); got != tt.wantOr {
+				t.Errorf("DerefOr() = %v, 期望 %v", got, tt.wantOr)
+			}
+		})
+	}
+}
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b *string
+		want bool
+	}{
+		{"值相等", Ptr("x"), Ptr("x"), true},
+		{"值不等", Ptr("x"), Ptr("y"), false},
+		{"都为 nil", nil, nil, true},
+		{"一个为 nil", Ptr("x"), nil, false},
+		{"另一个为 nil", nil, Ptr(""), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Equal(tt.a, tt.b); got != tt.want {
+				t.Errorf("Equal() = %v, 期望 %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPtrSlice(t *testing.T) {
+	values := []int{1, 2, 3}
+	ptrs := ToPtrSlice(values)
+	*ptrs[0] = 100
+	if values[0] != 1 {
+		t.Errorf("ToPtrSlice() 应指向副本, 原切片被修改为 %v", values)
+	}
+	if got := FromPtrSlice(ptrs); !slices.Equal(got, []int{100, 2, 3}) {
+		t.Errorf("FromPtrSlice() = %v, 期望 %v", got, []int{100, 2, 3})
+	}
+	if got := FromPtrSlice([]*int{Ptr(1), nil}); !slices.Equal(got, []int{1, 0}) {
+		t.Errorf("FromPtrSlice() = %v, 期望 %v", got, []int{1, 0})
+	}
+	if got := ToPtrSlice[int](nil); len(got) != 0 {
+		t.Errorf("ToPtrSlice(nil) = %v, 期望空切片", got)
+	}
+}
@@ -0,0 +1,264 @@
+package randutils
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"math/big"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Source 随机数来源，Intn 返回 [0, n) 范围内的随机整数，n <= 0 时可以 panic
+// *rand.Rand 满足该接口；本包、strutils、sliceutils、backoff 的抖动、concurrency 的定时抖动和 collections 的跳表都基于它，
+// 测试中可以注入固定种子的实现
+type Source interface {
+	Intn(n int) int
+}
+
+// globalSource 使用 math/rand 的全局随机数生成器，并发安全
+type globalSource struct{}
+
+func (globalSource) Intn(n int) int {
+	return rand.Intn(n)
+}
+
+// Default 返回使用 math/rand 全局随机数生成器的 Source，并发安全
+func Default() Source {
+	return globalSource{}
+}
+
+// lockedSource 加锁的 *rand.Rand，使其可以在多个 goroutine 中使用
+type lockedSource struct {
+	mu sync.Mutex
+	r  *rand.Rand
+}
+
+func (s *lockedSource) Intn(n int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.r.Intn(n)
+}
+
+// NewSource 返回使用固定种子的 Source，相同种子产生相同的序列，并发安全
+func NewSource(seed int64) Source {
+	return &lockedSource{r: rand.New(rand.NewSource(seed))}
+}
+
+// secureSource 基于 crypto/rand 的 Source
+type secureSource struct{}
+
+func (secureSource) Intn(n int) int {
+	v, err := IntnSecure(n)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Secure 返回使用 crypto/rand 的 Source，适用于安全敏感的场景；读取系统随机数失败时 Intn 会 panic
+func Secure() Source {
+	return secureSource{}
+}
+
+// IntnSecure 使用 crypto/rand 返回 [0, n) 范围内均匀分布的随机整数，n <= 0 时 panic
+func IntnSecure(n int) (int, error) {
+	if n <= 0 {
+		panic("randutils: invalid argument to IntnSecure")
+	}
+	v, err := crand.Int(crand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, err
+	}
+	return int(v.Int64()), nil
+}
+
+// Between 返回 [min, max] 闭区间内的随机整数，min > max 时交换两者
+func Between(min, max int) int {
+	return BetweenFrom(globalSource{}, min, max)
+}
+
+// BetweenFrom 与 Between 相同，使用指定的随机源
+func BetweenFrom(src Source, min, max int) int {
+	return int(Int64BetweenFrom(src, int64(min), int64(max)))
+}
+
+// Int64BetweenFrom 使用 src 返回 [min, max] 闭区间内均匀分布的随机 int64，min > max 时交换两者
+// 区间大小超出 Intn 的参数范围时（如 32 位平台上较长的 time.Duration）同样适用
+func Int64BetweenFrom(src Source, min, max int64) int64 {
+	if min > max {
+		min, max = max, min
+	}
+	span := uint64(max) - uint64(min)
+	if span < math.MaxInt {
+		return min + int64(src.Intn(int(span)+1))
+	}
+	return min + int64(uniformUint64(src, span))
+}
+
+// uniformUint64 返回 [0, span] 范围内均匀分布的随机数
+// 对 64 位随机数做拒绝采样：丢弃会导致取模偏差的最小的 2^64 mod (span+1) 个值
+func uniformUint64(src Source, span uint64) uint64 {
+	if span == math.MaxUint64 {
+		return uint64From(src)
+	}
+	n := span + 1
+	threshold := -n % n
+	for {
+		if v := uint64From(src); v >= threshold {
+			return v % n
+		}
+	}
+}
+
+// uint64From 拼接多次取值得到 64 位随机数，每次取值不超过 30 位以兼容 32 位平台
+func uint64From(src Source) uint64 {
+	return uint64(src.Intn(1<<30))<<34 | uint64(src.Intn(1<<30))<<4 | uint64(src.Intn(1<<4))
+}
+
+// Float64From 使用 src 返回 [0, 1) 范围内的随机浮点数
+func Float64From(src Source) float64 {
+	// 分两次取 53 位，避免在 32 位平台上超出 int 的范围
+	hi, lo := src.Intn(1<<26), src.Intn(1<<27)
+	return (float64(hi)*(1<<27) + float64(lo)) / (1 << 53)
+}
+
+// Pick 随机返回 slice 中的一个元素，slice 为空时返回零值和 false
+func Pick[T any](slice []T) (T, bool) {
+	return PickFrom(globalSource{}, slice)
+}
+
+// PickFrom 与 Pick 相同，使用指定的随机源
+func PickFrom[T any](src Source, slice []T) (T, bool) {
+	if len(slice) == 0 {
+		var zero T
+		return zero, false
+	}
+	return slice[src.Intn(len(slice))], true
+}
+
+// PickWeighted 按权重随机返回 items 中的一个元素，被选中的概率与 weights 中对应的权重成正比
+// 权重小于等于 0 的元素不会被选中；items 为空、长度与 weights 不一致或权重之和不为正数时返回零值和 false
+func PickWeighted[T any](items []T, weights []float64) (T, bool) {
+	return PickWeightedFrom(globalSource{}, items, weights)
+}
+
+// PickWeightedFrom 与 PickWeighted 相同，使用指定的随机源
+func PickWeightedFrom[T any](src Source, items []T, weights []float64) (T, bool) {
+	var zero T
+	if len(items) == 0 || len(items) != len(weights) {
+		return zero, false
+	}
+	var total float64
+	for _, w := range weights {
+		if w > 0 {
+			total += w
+		}
+	}
+	if total <= 0 || math.IsInf(total, 0) || math.IsNaN(total) {
+		return zero, false
+	}
+
+	r := Float64From(src) * total
+	last := -1
+	for i, w := range weights {
+		if w <= 0 {
+			continue
+		}
+		if r < w {
+			return items[i], true
+		}
+		r -= w
+		last = i
+	}
+	// 浮点误差可能使 r 略大于剩余的权重，此时返回最后一个可选元素
+	return items[last], true
+}
+
+// Shuffle 使用 Fisher-Yates 算法原地随机打乱 slice
+func Shuffle[T any](slice []T) {
+	ShuffleFrom(globalSource{}, slice)
+}
+
+// ShuffleFrom 与 Shuffle 相同，使用指定的随机源
+func ShuffleFrom[T any](src Source, slice []T) {
+	for i := len(slice) - 1; i > 0; i-- {
+		j := src.Intn(i + 1)
+		slice[i], slice[j] = slice[j], slice[i]
+	}
+}
+
+// Sample 从 slice 中不放回地随机选取 k 个元素，返回新切片，不修改 slice
+// k 大于 len(slice) 时返回全部元素的随机排列，k <= 0 时返回空切片
+func Sample[T any](slice []T, k int) []T {
+	return SampleFrom(globalSource{}, slice, k)
+}
+
+// SampleFrom 与 Sample 相同，使用指定的随机源
+func SampleFrom[T any](src Source, slice []T, k int) []T {
+	k = min(max(k, 0), len(slice))
+	// 对下标做部分 Fisher-Yates，只交换前 k 个位置
+	idx := make([]int, len(slice))
+	for i := range idx {
+		idx[i] = i
+	}
+	result := make([]T, k)
+	for i := 0; i < k; i++ {
+		j := i + src.Intn(len(idx)-i)
+		idx[i], idx[j] = idx[j], idx[i]
+		result[i] = slice[idx[i]]
+	}
+	return result
+}
+
+// UUIDv4 使用 crypto/rand 生成一个随机的 UUID（RFC 9562 版本 4），格式为 xxxxxxxx-xxxx-4xxx-yxxx-xxxxxxxxxxxx
+// 读取系统随机数失败时 panic
+func UUIDv4() string {
+	var u [16]byte
+	readSecure(u[:])
+	u[6] = u[6]&0x0f | 0x40
+	u[8] = u[8]&0x3f | 0x80
+	return formatUUID(u)
+}
+
+// UUIDv7 生成一个以当前 Unix 毫秒时间戳开头的 UUID（RFC 9562 版本 7），按字符串排序即按生成时间排序
+// 同一毫秒内生成的多个 UUID 之间的顺序是随机的；读取系统随机数失败时 panic
+func UUIDv7() string {
+	return uuidV7At(time.Now())
+}
+
+// uuidV7At 生成时间戳为 t 的版本 7 UUID
+func uuidV7At(t time.Time) string {
+	var u [16]byte
+	readSecure(u[6:])
+	var ts [8]byte
+	binary.BigEndian.PutUint64(ts[:], uint64(t.UnixMilli()))
+	copy(u[:6], ts[2:])
+	u[6] = u[6]&0x0f | 0x70
+	u[8] = u[8]&0x3f | 0x80
+	return formatUUID(u)
+}
+
+// readSecure 使用 crypto/rand 填充 b
+func readSecure(b []byte) {
+	if _, err := crand.Read(b); err != nil {
+		panic("randutils: reading random bytes: " + err.Error())
+	}
+}
+
+// formatUUID 将 16 字节格式化为 8-4-4-4-12 的十六进制字符串
+func formatUUID(u [16]byte) string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
@@ -0,0 +1,279 @@
+package randutils
+
+import (
+	"math"
+	"math/rand"
+	"regexp"
+	"slices"
+	"testing"
+	"time"
+)
+
+// seqSource 按顺序返回 vals 中的值，-1 表示 n-1，用于构造特定的随机序列
+type seqSource struct {
+	vals []int
+	i    int
+}
+
+func (s *seqSource) Intn(n int) int {
+	v := s.vals[s.i%len(s.vals)]
+	s.i++
+	if v < 0 {
+		return n - 1
+	}
+	return v % n
+}
+
+func TestSources(t *testing.T) {
+	t.Run("相同种子序列相同", func(t *testing.T) {
+		a, b := NewSource(42), NewSource(42)
+		for i := 0; i < 10; i++ {
+			if x, y := a.Intn(1000), b.Intn(1000); x != y {
+				t.Fatalf("第 %v 次 Intn() = %v, %v, 期望相同", i, x, y)
+			}
+		}
+	})
+
+	t.Run("*rand.Rand 满足 Source", func(t *testing.T) {
+		var src Source = rand.New(rand.NewSource(1))
+		if v := src.Intn(10); v < 0 || v >= 10 {
+			t.Errorf("Intn(10) = %v, 超出范围", v)
+		}
+	})
+
+	for name, src := range map[string]Source{"Default": Default(), "Secure": Secure()} {
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				if v := src.Intn(7); v < 0 || v >= 7 {
+					t.Fatalf("Intn(7) = %v, 超出范围", v)
+				}
+			}
+		})
+	}
+}
+
+func TestIntnSecure(t *testing.T) {
+	seen := make(map[int]bool)
+	for i := 0; i < 200; i++ {
+		v, err := IntnSecure(4)
+		if err != nil {
+			t.Fatalf("IntnSecure() 错误 = %v", err)
+		}
+		if v < 0 || v >= 4 {
+			t.Fatalf("IntnSecure(4) = %v, 超出范围", v)
+		}
+		seen[v] = true
+	}
+	if len(seen) != 4 {
+		t.Errorf("200 次取值只出现了 %v 个不同的值, 期望 4 个", len(seen))
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("IntnSecure(0) 期望 panic")
+		}
+	}()
+	IntnSecure(0)
+}
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		name     string
+		min, max int
+	}{
+		{"普通区间", 3, 7},
+		{"单个值", 5, 5},
+		{"负数区间", -10, -5},
+		{"反向区间", 7, 3},
+		{"整个 int 范围", math.MinInt, math.MaxInt},
+	}
+	src := NewSource(1)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lo, hi := min(tt.min, tt.max), max(tt.min, tt.max)
+			for i := 0; i < 100; i++ {
+				if v := BetweenFrom(src, tt.min, tt.max); v < lo || v > hi {
+					t.Fatalf("BetweenFrom(%v, %v) = %v, 超出范围", tt.min, tt.max, v)
+				}
+			}
+		})
+	}
+
+	t.Run("大区间使用拒绝采样", func(t *testing.T) {
+		// 区间大小为 2^63+1，全 0 的 64 位随机数落在会产生偏差的范围内，必须被丢弃
+		src := &seqSource{vals: []int{0, 0, 0, -1, -1, -1}}
+		if v := Int64BetweenFrom(src, math.MinInt64, 0); v != -2 {
+			t.Errorf("Int64BetweenFrom() = %v, 期望 %v", v, -2)
+		}
+		if src.i != 6 {
+			t.Errorf("Intn 调用了 %v 次, 期望 %v", src.i, 6)
+		}
+	})
+
+	t.Run("int64 区间", func(t *testing.T) {
+		src := NewSource(1)
+		for i := 0; i < 100; i++ {
+			if v := Int64BetweenFrom(src, 0, math.MaxInt64); v < 0 {
+				t.Fatalf("Int64BetweenFrom(0, MaxInt64) = %v, 超出范围", v)
+			}
+		}
+	})
+
+	seen := make(map[int]bool)
+	for i := 0; i < 200; i++ {
+		seen[Between(1, 3)] = true
+	}
+	if len(seen) != 3 {
+		t.Errorf("Between(1, 3) 只出现了 %v 个不同的值, 期望 3 个", len(seen))
+	}
+}
+
+func TestFloat64From(t *testing.T) {
+	src := NewSource(1)
+	for i := 0; i < 1000; i++ {
+		if f := Float64From(src); f < 0 || f >= 1 {
+			t.Fatalf("Float64From() = %v, 超出 [0, 1)", f)
+		}
+	}
+}
+
+func TestPick(t *testing.T) {
+	if _, ok := Pick([]int{}); ok {
+		t.Errorf("Pick() 空切片 ok = true, 期望 false")
+	}
+	items := []string{"a", "b", "c"}
+	for i := 0; i < 20; i++ {
+		v, ok := Pick(items)
+		if !ok || !slices.Contains(items, v) {
+			t.Fatalf("Pick() = %v, %v, 期望 items 中的元素", v, ok)
+		}
+	}
+}
+
+func TestPickWeighted(t *testing.T) {
+	t.Run("按权重分布", func(t *testing.T) {
+		src := NewSource(7)
+		counts := make(map[string]int)
+		items := []string{"a", "b", "never"}
+		for i := 0; i < 10000; i++ {
+			v, ok := PickWeightedFrom(src, items, []float64{1, 3, 0})
+			if !ok {
+				t.Fatalf("PickWeightedFrom() ok = false")
+			}
+			counts[v]++
+		}
+		if counts["never"] != 0 {
+			t.Errorf("权重为 0 的元素被选中 %v 次", counts["never"])
+		}
+		if ratio := float64(counts["b"]) / float64(counts["a"]); ratio < 2.7 || ratio > 3.3 {
+			t.Errorf("b/a 比例 = %.2f, 期望约 3", ratio)
+		}
+	})
+
+	tests := []struct {
+		name    string
+		items   []int
+		weights []float64
+	}{
+		{"空切片", nil, nil},
+		{"长度不一致", []int{1, 2}, []float64{1}},
+		{"权重全为零", []int{1, 2}, []float64{0, -1}},
+		{"权重为 NaN", []int{1}, []float64{math.NaN()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := PickWeighted(tt.items, tt.weights); ok {
+				t.Errorf("PickWeighted() ok = true, 期望 false")
+			}
+		})
+	}
+}
+
+func TestShuffle(t *testing.T) {
+	original := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	a := slices.Clone(original)
+	b := slices.Clone(original)
+	ShuffleFrom(NewSource(3), a)
+	ShuffleFrom(NewSource(3), b)
+	if !slices.Equal(a, b) {
+		t.Errorf("ShuffleFrom() 相同种子结果不同: %v, %v", a, b)
+	}
+	sorted := slices.Clone(a)
+	slices.Sort(sorted)
+	if !slices.Equal(sorted, original) {
+		t.Errorf("ShuffleFrom() 结果 %v 不是原切片的排列", a)
+	}
+
+	c := slices.Clone(original)
+	Shuffle(c)
+	slices.Sort(c)
+	if !slices.Equal(c, original) {
+		t.Errorf("Shuffle() 结果不是原切片的排列")
+	}
+}
+
+func TestSample(t *testing.T) {
+	original := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	tests := []struct {
+		name    string
+		k       int
+		wantLen int
+	}{
+		{"部分元素", 3, 3},
+		{"全部元素", 10, 10},
+		{"超过长度", 20, 10},
+		{"零个", 0, 0},
+		{"负数", -1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Sample(original, tt.k)
+			if len(got) != tt.wantLen {
+				t.Fatalf("Sample(%v) 长度 = %v, 期望 %v", tt.k, len(got), tt.wantLen)
+			}
+			seen := make(map[int]bool)
+			for _, v := range got {
+				if seen[v] || !slices.Contains(original, v) {
+					t.Errorf("Sample(%v) = %v, 存在重复或不属于原切片的元素", tt.k, got)
+				}
+				seen[v] = true
+			}
+		})
+	}
+	if !slices.Equal(SampleFrom(NewSource(5), original, 4), SampleFrom(NewSource(5), original, 4)) {
+		t.Errorf("SampleFrom() 相同种子结果不同")
+	}
+	if !slices.Equal(original, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}) {
+		t.Errorf("Sample() 修改了原切片: %v", original)
+	}
+}
+
+func TestUUID(t *testing.T) {
+	v4 := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	v7 := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		u := UUIDv4()
+		if !v4.MatchString(u) {
+			t.Fatalf("UUIDv4() = %v, 格式不正确", u)
+		}
+		if seen[u] {
+			t.Fatalf("UUIDv4() 生成了重复的值 %v", u)
+		}
+		seen[u] = true
+	}
+
+	if u := UUIDv7(); !v7.MatchString(u) {
+		t.Errorf("UUIDv7() = %v, 格式不正确", u)
+	}
+	ts := time.UnixMilli(0x0123456789ab)
+	if u := uuidV7At(ts); u[:13] != "01234567-89ab" {
+		t.Errorf("uuidV7At() = %v, 期望以时间戳 01234567-89ab 开头", u)
+	}
+	earlier, later := uuidV7At(ts), uuidV7At(ts.Add(time.Millisecond))
+	if earlier >= later {
+		t.Errorf("UUIDv7 不按时间排序: %v >= %v", earlier, later)
+	}
+}
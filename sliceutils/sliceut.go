@@ -1,5 +1,11 @@
 package sliceutils
 
+import (
+	"github.com/jiu-u/gogout/mathutils"
+	"github.com/jiu-u/gogout/randutils"
+	"github.com/jiu-u/gogout/types"
+)
+
 // Map 对切片中的每个元素应用函数 fn，返回一个新的切片
 // 如果输入切片为空，则返回空切片
 func Map[T any, R any](input []T, fn func(T) R) []R {
@@ -211,7 +217,7 @@ func Chunk[T any](slice []T, size int) [][]T {
 		return [][]T{}
 	}
 
-	chunksCount := (len(slice) + size - 1) / size
+	chunksCount := mathutils.CeilDiv(len(slice), size)
 	chunks := make([][]T, 0, chunksCount)
 
 	for i := 0; i < len(slice); i += size {
@@ -239,41 +245,12 @@ func ForEachWithIndex[T any](slice []T, fn func(int, T)) {
 	}
 }
 
-// Shuffle 随机打乱切片元素顺序，返回新切片
-// 使用 Fisher-Yates 算法
+// Shuffle 随机打乱切片元素顺序，返回新切片，不修改原切片
+// 使用 Fisher-Yates 算法；需要可复现的结果时使用 randutils.ShuffleFrom
 func Shuffle[T any](slice []T) []T {
-	if len(slice) <= 1 {
-		result := make([]T, len(slice))
-		copy(result, slice)
-		return result
-	}
-
-	// 导入必要的包
-	// import "math/rand"
-	// import "time"
-
-	// 在实际使用时取消下面代码的注释
-	/*
-		result := make([]T, len(slice))
-		copy(result, slice)
-
-		r := rand.New(rand.NewSource(time.Now().UnixNano()))
-		for i := len(result) - 1; i > 0; i-- {
-			j := r.Intn(i + 1)
-			result[i], result[j] = result[j], result[i]
-		}
-		return result
-	*/
-
-	// 由于不能在包级别导入，这里提供一个简单实现
 	result := make([]T, len(slice))
 	copy(result, slice)
-
-	for i := len(result) - 1; i > 0; i-- {
-		// 警告：这不是真正的随机，实际应用中请使用上面注释的代码
-		j := i % (i + 1)
-		result[i], result[j] = result[j], result[i]
-	}
+	randutils.Shuffle(result)
 	return result
 }
 
@@ -585,3 +562,50 @@ func Zip[T any](slices ...[]T) [][]T {
 
 	return result
 }
+
+// Zip2 将两个不同类型切片对应位置的元素组合成二元组，长度以较短的切片为准
+func Zip2[A, B any](a []A, b []B) []types.Pair[A, B] {
+	n := min(len(a), len(b))
+	result := make([]types.Pair[A, B], n)
+	for i := 0; i < n; i++ {
+		result[i] = types.NewPair(a[i], b[i])
+	}
+	return result
+}
+
+// Unzip2 将二元组切片拆分为两个切片，是 Zip2 的逆操作
+func Unzip2[A, B any](pairs []types.Pair[A, B]) ([]A, []B) {
+	a := make([]A, len(pairs))
+	b := make([]B, len(pairs))
+	for i, p := range pairs {
+		a[i], b[i] = p.Unpack()
+	}
+	return a, b
+}
+
+// Enumerate 返回由下标和元素组成的二元组切片
+func Enumerate[T any](slice []T) []types.Pair[int, T] {
+	result := make([]types.Pair[int, T], len(slice))
+	for i, v := range slice {
+		result[i] = types.NewPair(i, v)
+	}
+	return result
+}
+
+// Entries 将 map 转换为键值二元组切片，顺序不保证
+func Entries[K comparable, V any](m map[K]V) []types.Pair[K, V] {
+	result := make([]types.Pair[K, V], 0, len(m))
+	for k, v := range m {
+		result = append(result, types.NewPair(k, v))
+	}
+	return result
+}
+
+// FromEntries 将键值二元组切片转换为 map，key 重复时后面的值覆盖前面的值
+func FromEntries[K comparable, V any](entries []types.Pair[K, V]) map[K]V {
+	result := make(map[K]V, len(entries))
+	for _, e := range entries {
+		result[e.First] = e.Second
+	}
+	return result
+}
@@ -6,6 +6,8 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+
+	"github.com/jiu-u/gogout/types"
 )
 
 func TestMap(t *testing.T) {
@@ -738,6 +740,16 @@ func TestShuffle(t *testing.T) {
 		}
 	})
 
+	t.Run("结果是随机的", func(t *testing.T) {
+		original := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+		for i := 0; i < 20; i++ {
+			if !reflect.DeepEqual(Shuffle(original), original) {
+				return
+			}
+		}
+		t.Errorf("Shuffle() 连续 20 次返回原顺序")
+	})
+
 	t.Run("空切片", func(t *testing.T) {
 		var original []int
 		result := Shuffle(original)
@@ -865,3 +877,68 @@ func TestIntersection(t *testing.T) {
 		})
 	}
 }
+
+func TestZip2(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        []string
+		b        []int
+		expected []types.Pair[string, int]
+	}{
+		{
+			name:     "长度相同",
+			a:        []string{"a", "b"},
+			b:        []int{1, 2},
+			expected: []types.Pair[string, int]{{First: "a", Second: 1}, {First: "b", Second: 2}},
+		},
+		{
+			name:     "长度不同",
+			a:        []string{"a", "b", "c"},
+			b:        []int{1},
+			expected: []types.Pair[string, int]{{First: "a", Second: 1}},
+		},
+		{
+			name:     "空切片",
+			a:        nil,
+			b:        []int{1},
+			expected: []types.Pair[string, int]{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Zip2(tt.a, tt.b)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Zip2() = %v, want %v", result, tt.expected)
+			}
+			a, b := Unzip2(result)
+			if len(a) != len(result) || len(b) != len(result) {
+				t.Errorf("Unzip2() 长度 = %v, %v, want %v", len(a), len(b), len(result))
+			}
+		})
+	}
+}
+
+func TestEnumerate(t *testing.T) {
+	result := Enumerate([]string{"a", "b"})
+	expected := []types.Pair[int, string]{{First: 0, Second: "a"}, {First: 1, Second: "b"}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Enumerate() = %v, want %v", result, expected)
+	}
+	if len(Enumerate([]int{})) != 0 {
+		t.Errorf("Enumerate() 空切片应返回空结果")
+	}
+}
+
+func TestEntries(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2}
+	entries := Entries(m)
+	sort.Slice(entries, func(i, j int) bool { return entries[i].First < entries[j].First })
+	expected := []types.Pair[string, int]{{First: "a", Second: 1}, {First: "b", Second: 2}}
+	if !reflect.DeepEqual(entries, expected) {
+		t.Errorf("Entries() = %v, want %v", entries, expected)
+	}
+	if back := FromEntries(entries); !reflect.DeepEqual(back, m) {
+		t.Errorf("FromEntries() = %v, want %v", back, m)
+	}
+}
@@ -0,0 +1,290 @@
+package stream
+
+// Stream 惰性求值的元素序列，Filter、Map、Take 等中间操作只组合处理函数，
+// 直到调用 Collect、Each 等终止操作时才逐个拉取元素，整条链不会为每一步分配中间切片
+// Stream 只能被消费一次，终止操作或 Next 取走的元素不会再次出现；零值是一个空的 Stream，非并发安全
+type Stream[T any] struct {
+	next func() (T, bool)
+}
+
+// FromFunc 创建由 next 产生元素的 Stream，next 返回 false 表示没有更多元素，之后不会再被调用
+func FromFunc[T any](next func() (T, bool)) Stream[T] {
+	done := false
+	return Stream[T]{next: func() (T, bool) {
+		if !done {
+			if v, ok := next(); ok {
+				return v, true
+			}
+			done = true
+		}
+		var zero T
+		return zero, false
+	}}
+}
+
+// From 创建按顺序产生 slice 中元素的 Stream，不会复制 slice，消费完之前修改 slice 会影响结果
+func From[T any](slice []T) Stream[T] {
+	i := 0
+	return Stream[T]{next: func() (T, bool) {
+		if i >= len(slice) {
+			var zero T
+			return zero, false
+		}
+		i++
+		return slice[i-1], true
+	}}
+}
+
+// Of 创建产生 values 的 Stream
+func Of[T any](values ...T) Stream[T] {
+	return From(values)
+}
+
+// FromChan 创建从 ch 接收元素的 Stream，ch 关闭后结束
+func FromChan[T any](ch <-chan T) Stream[T] {
+	return FromFunc(func() (T, bool) {
+		v, ok := <-ch
+		return v, ok
+	})
+}
+
+// Generate 创建由 fn 无限产生元素的 Stream，需要配合 Take 或 TakeWhile 使用
+func Generate[T any](fn func() T) Stream[T] {
+	return Stream[T]{next: func() (T, bool) {
+		return fn(), true
+	}}
+}
+
+// Iterate 创建无限 Stream：seed、fn(seed)、fn(fn(seed))...，需要配合 Take 或 TakeWhile 使用
+func Iterate[T any](seed T, fn func(T) T) Stream[T] {
+	v, started := seed, false
+	return Stream[T]{next: func() (T, bool) {
+		if started {
+			v = fn(v)
+		}
+		started = true
+		return v, true
+	}}
+}
+
+// Range 创建产生 [start, end) 范围内整数的 Stream，step 为负数时递减，step 为 0 时 panic
+func Range(start, end, step int) Stream[int] {
+	if step == 0 {
+		panic("stream: Range step must not be zero")
+	}
+	i := start
+	return Stream[int]{next: func() (int, bool) {
+		if (step > 0 && i >= end) || (step < 0 && i <= end) {
+			return 0, false
+		}
+		v := i
+		i += step
+		return v, true
+	}}
+}
+
+// Concat 依次连接多个 Stream
+func Concat[T any](streams ...Stream[T]) Stream[T] {
+	return FromFunc(func() (T, bool) {
+		for len(streams) > 0 {
+			if v, ok := streams[0].Next(); ok {
+				return v, true
+			}
+			streams = streams[1:]
+		}
+		var zero T
+		return zero, false
+	})
+}
+
+// Next 取出下一个元素，没有更多元素时返回零值和 false
+func (s Stream[T]) Next() (T, bool) {
+	if s.next == nil {
+		var zero T
+		return zero, false
+	}
+	return s.next()
+}
+
+// Filter 只保留满足 predicate 的元素
+func (s Stream[T]) Filter(predicate func(T) bool) Stream[T] {
+	return Stream[T]{next: func() (T, bool) {
+		for {
+			v, ok := s.Next()
+			if !ok || predicate(v) {
+				return v, ok
+			}
+		}
+	}}
+}
+
+// Map 对每个元素应用 fn，结果类型与元素类型相同；需要转换为其他类型时使用包级函数 Map
+func (s Stream[T]) Map(fn func(T) T) Stream[T] {
+	return Map(s, fn)
+}
+
+// Peek 在元素经过时调用 fn，不改变元素，常用于调试
+func (s Stream[T]) Peek(fn func(T)) Stream[T] {
+	return Stream[T]{next: func() (T, bool) {
+		v, ok := s.Next()
+		if ok {
+			fn(v)
+		}
+		return v, ok
+	}}
+}
+
+// Take 最多取前 n 个元素，取够后不再从上游拉取
+func (s Stream[T]) Take(n int) Stream[T] {
+	return Stream[T]{next: func() (T, bool) {
+		if n <= 0 {
+			var zero T
+			return zero, false
+		}
+		n--
+		return s.Next()
+	}}
+}
+
+// Skip 跳过前 n 个元素
+func (s Stream[T]) Skip(n int) Stream[T] {
+	return Stream[T]{next: func() (T, bool) {
+		for ; n > 0; n-- {
+			if _, ok := s.Next(); !ok {
+				n = 0
+				break
+			}
+		}
+		return s.Next()
+	}}
+}
+
+// TakeWhile 从开头取元素，遇到第一个不满足 predicate 的元素时结束
+func (s Stream[T]) TakeWhile(predicate func(T) bool) Stream[T] {
+	return FromFunc(func() (T, bool) {
+		v, ok := s.Next()
+		if !ok || !predicate(v) {
+			var zero T
+			return zero, false
+		}
+		return v, true
+	})
+}
+
+// DropWhile 跳过开头满足 predicate 的元素，之后的元素全部保留
+func (s Stream[T]) DropWhile(predicate func(T) bool) Stream[T] {
+	dropping := true
+	return Stream[T]{next: func() (T, bool) {
+		for {
+			v, ok := s.Next()
+			if !ok || !dropping || !predicate(v) {
+				dropping = false
+				return v, ok
+			}
+		}
+	}}
+}
+
+// Each 依次对每个元素调用 fn，fn 返回 false 时停止
+func (s Stream[T]) Each(fn func(T) bool) {
+	for {
+		v, ok := s.Next()
+		if !ok || !fn(v) {
+			return
+		}
+	}
+}
+
+// Collect 将剩余的元素收集到新切片中，没有元素时返回空切片
+func (s Stream[T]) Collect() []T {
+	result := []T{}
+	s.Each(func(v T) bool {
+		result = append(result, v)
+		return true
+	})
+	return result
+}
+
+// Count 消费所有元素并返回数量
+func (s Stream[T]) Count() int {
+	n := 0
+	s.Each(func(T) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+// First 返回第一个元素，没有元素时返回零值和 false
+func (s Stream[T]) First() (T, bool) {
+	return s.Next()
+}
+
+// Find 返回第一个满足 predicate 的元素，找到后停止拉取
+func (s Stream[T]) Find(predicate func(T) bool) (T, bool) {
+	return s.Filter(predicate).Next()
+}
+
+// Any 判断是否存在满足 predicate 的元素，找到后停止拉取
+func (s Stream[T]) Any(predicate func(T) bool) bool {
+	_, ok := s.Find(predicate)
+	return ok
+}
+
+// All 判断是否所有元素都满足 predicate，遇到不满足的元素后停止拉取；没有元素时返回 true
+func (s Stream[T]) All(predicate func(T) bool) bool {
+	return !s.Any(func(v T) bool { return !predicate(v) })
+}
+
+// Map 对每个元素应用 fn，返回元素类型为 R 的 Stream
+// Go 的方法不能引入新的类型参数，因此类型转换需要使用该函数，如 stream.Map(s.Filter(f), g).Take(10)
+func Map[T, R any](s Stream[T], fn func(T) R) Stream[R] {
+	return Stream[R]{next: func() (R, bool) {
+		v, ok := s.Next()
+		if !ok {
+			var zero R
+			return zero, false
+		}
+		return fn(v), true
+	}}
+}
+
+// FlatMap 对每个元素应用 fn，并依次产生 fn 返回的切片中的元素
+func FlatMap[T, R any](s Stream[T], fn func(T) []R) Stream[R] {
+	var buf []R
+	return Stream[R]{next: func() (R, bool) {
+		for len(buf) == 0 {
+			v, ok := s.Next()
+			if !ok {
+				var zero R
+				return zero, false
+			}
+			buf = fn(v)
+		}
+		r := buf[0]
+		buf = buf[1:]
+		return r, true
+	}}
+}
+
+// Distinct 去除重复的元素，保留第一次出现的顺序
+func Distinct[T comparable](s Stream[T]) Stream[T] {
+	seen := make(map[T]struct{})
+	return s.Filter(func(v T) bool {
+		if _, ok := seen[v]; ok {
+			return false
+		}
+		seen[v] = struct{}{}
+		return true
+	})
+}
+
+// Reduce 从初始值 start 开始，依次用 fn 累积所有元素
+func Reduce[T, R any](s Stream[T], start R, fn func(R, T) R) R {
+	acc := start
+	s.Each(func(v T) bool {
+		acc = fn(acc, v)
+		return true
+	})
+	return acc
+}
@@ -0,0 +1,179 @@
+package stream
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func isEven(n int) bool { return n%2 == 0 }
+
+func TestPipeline(t *testing.T) {
+	t.Run("链式操作", func(t *testing.T) {
+		result := From([]int{1, 2, 3, 4, 5, 6, 7, 8}).
+			Filter(isEven).
+			Map(func(n int) int { return n * n }).
+			Take(3).
+			Collect()
+		expected := []int{4, 16, 36}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Collect() = %v, 期望 %v", result, expected)
+		}
+	})
+
+	t.Run("惰性求值", func(t *testing.T) {
+		var pulled []int
+		result := Iterate(1, func(n int) int { return n + 1 }).
+			Peek(func(n int) { pulled = append(pulled, n) }).
+			Filter(isEven).
+			Take(2).
+			Collect()
+		if !reflect.DeepEqual(result, []int{2, 4}) {
+			t.Errorf("Collect() = %v, 期望 %v", result, []int{2, 4})
+		}
+		if !reflect.DeepEqual(pulled, []int{1, 2, 3, 4}) {
+			t.Errorf("拉取的元素 = %v, 期望 %v", pulled, []int{1, 2, 3, 4})
+		}
+	})
+
+	t.Run("类型转换", func(t *testing.T) {
+		result := Map(Of(1, 2, 3).Filter(func(n int) bool { return n != 2 }), strconv.Itoa).Collect()
+		expected := []string{"1", "3"}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Map() = %v, 期望 %v", result, expected)
+		}
+	})
+
+	t.Run("只能消费一次", func(t *testing.T) {
+		s := Of(1, 2, 3)
+		if first, ok := s.Next(); !ok || first != 1 {
+			t.Errorf("Next() = %v, %v, 期望 1, true", first, ok)
+		}
+		if result := s.Collect(); !reflect.DeepEqual(result, []int{2, 3}) {
+			t.Errorf("Collect() = %v, 期望 %v", result, []int{2, 3})
+		}
+		if result := s.Collect(); len(result) != 0 {
+			t.Errorf("第二次 Collect() = %v, 期望空切片", result)
+		}
+	})
+
+	t.Run("零值", func(t *testing.T) {
+		var s Stream[int]
+		if result := s.Filter(isEven).Collect(); result == nil || len(result) != 0 {
+			t.Errorf("零值 Collect() = %#v, 期望空切片", result)
+		}
+	})
+}
+
+func TestSources(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        Stream[int]
+		expected []int
+	}{
+		{"Range 递增", Range(0, 5, 2), []int{0, 2, 4}},
+		{"Range 递减", Range(3, 0, -1), []int{3, 2, 1}},
+		{"Range 为空", Range(3, 3, 1), []int{}},
+		{"Generate", Generate(func() int { return 7 }).Take(2), []int{7, 7}},
+		{"Concat", Concat(Of(1), Stream[int]{}, Of(2, 3)), []int{1, 2, 3}},
+		{"FromFunc", func() Stream[int] {
+			n := 0
+			return FromFunc(func() (int, bool) {
+				n++
+				return n, n <= 3
+			})
+		}(), []int{1, 2, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.s.Collect(); !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Collect() = %v, 期望 %v", result, tt.expected)
+			}
+		})
+	}
+
+	t.Run("FromChan", func(t *testing.T) {
+		ch := make(chan int, 3)
+		ch <- 1
+		ch <- 2
+		close(ch)
+		if result := FromChan(ch).Collect(); !reflect.DeepEqual(result, []int{1, 2}) {
+			t.Errorf("FromChan() = %v, 期望 %v", result, []int{1, 2})
+		}
+	})
+
+	t.Run("Range 步长为 0 时 panic", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("Range(0, 1, 0) 期望 panic")
+			}
+		}()
+		Range(0, 1, 0)
+	})
+}
+
+func TestOperations(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        Stream[int]
+		expected []int
+	}{
+		{"Skip", Of(1, 2, 3, 4).Skip(2), []int{3, 4}},
+		{"Skip 超过长度", Of(1, 2).Skip(5), []int{}},
+		{"Take 超过长度", Of(1, 2).Take(5), []int{1, 2}},
+		{"Take 0", Of(1, 2).Take(0), []int{}},
+		{"TakeWhile", Of(2, 4, 5, 6).TakeWhile(isEven), []int{2, 4}},
+		{"DropWhile", Of(2, 4, 5, 6).DropWhile(isEven), []int{5, 6}},
+		{"FlatMap", FlatMap(Of(1, 0, 2), func(n int) []int {
+			result := make([]int, n)
+			for i := range result {
+				result[i] = n
+			}
+			return result
+		}), []int{1, 2, 2}},
+		{"Distinct", Distinct(Of(3, 1, 3, 2, 1)), []int{3, 1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.s.Collect(); !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Collect() = %v, 期望 %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTerminals(t *testing.T) {
+	if result := Of(1, 2, 3).Count(); result != 3 {
+		t.Errorf("Count() = %v, 期望 %v", result, 3)
+	}
+	if result := Reduce(Of(1, 2, 3, 4), "", func(acc string, n int) string { return acc + strconv.Itoa(n) }); result != "1234" {
+		t.Errorf("Reduce() = %v, 期望 %v", result, "1234")
+	}
+	if v, ok := Of(1, 3, 4, 6).Find(isEven); !ok || v != 4 {
+		t.Errorf("Find() = %v, %v, 期望 4, true", v, ok)
+	}
+	if _, ok := Of(1, 3).First(); !ok {
+		t.Errorf("First() ok = false, 期望 true")
+	}
+	if _, ok := Of[int]().First(); ok {
+		t.Errorf("空 Stream First() ok = true, 期望 false")
+	}
+	if !Of(1, 2).Any(isEven) || Of(1, 3).Any(isEven) {
+		t.Errorf("Any() 结果错误")
+	}
+	if !Of(2, 4).All(isEven) || Of(2, 3).All(isEven) || !Of[int]().All(isEven) {
+		t.Errorf("All() 结果错误")
+	}
+
+	t.Run("Each 提前停止", func(t *testing.T) {
+		var pulled int
+		var seen []int
+		Range(0, 100, 1).Peek(func(int) { pulled++ }).Each(func(n int) bool {
+			seen = append(seen, n)
+			return n < 2
+		})
+		if !reflect.DeepEqual(seen, []int{0, 1, 2}) || pulled != 3 {
+			t.Errorf("Each() 处理了 %v, 拉取了 %v 个, 期望 [0 1 2] 和 3 个", seen, pulled)
+		}
+	})
+}
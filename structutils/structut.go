@@ -0,0 +1,346 @@
+package structutils
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+var (
+	// ErrNotStructPtr 目标不是非 nil 的结构体指针
+	ErrNotStructPtr = errors.New("structutils: dst must be a non-nil pointer to struct")
+	// ErrNotStruct 源不是结构体或结构体指针
+	ErrNotStruct = errors.New("structutils: src must be a struct or pointer to struct")
+	// ErrIncompatibleField 同名字段的类型无法转换
+	ErrIncompatibleField = errors.New("structutils: incompatible field types")
+)
+
+// converterKey 转换函数的源类型和目标类型
+type converterKey struct {
+	src, dst reflect.Type
+}
+
+// copyConfig CopyFields 和 MergeNonZero 的配置
+type copyConfig struct {
+	tag        string
+	converters map[converterKey]func(reflect.Value) (reflect.Value, error)
+	skipZero   bool
+}
+
+// CopyOption CopyFields 和 MergeNonZero 的可选配置项
+type CopyOption func(*copyConfig)
+
+// WithTagName 设置用于匹配字段的结构体标签，默认为 "copy"
+// 标签值（逗号之前的部分）作为字段的匹配名称，为 "-" 时忽略该字段，没有标签时使用字段名
+func WithTagName(tag string) CopyOption {
+	return func(c *copyConfig) {
+		c.tag = tag
+	}
+}
+
+// WithConverter 注册从 S 到 D 的类型转换函数，匹配字段的类型分别为 S 和 D 时使用，优先于内置的转换规则
+// 转换函数返回的错误会以字段名包装后返回
+func WithConverter[S, D any](fn func(S) (D, error)) CopyOption {
+	key := converterKey{reflect.TypeOf((*S)(nil)).Elem(), reflect.TypeOf((*D)(nil)).Elem()}
+	return func(c *copyConfig) {
+		if c.converters == nil {
+			c.converters = make(map[converterKey]func(reflect.Value) (reflect.Value, error))
+		}
+		c.converters[key] = func(v reflect.Value) (reflect.Value, error) {
+			d, err := fn(v.Interface().(S))
+			return reflect.ValueOf(&d).Elem(), err
+		}
+	}
+}
+
+// CopyFields 将 src 中的字段按名称复制到 dst 中同名的字段，名称由 WithTagName 指定的标签或字段名决定
+// src 为结构体或结构体指针，dst 必须为非 nil 的结构体指针；只处理导出字段，嵌入结构体的字段按提升后的名称匹配
+// 类型不同时依次尝试：WithConverter 注册的函数、直接赋值、数值或字符串等同类类型之间的转换、
+// *T 与 T 之间的指针解引用和取地址、不同结构体类型之间按字段递归复制；
+// 数值转换不会截断，值超出目标类型范围或浮点数带有小数部分时视为无法转换；
+// 无法转换的字段不会被复制，并以 ErrIncompatibleField 报告
+// 所有错误以 errors.Join 合并返回，其余字段仍会被复制
+func CopyFields(dst, src any, opts ...CopyOption) error {
+	cfg := copyConfig{tag: "copy"}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return copyFields(dst, src, &cfg)
+}
+
+// MergeNonZero 将 src 中的非零值字段复制到 dst，零值字段保留 dst 原有的值，用于 PATCH 式的部分更新
+// src 中 nil 指针字段被视为未设置，非 nil 指针会解引用后写入 dst 的非指针字段；匹配和转换规则与 CopyFields 相同
+// 非零的嵌套结构体字段作为整体写入，不会逐字段合并
+func MergeNonZero(dst, src any, opts ...CopyOption) error {
+	cfg := copyConfig{tag: "copy", skipZero: true}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return copyFields(dst, src, &cfg)
+}
+
+// copyFields CopyFields 和 MergeNonZero 的实现
+func copyFields(dst, src any, cfg *copyConfig) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return ErrNotStructPtr
+	}
+	dv = dv.Elem()
+	sv := reflect.ValueOf(src)
+	if sv.Kind() == reflect.Pointer && !sv.IsNil() {
+		sv = sv.Elem()
+	}
+	if sv.Kind() != reflect.Struct {
+		return ErrNotStruct
+	}
+
+	dstFields := make(map[string]reflect.StructField)
+	for _, f := range reflect.VisibleFields(dv.Type()) {
+		if name, ok := fieldName(f, cfg.tag); ok {
+			dstFields[name] = f
+		}
+	}
+
+	var errs []error
+	for _, sf := range reflect.VisibleFields(sv.Type()) {
+		name, ok := fieldName(sf, cfg.tag)
+		if !ok {
+			continue
+		}
+		df, ok := dstFields[name]
+		if !ok {
+			continue
+		}
+		from, err := sv.FieldByIndexErr(sf.Index)
+		if err != nil {
+			// 经过 nil 的嵌入指针，视为未设置
+			continue
+		}
+		if cfg.skipZero && from.IsZero() {
+			continue
+		}
+		to, err := dv.FieldByIndexErr(df.Index)
+		if err != nil || !to.CanSet() {
+			continue
+		}
+		converted, err := convert(from, to.Type(), cfg)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("structutils: field %s: %w", name, err))
+			continue
+		}
+		to.Set(converted)
+	}
+	return errors.Join(errs...)
+}
+
+// fieldName 返回字段用于匹配的名称，未导出、嵌入的结构体本身或被标签忽略的字段返回 false
+func fieldName(f reflect.StructField, tag string) (string, bool) {
+	if !f.IsExported() || (f.Anonymous && indirectType(f.Type).Kind() == reflect.Struct) {
+		return "", false
+	}
+	name, _, _ := strings.Cut(f.Tag.Get(tag), ",")
+	switch name {
+	case "-":
+		return "", false
+	case "":
+		return f.Name, true
+	}
+	return name, true
+}
+
+// indirectType 返回指针指向的类型，非指针类型原样返回
+func indirectType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+	return t
+}
+
+// convert 将 v 转换为类型 to
+func convert(v reflect.Value, to reflect.Type, cfg *copyConfig) (reflect.Value, error) {
+	if fn, ok := cfg.converters[converterKey{v.Type(), to}]; ok {
+		return fn(v)
+	}
+	if v.Type().AssignableTo(to) {
+		return v, nil
+	}
+	if sameKindClass(v.Type(), to) && v.Type().ConvertibleTo(to) {
+		if isNumber(v.Type()) {
+			return convertNumber(v, to)
+		}
+		return v.Convert(to), nil
+	}
+	// *T -> T：nil 指针转换为零值
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Zero(to), nil
+		}
+		return convert(v.Elem(), to, cfg)
+	}
+	// T -> *T
+	if to.Kind() == reflect.Pointer {
+		elem, err := convert(v, to.Elem(), cfg)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		p := reflect.New(to.Elem())
+		p.Elem().Set(elem)
+		return p, nil
+	}
+	// 不同的结构体类型之间按字段递归复制
+	if v.Kind() == reflect.Struct && to.Kind() == reflect.Struct {
+		nested := *cfg
+		nested.skipZero = false
+		p := reflect.New(to)
+		if err := copyFields(p.Interface(), v.Interface(), &nested); err != nil {
+			return reflect.Value{}, err
+		}
+		return p.Elem(), nil
+	}
+	return reflect.Value{}, fmt.Errorf("%w: %s to %s", ErrIncompatibleField, v.Type(), to)
+}
+
+// isNumber 判断 t 是否为整数或浮点数类型
+func isNumber(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// convertNumber 在数值类型之间转换，不允许截断：
+// 超出目标类型范围、负数转换为无符号整数、带有小数部分（或为 NaN、Inf）的浮点数转换为整数时返回 ErrIncompatibleField
+func convertNumber(v reflect.Value, to reflect.Type) (reflect.Value, error) {
+	target := reflect.Zero(to)
+	ok := true
+	switch {
+	case v.CanInt() && target.CanInt():
+		ok = !target.OverflowInt(v.Int())
+	case v.CanInt() && target.CanUint():
+		ok = v.Int() >= 0 && !target.OverflowUint(uint64(v.Int()))
+	case v.CanUint() && target.CanInt():
+		ok = v.Uint() <= math.MaxInt64 && !target.OverflowInt(int64(v.Uint()))
+	case v.CanUint() && target.CanUint():
+		ok = !target.OverflowUint(v.Uint())
+	case v.CanFloat() && target.CanFloat():
+		ok = !target.OverflowFloat(v.Float())
+	case v.CanFloat():
+		f := v.Float()
+		if f != math.Trunc(f) || math.IsInf(f, 0) {
+			return reflect.Value{}, fmt.Errorf("%w: %v (%s) is not an integer for %s", ErrIncompatibleField, f, v.Type(), to)
+		}
+		if target.CanInt() {
+			ok = f >= math.MinInt64 && f < math.MaxInt64 && !target.OverflowInt(int64(f))
+		} else {
+			ok = f >= 0 && f < math.MaxUint64 && !target.OverflowUint(uint64(f))
+		}
+	}
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("%w: %v (%s) overflows %s", ErrIncompatibleField, v.Interface(), v.Type(), to)
+	}
+	return v.Convert(to), nil
+}
+
+// sameKindClass 判断两个类型是否属于可以安全转换的同一类：数值、字符串、布尔，或种类相同的复合类型
+// 用于排除 int 到 string 这类 Go 允许但语义不同的转换
+func sameKindClass(a, b reflect.Type) bool {
+	class := func(t reflect.Type) int {
+		if isNumber(t) {
+			return 1
+		}
+		switch t.Kind() {
+		case reflect.String:
+			return 2
+		case reflect.Bool:
+			return 3
+		case reflect.Slice, reflect.Map, reflect.Struct, reflect.Array:
+			return 4
+		}
+		return 0
+	}
+	ca := class(a)
+	return ca != 0 && ca == class(b) && (ca != 4 || a.Kind() == b.Kind())
+}
+
+// FieldChange Diff 找到的一个字段差异
+type FieldChange struct {
+	// Field 字段路径，嵌套结构体的字段以 "." 连接，如 "Address.City"
+	Field string
+	Old   any
+	New   any
+}
+
+// String 返回 "Field: old -> new" 格式的描述
+func (c FieldChange) String() string {
+	return fmt.Sprintf("%s: %v -> %v", c.Field, c.Old, c.New)
+}
+
+// Diff 比较同一结构体类型的两个值 a 和 b（或其指针），按字段声明顺序返回所有不同的导出字段
+// 嵌套的结构体字段会递归比较；没有导出字段或带有 Equal 方法的结构体（如 time.Time）作为整体比较；
+// 其他字段使用 reflect.DeepEqual 比较；带有 diff:"-" 标签的字段被忽略
+// a 和 b 不是同一结构体类型时 panic
+func Diff(a, b any) []FieldChange {
+	av, bv := reflect.ValueOf(a), reflect.ValueOf(b)
+	if av.Kind() == reflect.Pointer {
+		av = av.Elem()
+	}
+	if bv.Kind() == reflect.Pointer {
+		bv = bv.Elem()
+	}
+	if av.Kind() != reflect.Struct || !bv.IsValid() || av.Type() != bv.Type() {
+		panic(fmt.Sprintf("structutils: Diff of %T and %T", a, b))
+	}
+	var changes []FieldChange
+	diffStruct(av, bv, "", &changes)
+	return changes
+}
+
+// diffStruct 递归比较两个同类型的结构体
+func diffStruct(a, b reflect.Value, prefix string, changes *[]FieldChange) {
+	t := a.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() || f.Tag.Get("diff") == "-" {
+			continue
+		}
+		name := prefix + f.Name
+		fa, fb := a.Field(i), b.Field(i)
+		if f.Type.Kind() == reflect.Struct && hasExportedFields(f.Type) && !hasEqualMethod(f.Type) {
+			diffStruct(fa, fb, name+".", changes)
+			continue
+		}
+		if !valuesEqual(fa, fb) {
+			*changes = append(*changes, FieldChange{Field: name, Old: fa.Interface(), New: fb.Interface()})
+		}
+	}
+}
+
+// valuesEqual 比较两个同类型的值，优先使用类型的 Equal 方法
+func valuesEqual(a, b reflect.Value) bool {
+	if hasEqualMethod(a.Type()) {
+		return a.MethodByName("Equal").Call([]reflect.Value{b})[0].Bool()
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+// hasEqualMethod 判断 t 是否有 Equal(t) bool 方法
+func hasEqualMethod(t reflect.Type) bool {
+	m, ok := t.MethodByName("Equal")
+	return ok && m.Type.NumIn() == 2 && m.Type.In(1) == t &&
+		m.Type.NumOut() == 1 && m.Type.Out(0).Kind() == reflect.Bool
+}
+
+// hasExportedFields 判断结构体类型是否有导出字段
+func hasExportedFields(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
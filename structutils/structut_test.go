@@ -0,0 +1,287 @@
+package structutils
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type address struct {
+	City   string
+	Street string
+}
+
+type Base struct {
+	ID      int64
+	Created time.Time
+}
+
+type userModel struct {
+	Base
+	Name     string
+	Email    string
+	Age      int32
+	Nickname *string
+	Role     string
+	Address  address
+	Secret   string
+	score    int
+}
+
+type userDTO struct {
+	ID       int
+	Name     string `copy:"Name"`
+	Mail     string `copy:"Email"`
+	Age      float64
+	Nickname string
+	Role     *string
+	Address  addressDTO
+	Secret   string `copy:"-"`
+	Score    int
+}
+
+type addressDTO struct {
+	City string
+}
+
+func TestCopyFields(t *testing.T) {
+	nick := "al"
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	src := userModel{
+		Base:     Base{ID: 7, Created: created},
+		Name:     "alice",
+		Email:    "a@example.com",
+		Age:      30,
+		Nickname: &nick,
+		Role:     "admin",
+		Address:  address{City: "Paris", Street: "Rue"},
+		Secret:   "s3cret",
+		score:    99,
+	}
+
+	t.Run("模型到 DTO", func(t *testing.T) {
+		var dst userDTO
+		if err := CopyFields(&dst, &src); err != nil {
+			t.Fatalf("CopyFields() 错误 = %v", err)
+		}
+		role := "admin"
+		expected := userDTO{
+			ID:       7,
+			Name:     "alice",
+			Mail:     "a@example.com",
+			Age:      30,
+			Nickname: "al",
+			Role:     &role,
+			Address:  addressDTO{City: "Paris"},
+		}
+		if !reflect.DeepEqual(dst, expected) {
+			t.Errorf("CopyFields() = %+v, 期望 %+v", dst, expected)
+		}
+	})
+
+	t.Run("DTO 到模型", func(t *testing.T) {
+		role := "viewer"
+		var dst userModel
+		err := CopyFields(&dst, userDTO{ID: 3, Name: "bob", Mail: "b@example.com", Age: 41, Role: &role})
+		if err != nil {
+			t.Fatalf("CopyFields() 错误 = %v", err)
+		}
+		if dst.ID != 3 || dst.Email != "b@example.com" || dst.Age != 41 || dst.Role != "viewer" || dst.Nickname == nil || *dst.Nickname != "" {
+			t.Errorf("CopyFields() = %+v", dst)
+		}
+	})
+
+	t.Run("自定义标签", func(t *testing.T) {
+		type in struct {
+			UserName string `json:"user_name"`
+		}
+		type out struct {
+			Name string `json:"user_name"`
+		}
+		var dst out
+		if err := CopyFields(&dst, in{UserName: "carol"}, WithTagName("json")); err != nil || dst.Name != "carol" {
+			t.Errorf("CopyFields() = %+v, %v, 期望 Name 为 carol", dst, err)
+		}
+	})
+
+	t.Run("类型转换函数", func(t *testing.T) {
+		type in struct {
+			Created time.Time
+			Count   string
+		}
+		type out struct {
+			Created string
+			Count   int
+		}
+		var dst out
+		err := CopyFields(&dst, in{Created: created, Count: "12"},
+			WithConverter(func(t time.Time) (string, error) { return t.Format(time.DateOnly), nil }),
+			WithConverter(strconv.Atoi))
+		if err != nil || dst.Created != "2024-01-01" || dst.Count != 12 {
+			t.Errorf("CopyFields() = %+v, %v", dst, err)
+		}
+
+		err = CopyFields(&dst, in{Count: "x"}, WithConverter(strconv.Atoi))
+		var numErr *strconv.NumError
+		if !errors.As(err, &numErr) {
+			t.Errorf("CopyFields() 错误 = %v, 期望包含转换函数的错误", err)
+		}
+	})
+
+	t.Run("不兼容的字段", func(t *testing.T) {
+		type in struct {
+			A int
+			B string
+		}
+		type out struct {
+			A string
+			B string
+		}
+		var dst out
+		err := CopyFields(&dst, in{A: 65, B: "ok"})
+		if !errors.Is(err, ErrIncompatibleField) {
+			t.Errorf("CopyFields() 错误 = %v, 期望 %v", err, ErrIncompatibleField)
+		}
+		if dst.A != "" || dst.B != "ok" {
+			t.Errorf("CopyFields() = %+v, 期望 A 未被复制而 B 被复制", dst)
+		}
+	})
+
+	t.Run("数值转换", func(t *testing.T) {
+		type in struct {
+			Small int64
+			Count float64
+			Size  int
+			Ratio float64
+		}
+		type out struct {
+			Small int8
+			Count int
+			Size  uint16
+			Ratio float32
+		}
+		var dst out
+		if err := CopyFields(&dst, in{Small: -128, Count: 3, Size: 65535, Ratio: 0.5}); err != nil {
+			t.Fatalf("CopyFields() 错误 = %v", err)
+		}
+		if expected := (out{Small: -128, Count: 3, Size: 65535, Ratio: 0.5}); dst != expected {
+			t.Errorf("CopyFields() = %+v, 期望 %+v", dst, expected)
+		}
+
+		tests := []struct {
+			name string
+			src  in
+		}{
+			{"整数溢出", in{Small: 300}},
+			{"浮点数有小数部分", in{Count: 3.7}},
+			{"浮点数超出整数范围", in{Count: 1e20}},
+			{"NaN 转换为整数", in{Count: math.NaN()}},
+			{"负数转换为无符号整数", in{Size: -1}},
+			{"无符号整数溢出", in{Size: 65536}},
+			{"浮点数溢出", in{Ratio: math.MaxFloat64}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				var dst out
+				err := CopyFields(&dst, tt.src)
+				if !errors.Is(err, ErrIncompatibleField) {
+					t.Errorf("CopyFields() 错误 = %v, 期望 %v", err, ErrIncompatibleField)
+				}
+				if dst != (out{}) {
+					t.Errorf("CopyFields() = %+v, 期望没有字段被截断后复制", dst)
+				}
+			})
+		}
+	})
+
+	t.Run("参数错误", func(t *testing.T) {
+		var dst userDTO
+		if err := CopyFields(dst, src); !errors.Is(err, ErrNotStructPtr) {
+			t.Errorf("CopyFields() 非指针 dst 错误 = %v, 期望 %v", err, ErrNotStructPtr)
+		}
+		if err := CopyFields(&dst, 42); !errors.Is(err, ErrNotStruct) {
+			t.Errorf("CopyFields() 非结构体 src 错误 = %v, 期望 %v", err, ErrNotStruct)
+		}
+	})
+}
+
+func TestMergeNonZero(t *testing.T) {
+	type user struct {
+		Name   string
+		Email  string
+		Age    int
+		Active bool
+	}
+	type patch struct {
+		Name   *string
+		Email  string
+		Age    *int
+		Active *bool
+	}
+
+	name, inactive := "alice2", false
+	dst := user{Name: "alice", Email: "a@example.com", Age: 30, Active: true}
+	if err := MergeNonZero(&dst, patch{Name: &name, Active: &inactive}); err != nil {
+		t.Fatalf("MergeNonZero() 错误 = %v", err)
+	}
+	expected := user{Name: "alice2", Email: "a@example.com", Age: 30, Active: false}
+	if dst != expected {
+		t.Errorf("MergeNonZero() = %+v, 期望 %+v", dst, expected)
+	}
+
+	if err := MergeNonZero(&dst, user{Email: "new@example.com"}); err != nil || dst.Email != "new@example.com" || dst.Name != "alice2" {
+		t.Errorf("MergeNonZero() 同类型 = %+v, %v", dst, err)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	t1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	type profile struct {
+		Tags    []string
+		Updated time.Time
+		Address address
+		Hash    string `diff:"-"`
+		private int
+	}
+	a := profile{Tags: []string{"a"}, Updated: t1, Address: address{City: "Paris", Street: "Rue"}, Hash: "x", private: 1}
+
+	t.Run("无差异", func(t *testing.T) {
+		b := a
+		b.Updated = t1.In(time.FixedZone("UTC+8", 8*3600))
+		b.Hash, b.private = "y", 2
+		if changes := Diff(a, &b); len(changes) != 0 {
+			t.Errorf("Diff() = %v, 期望无差异", changes)
+		}
+	})
+
+	t.Run("字段差异", func(t *testing.T) {
+		b := a
+		b.Tags = []string{"a", "b"}
+		b.Updated = t1.Add(time.Hour)
+		b.Address.City = "Lyon"
+		changes := Diff(&a, &b)
+		expected := []FieldChange{
+			{Field: "Tags", Old: []string{"a"}, New: []string{"a", "b"}},
+			{Field: "Updated", Old: t1, New: t1.Add(time.Hour)},
+			{Field: "Address.City", Old: "Paris", New: "Lyon"},
+		}
+		if !reflect.DeepEqual(changes, expected) {
+			t.Errorf("Diff() = %v, 期望 %v", changes, expected)
+		}
+		if s := changes[2].String(); s != "Address.City: Paris -> Lyon" {
+			t.Errorf("FieldChange.String() = %q, 期望 %q", s, "Address.City: Paris -> Lyon")
+		}
+	})
+
+	t.Run("类型不同时 panic", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("Diff() 类型不同期望 panic")
+			}
+		}()
+		Diff(a, address{})
+	})
+}
@@ -0,0 +1,847 @@
+package strutils
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jiu-u/gogout/randutils"
+)
+
+// Charset 随机字符串使用的字符集
+type Charset string
+
+// 预定义的常用字符集
+const (
+	// Numeric 数字
+	Numeric Charset = "0123456789"
+	// LowerLetters 小写字母
+	LowerLetters Charset = "abcdefghijklmnopqrstuvwxyz"
+	// UpperLetters 大写字母
+	UpperLetters Charset = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	// Letters 大小写字母
+	Letters = UpperLetters + LowerLetters
+	// Alphanumeric 大小写字母和数字
+	Alphanumeric = Letters + Numeric
+	// Hex 小写十六进制字符
+	Hex Charset = "0123456789abcdef"
+	// URLSafe 可直接用于 URL 的字符（RFC 3986 中的非保留字符，不含 '.' 和 '~'）
+	URLSafe = Alphanumeric + "-_"
+)
+
+// Source 随机数来源，与 randutils.Source 相同
+// *rand.Rand 满足该接口，测试中可以注入固定种子的实现，如 randutils.NewSource
+type Source = randutils.Source
+
+// RandomString 使用默认随机源从 charset 中生成长度为 n 的随机字符串
+// 长度按 rune 计算；n <= 0 或 charset 为空时返回空字符串
+// 注意：结果不可用于安全敏感场景，请使用 RandomStringSecure
+func RandomString(n int, charset Charset) string {
+	return RandomStringFrom(randutils.Default(), n, charset)
+}
+
+// RandomStringFrom 使用指定的随机源 src 生成随机字符串，便于在测试中得到可复现的结果
+func RandomStringFrom(src Source, n int, charset Charset) string {
+	chars := []rune(string(charset))
+	if n <= 0 || len(chars) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.Grow(n)
+	for i := 0; i < n; i++ {
+		sb.WriteRune(chars[src.Intn(len(chars))])
+	}
+	return sb.String()
+}
+
+// RandomStringSecure 使用 crypto/rand 生成随机字符串，适用于令牌、密钥等场景
+// 字符的选取是均匀分布的，不存在取模偏差
+func RandomStringSecure(n int, charset Charset) (string, error) {
+	chars := []rune(string(charset))
+	if n <= 0 || len(chars) == 0 {
+		return "", nil
+	}
+
+	var sb strings.Builder
+	sb.Grow(n)
+	for i := 0; i < n; i++ {
+		idx, err := randutils.IntnSecure(len(chars))
+		if err != nil {
+			return "", err
+		}
+		sb.WriteRune(chars[idx])
+	}
+	return sb.String(), nil
+}
+
+// slugConfig Slugify 的配置
+type slugConfig struct {
+	separator    string
+	maxLength    int
+	allowedChars string
+}
+
+// SlugOption Slugify 的可选配置项
+type SlugOption func(*slugConfig)
+
+// WithSeparator 设置单词之间的分隔符，默认为 "-"
+func WithSeparator(sep string) SlugOption {
+	return func(c *slugConfig) {
+		c.separator = sep
+	}
+}
+
+// WithMaxLength 限制结果的最大长度（按 rune 计算），截断后会去掉末尾的分隔符，以及被截断的不完整的分隔符
+// maxLength <= 0 表示不限制
+func WithMaxLength(maxLength int) SlugOption {
+	return func(c *slugConfig) {
+		c.maxLength = maxLength
+	}
+}
+
+// WithAllowedChars 除字母和数字外额外保留的字符，例如 "._"
+func WithAllowedChars(chars string) SlugOption {
+	return func(c *slugConfig) {
+		c.allowedChars = chars
+	}
+}
+
+// transliterations 常见带重音符号或特殊拉丁字符到 ASCII 的转写表
+var transliterations = map[rune]string{
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'ā': "a", 'ă': "a", 'ą': "a",
+	'À': "a", 'Á': "a", 'Â': "a", 'Ã': "a", 'Ä': "a", 'Å': "a", 'Ā': "a", 'Ă': "a", 'Ą': "a",
+	'æ': "ae", 'Æ': "ae",
+	'ç': "c", 'ć': "c", 'ĉ': "c", 'ċ': "c", 'č': "c",
+	'Ç': "c", 'Ć': "c", 'Ĉ': "c", 'Ċ': "c", 'Č': "c",
+	'ď': "d", 'đ': "d", 'ð': "d", 'Ď': "d", 'Đ': "d", 'Ð': "d",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e", 'ē': "e", 'ĕ': "e", 'ė': "e", 'ę': "e", 'ě': "e",
+	'È': "e", 'É': "e", 'Ê': "e", 'Ë': "e", 'Ē': "e", 'Ĕ': "e", 'Ė': "e", 'Ę': "e", 'Ě': "e",
+	'ĝ': "g", 'ğ': "g", 'ġ': "g", 'ģ': "g", 'Ĝ': "g", 'Ğ': "g", 'Ġ': "g", 'Ģ': "g",
+	'ĥ': "h", 'ħ': "h", 'Ĥ': "h", 'Ħ': "h",
+	'ì': "i", 'í': "i", 'î': "i", 'ï': "i", 'ĩ': "i", 'ī': "i", 'ĭ': "i", 'į': "i", 'ı': "i",
+	'Ì': "i", 'Í': "i", 'Î': "i", 'Ï': "i", 'Ĩ': "i", 'Ī': "i", 'Ĭ': "i", 'Į': "i", 'İ': "i",
+	'ĵ': "j", 'Ĵ': "j",
+	'ķ': "k", 'Ķ': "k",
+	'ĺ': "l", 'ļ': "l", 'ľ': "l", 'ŀ': "l", 'ł': "l", 'Ĺ': "l", 'Ļ': "l", 'Ľ': "l", 'Ŀ': "l", 'Ł': "l",
+	'ñ': "n", 'ń': "n", 'ņ': "n", 'ň': "n", 'Ñ': "n", 'Ń': "n", 'Ņ': "n", 'Ň': "n",
+	'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o", 'ō': "o", 'ŏ': "o", 'ő': "o",
+	'Ò': "o", 'Ó': "o", 'Ô': "o", 'Õ': "o", 'Ö': "o", 'Ø': "o", 'Ō': "o", 'Ŏ': "o", 'Ő': "o",
+	'œ': "oe", 'Œ': "oe",
+	'ŕ': "r", 'ŗ': "r", 'ř': "r", 'Ŕ': "r", 'Ŗ': "r", 'Ř': "r",
+	'ś': "s", 'ŝ': "s", 'ş': "s", 'š': "s", 'Ś': "s", 'Ŝ': "s", 'Ş': "s", 'Š': "s",
+	'ß': "ss",
+	'ţ': "t", 'ť': "t", 'ŧ': "t", 'Ţ': "t", 'Ť': "t", 'Ŧ': "t",
+	'þ': "th", 'Þ': "th",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "u", 'ũ': "u", 'ū': "u", 'ŭ': "u", 'ů': "u", 'ű': "u", 'ų': "u",
+	'Ù': "u", 'Ú': "u", 'Û': "u", 'Ü': "u", 'Ũ': "u", 'Ū': "u", 'Ŭ': "u", 'Ů': "u", 'Ű': "u", 'Ų': "u",
+	'ŵ': "w", 'Ŵ': "w",
+	'ý': "y", 'ÿ': "y", 'ŷ': "y", 'Ý': "y", 'Ÿ': "y", 'Ŷ': "y",
+	'ź': "z", 'ż': "z", 'ž': "z", 'Ź': "z", 'Ż': "z", 'Ž': "z",
+	'&': "and",
+}
+
+// Slugify 将字符串转换为 URL 安全的标识符，适用于生成 URL slug 或文件名
+// 处理步骤：转写常见的重音字符（如 é -> e、ß -> ss），去掉组合用附加符号，
+// 转为小写，其余非字母数字字符合并为单个分隔符，并去掉首尾分隔符
+// 例如 "Héllo, Wörld!" -> "hello-world"
+func Slugify(s string, opts ...SlugOption) string {
+	cfg := slugConfig{separator: "-"}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(s))
+	pendingSep := false
+	sepLen := utf8.RuneCountInString(cfg.separator)
+	var (
+		runes     int   // 已写入的 rune 数
+		sepStarts []int // 每个分隔符起始位置的 rune 下标，用于截断
+	)
+	write := func(r rune) {
+		if pendingSep && sb.Len() > 0 {
+			sepStarts = append(sepStarts, runes)
+			sb.WriteString(cfg.separator)
+			runes += sepLen
+		}
+		pendingSep = false
+		sb.WriteRune(r)
+		runes++
+	}
+
+	for _, r := range s {
+		if repl, ok := transliterations[r]; ok {
+			for _, c := range repl {
+				write(c)
+			}
+			continue
+		}
+		// 分解形式中的附加符号（如 e + U+0301）直接丢弃
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		lower := unicode.ToLower(r)
+		switch {
+		case lower >= 'a' && lower <= 'z', lower >= '0' && lower <= '9':
+			write(lower)
+		case cfg.allowedChars != "" && strings.ContainsRune(cfg.allowedChars, r):
+			write(r)
+		default:
+			pendingSep = true
+		}
+	}
+
+	result := sb.String()
+	if cfg.maxLength > 0 && runes > cfg.maxLength {
+		cut := cfg.maxLength
+		// 截断位置落在分隔符中间时，去掉剩下的半个分隔符
+		for _, start := range sepStarts {
+			if start < cut && cut < start+sepLen {
+				cut = start
+				break
+			}
+		}
+		result = string([]rune(result)[:cut])
+		for cfg.separator != "" && strings.HasSuffix(result, cfg.separator) {
+			result = strings.TrimSuffix(result, cfg.separator)
+		}
+	}
+	return result
+}
+
+// WordWrap 按单词边界将字符串折行，使每行宽度不超过 width（按 rune 计算）
+// 原有的换行会被保留，行内连续的空白会被合并为一个空格
+// 长度超过 width 的单词不会被拆分，而是独占一行
+// width <= 0 时原样返回
+func WordWrap(s string, width int) string {
+	if width <= 0 || s == "" {
+		return s
+	}
+
+	lines := strings.Split(s, "\n")
+	var sb strings.Builder
+	sb.Grow(len(s))
+	for i, line := range lines {
+		if i > 0 {
+			sb.WriteByte('\n')
+		}
+		lineLen := 0
+		for j, word := range strings.Fields(line) {
+			wordLen := utf8.RuneCountInString(word)
+			if j > 0 {
+				if lineLen+1+wordLen > width {
+					sb.WriteByte('\n')
+					lineLen = 0
+				} else {
+					sb.WriteByte(' ')
+					lineLen++
+				}
+			}
+			sb.WriteString(word)
+			lineLen += wordLen
+		}
+	}
+	return sb.String()
+}
+
+// Indent 在多行字符串的每个非空行前添加 prefix
+// 空行（包括只包含空白的行）保持不变，避免产生行尾空白
+func Indent(s, prefix string) string {
+	if s == "" || prefix == "" {
+		return s
+	}
+
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ErrMissingKey 模板中的占位符在 vars 中没有对应的值
+var ErrMissingKey = errors.New("strutils: missing value for placeholder")
+
+// MissingKeyPolicy 占位符缺少对应值时的处理策略
+type MissingKeyPolicy int
+
+const (
+	// MissingKeyError 返回 ErrMissingKey 错误（默认）
+	MissingKeyError MissingKeyPolicy = iota
+	// MissingKeyKeep 保留原始占位符文本
+	MissingKeyKeep
+	// MissingKeyEmpty 替换为空字符串
+	MissingKeyEmpty
+)
+
+// substituteConfig Substitute 的配置
+type substituteConfig struct {
+	missingKey MissingKeyPolicy
+}
+
+// SubstituteOption Substitute 的可选配置项
+type SubstituteOption func(*substituteConfig)
+
+// WithMissingKey 设置占位符缺少对应值时的处理策略
+func WithMissingKey(policy MissingKeyPolicy) SubstituteOption {
+	return func(c *substituteConfig) {
+		c.missingKey = policy
+	}
+}
+
+// Substitute 将模板中的 ${name} 或 {name} 占位符替换为 vars 中对应的值
+// 占位符名称只能包含字母、数字以及 '_'、'.'、'-'，不符合该规则的花括号（如 JSON 片段、
+// 未闭合的 '{'）按原样输出，不会报错或 panic
+// 缺少对应值时的行为由 WithMissingKey 控制，默认返回 ErrMissingKey
+func Substitute(template string, vars map[string]string, opts ...SubstituteOption) (string, error) {
+	cfg := substituteConfig{missingKey: MissingKeyError}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(template))
+	for i := 0; i < len(template); {
+		start := i
+		switch {
+		case template[i] == '$' && i+1 < len(template) && template[i+1] == '{':
+			i += 2
+		case template[i] == '{':
+			i++
+		default:
+			sb.WriteByte(template[i])
+			i++
+			continue
+		}
+
+		end := strings.IndexByte(template[i:], '}')
+		if end < 0 || !isPlaceholderName(template[i:i+end]) {
+			// 不是合法的占位符，按原样输出起始字符
+			sb.WriteString(template[start:i])
+			continue
+		}
+		name := template[i : i+end]
+		i += end + 1
+
+		value, ok := vars[name]
+		if !ok {
+			switch cfg.missingKey {
+			case MissingKeyKeep:
+				value = template[start:i]
+			case MissingKeyEmpty:
+				value = ""
+			default:
+				return "", fmt.Errorf("%w: %q", ErrMissingKey, name)
+			}
+		}
+		sb.WriteString(value)
+	}
+	return sb.String(), nil
+}
+
+// isPlaceholderName 判断 name 是否为合法的占位符名称
+func isPlaceholderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '.' && r != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsAny 判断 s 是否包含 subs 中的任意一个子串
+// 注意：与 strings.ContainsAny 按字符匹配不同，这里按子串匹配；subs 为空时返回 false
+func ContainsAny(s string, subs ...string) bool {
+	for _, sub := range subs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsAll 判断 s 是否包含 subs 中的所有子串
+// subs 为空时返回 true
+func ContainsAll(s string, subs ...string) bool {
+	for _, sub := range subs {
+		if !strings.Contains(s, sub) {
+			return false
+		}
+	}
+	return true
+}
+
+// HasPrefixAny 判断 s 是否以 prefixes 中的任意一个前缀开头
+func HasPrefixAny(s string, prefixes ...string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasSuffixAny 判断 s 是否以 suffixes 中的任意一个后缀结尾
+func HasSuffixAny(s string, suffixes ...string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(s, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Mask 保留 s 开头 keepStart 个和结尾 keepEnd 个字符，其余字符替换为 maskChar
+// 按 rune 计算位置；keepStart、keepEnd 小于 0 时按 0 处理
+// 如果保留的字符数不少于总长度，则全部替换，避免敏感信息被原样输出
+func Mask(s string, keepStart, keepEnd int, maskChar rune) string {
+	runes := []rune(s)
+	keepStart = max(keepStart, 0)
+	keepEnd = max(keepEnd, 0)
+	if keepStart+keepEnd >= len(runes) {
+		keepStart, keepEnd = 0, 0
+	}
+	for i := keepStart; i < len(runes)-keepEnd; i++ {
+		runes[i] = maskChar
+	}
+	return string(runes)
+}
+
+// MaskEmail 对邮箱地址脱敏，保留用户名前两个字符、域名首字符和顶级域名
+// 例如 "john.doe@example.com" -> "jo******@e******.com"
+// 不包含 '@' 时按普通字符串处理，仅保留前两个字符
+func MaskEmail(email string) string {
+	at := strings.LastIndexByte(email, '@')
+	if at < 0 {
+		return Mask(email, 2, 0, '*')
+	}
+	local, domain := email[:at], email[at+1:]
+
+	maskedLocal := Mask(local, 2, 0, '*')
+	if utf8.RuneCountInString(local) <= 2 {
+		maskedLocal = Mask(local, 1, 0, '*')
+	}
+
+	dot := strings.LastIndexByte(domain, '.')
+	if dot < 0 {
+		return maskedLocal + "@" + Mask(domain, 1, 0, '*')
+	}
+	return maskedLocal + "@" + Mask(domain[:dot], 1, 0, '*') + domain[dot:]
+}
+
+// MaskPhone 对电话号码脱敏，保留前 3 位和后 4 位数字，中间的数字替换为 '*'
+// 空格、'-'、括号等非数字字符保持原样，例如 "138-1234-5678" -> "138-****-5678"
+// 数字位数较少时会减少保留的位数，保证至少有一位被遮盖
+func MaskPhone(phone string) string {
+	digits := 0
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			digits++
+		}
+	}
+
+	keepStart, keepEnd := 3, 4
+	if digits <= keepStart+keepEnd {
+		keepStart = 0
+	}
+	if digits <= keepEnd {
+		keepEnd = 0
+	}
+
+	runes := []rune(phone)
+	idx := 0
+	for i, r := range runes {
+		if r < '0' || r > '9' {
+			continue
+		}
+		if idx >= keepStart && idx < digits-keepEnd {
+			runes[i] = '*'
+		}
+		idx++
+	}
+	return string(runes)
+}
+
+// SplitAndTrim 按 sep 拆分字符串，去掉每一部分首尾的空白，并丢弃空结果
+// 例如 " a, b ,, c " -> ["a", "b", "c"]，常用于解析逗号分隔的配置项
+// 结果为空时返回空切片而不是 nil
+func SplitAndTrim(s, sep string) []string {
+	parts := strings.Split(s, sep)
+	result := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			result = append(result, part)
+		}
+	}
+	return result
+}
+
+// SplitNonEmpty 按 sep 拆分字符串并丢弃空字符串，但不去除各部分的空白
+// 例如 "a,,b," -> ["a", "b"]
+func SplitNonEmpty(s, sep string) []string {
+	parts := strings.Split(s, sep)
+	result := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part != "" {
+			result = append(result, part)
+		}
+	}
+	return result
+}
+
+// IsBlank 判断字符串是否为空或只包含空白字符
+func IsBlank(s string) bool {
+	return strings.TrimSpace(s) == ""
+}
+
+// DefaultIfBlank 当 s 为空或只包含空白字符时返回 def，否则返回 s
+func DefaultIfBlank(s, def string) string {
+	if IsBlank(s) {
+		return def
+	}
+	return s
+}
+
+// Coalesce 返回第一个非空白的值，全部为空白时返回空字符串
+// 适用于 参数 -> 环境变量 -> 配置文件 -> 默认值 这样的回退链
+func Coalesce(values ...string) string {
+	for _, v := range values {
+		if !IsBlank(v) {
+			return v
+		}
+	}
+	return ""
+}
+
+// Ellipsis 省略号，EllipsisMiddle 用它替换被省略的部分
+const Ellipsis = "…"
+
+// EllipsisMiddle 将超过 maxLen 的字符串中间部分替换为 "…"，保留开头和结尾
+// 适用于在终端输出中显示文件路径或 ID，例如 "very/long/path/…/file.go"
+// 长度按 rune 计算，结果（含省略号）不超过 maxLen；多余的一个字符分给开头
+// maxLen <= 0 时返回空字符串
+func EllipsisMiddle(s string, maxLen int) string {
+	if maxLen <= 0 {
+		return ""
+	}
+	runes := []rune(s)
+	if len(runes) <= maxLen {
+		return s
+	}
+
+	remaining := maxLen - 1
+	head := (remaining + 1) / 2
+	tail := remaining / 2
+	return string(runes[:head]) + Ellipsis + string(runes[len(runes)-tail:])
+}
+
+// irregularPlurals 不规则的英文复数形式
+var irregularPlurals = map[string]string{
+	"person": "people", "man": "men", "woman": "women", "child": "children",
+	"mouse": "mice", "goose": "geese", "tooth": "teeth", "foot": "feet", "ox": "oxen",
+	"leaf": "leaves", "loaf": "loaves", "wolf": "wolves", "half": "halves", "calf": "calves",
+	"shelf": "shelves", "thief": "thieves", "elf": "elves",
+	"knife": "knives", "life": "lives", "wife": "wives",
+	"potato": "potatoes", "tomato": "tomatoes", "hero": "heroes", "echo": "echoes",
+	"index": "indices", "matrix": "matrices", "vertex": "vertices",
+	"analysis": "analyses", "crisis": "crises", "criterion": "criteria",
+}
+
+// uncountableWords 单复数同形的英文单词
+var uncountableWords = map[string]struct{}{
+	"sheep": {}, "fish": {}, "deer": {}, "series": {}, "species": {},
+	"news": {}, "information": {}, "equipment": {}, "metadata": {},
+}
+
+// Pluralize 返回英文单词的复数形式，覆盖常见规则：
+// 不规则词（person -> people）、单复数同形词（sheep）、
+// 以 s/x/z/ch/sh 结尾加 es、辅音 + y 结尾变 ies，其余加 s
+// 结果会保持原单词的大小写风格（全大写或首字母大写）
+func Pluralize(word string) string {
+	if word == "" {
+		return word
+	}
+	lower := strings.ToLower(word)
+
+	var plural string
+	if p, ok := irregularPlurals[lower]; ok {
+		plural = p
+	} else if _, ok := uncountableWords[lower]; ok {
+		plural = lower
+	} else {
+		switch {
+		case HasSuffixAny(lower, "s", "x", "z", "ch", "sh"):
+			plural = lower + "es"
+		case strings.HasSuffix(lower, "y") && len(lower) > 1 && !strings.ContainsRune("aeiou", rune(lower[len(lower)-2])):
+			plural = lower[:len(lower)-1] + "ies"
+		default:
+			plural = lower + "s"
+		}
+	}
+
+	switch {
+	case word == strings.ToUpper(word) && len(word) > 1:
+		return strings.ToUpper(plural)
+	case unicode.IsUpper([]rune(word)[0]):
+		return Capitalize(plural)
+	default:
+		return plural
+	}
+}
+
+// Plural 根据数量返回带数量的单复数形式，例如 "1 error"、"3 files"
+// plural 为空时使用 Pluralize(singular) 自动生成复数形式
+func Plural(count int, singular, plural string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, singular)
+	}
+	if plural == "" {
+		plural = Pluralize(singular)
+	}
+	return fmt.Sprintf("%d %s", count, plural)
+}
+
+// ansiPattern 匹配终端 ANSI 转义序列：
+// CSI 序列（如颜色代码 "\x1b[31m"）、OSC 序列（如超链接、窗口标题）以及其他两字节转义序列
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)|\x1b[@-Z\\-_]`)
+
+// StripANSI 去除字符串中的 ANSI 转义序列（颜色、光标控制等）
+// 适用于将彩色输出写入日志文件前进行清理
+func StripANSI(s string) string {
+	if !strings.ContainsRune(s, '\x1b') {
+		return s
+	}
+	return ansiPattern.ReplaceAllString(s, "")
+}
+
+// VisibleLength 返回字符串在终端中可见的字符数（按 rune 计算），忽略 ANSI 转义序列
+// 用于对齐彩色输出时计算宽度
+func VisibleLength(s string) int {
+	return utf8.RuneCountInString(StripANSI(s))
+}
+
+// Words 将字符串拆分为单词，单词由连续的字母、数字及附加符号组成
+// 单词内部的撇号会被保留（如 "don't"），其余标点和空白都视为分隔符
+// 注意：中文等不以空格分词的文字，连续的字符会被视为一个单词
+func Words(s string) []string {
+	runes := []rune(s)
+	result := make([]string, 0)
+	start := -1
+	for i, r := range runes {
+		if isWordRune(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		// 两侧都是单词字符的撇号属于单词的一部分
+		if (r == '\'' || r == '’') && start >= 0 && i+1 < len(runes) && isWordRune(runes[i+1]) {
+			continue
+		}
+		if start >= 0 {
+			result = append(result, string(runes[start:i]))
+			start = -1
+		}
+	}
+	if start >= 0 {
+		result = append(result, string(runes[start:]))
+	}
+	return result
+}
+
+// isWordRune 判断 r 是否可以作为单词的组成部分
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.In(r, unicode.Mn, unicode.Mc)
+}
+
+// CountWords 返回字符串中的单词数，分词规则与 Words 相同
+func CountWords(s string) int {
+	return len(Words(s))
+}
+
+// CountRunes 返回字符串中的 rune（Unicode 码点）数量
+func CountRunes(s string) int {
+	return utf8.RuneCountInString(s)
+}
+
+// LenGraphemes 返回字符串中用户感知的字符（字素簇）数量，适用于用户名、短信等长度校验
+// 以下情况会被计为一个字符：
+//   - 基础字符及其后的组合附加符号，如 "é"
+//   - 变体选择符、肤色修饰符和标签字符，如 "👍🏽"
+//   - 通过零宽连接符（ZWJ）连接的 emoji 序列，如 "👨‍👩‍👧"
+//   - 成对的区域指示符（国旗），如 "🇨🇳"
+//   - "\r\n"
+//
+// 这是 Unicode 字素簇规则（UAX #29）的常用子集，不处理韩文音节组合等少见情况
+func LenGraphemes(s string) int {
+	count := 0
+	var prev rune
+	afterZWJ := false
+	// regionalOpen 表示上一个字符是尚未配对的区域指示符
+	regionalOpen := false
+	for i, r := range s {
+		newCluster := false
+		switch {
+		case i == 0:
+			newCluster = true
+		case r == '\n' && prev == '\r':
+			// CRLF 视为一个字符
+		case isGraphemeExtend(r):
+			// 附加到前一个字符
+		case afterZWJ:
+			// ZWJ 之后的字符与前面的字符组成一个序列
+		case isRegionalIndicator(r) && regionalOpen:
+			// 与前一个区域指示符组成国旗
+		default:
+			newCluster = true
+		}
+		if newCluster {
+			count++
+		}
+		regionalOpen = newCluster && isRegionalIndicator(r)
+		afterZWJ = r == '\u200d'
+		prev = r
+	}
+	return count
+}
+
+// isGraphemeExtend 判断 r 是否附加到前一个字符上，而不是单独成为一个字素簇
+func isGraphemeExtend(r rune) bool {
+	switch {
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc):
+		return true
+	case r == '\u200d': // 零宽连接符
+		return true
+	case r >= 0xFE00 && r <= 0xFE0F: // 变体选择符
+		return true
+	case r >= 0x1F3FB && r <= 0x1F3FF: // emoji 肤色修饰符
+		return true
+	case r >= 0xE0020 && r <= 0xE007F: // 标签字符（如英格兰旗帜）
+		return true
+	case r >= 0xE0100 && r <= 0xE01EF: // 补充变体选择符
+		return true
+	}
+	return false
+}
+
+// isRegionalIndicator 判断 r 是否为区域指示符（两个组成一面国旗）
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+// Substr 按 rune 截取从 start 开始、长度为 length 的子串，越界时自动收缩而不是 panic
+// start 为负数时从末尾开始计算（-1 表示最后一个字符），与 Python 的负索引一致
+// length 为负数时表示截取到倒数第 -length 个字符之前，例如 Substr("hello", 1, -1) == "ell"
+func Substr(s string, start, length int) string {
+	runes := []rune(s)
+	n := len(runes)
+
+	if start < 0 {
+		start = max(n+start, 0)
+	}
+	if start >= n {
+		return ""
+	}
+
+	end := start + length
+	if length < 0 {
+		end = n + length
+	}
+	end = min(end, n)
+	if end <= start {
+		return ""
+	}
+	return string(runes[start:end])
+}
+
+// Capitalize 将字符串的第一个字符转换为大写（标题形式），其余字符保持不变
+// 按 rune 处理，首字符为多字节字符时也能正确转换
+func Capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if size == 0 || r == utf8.RuneError {
+		return s
+	}
+	upper := unicode.ToTitle(r)
+	if upper == r {
+		return s
+	}
+	return string(upper) + s[size:]
+}
+
+// Title 将每个单词的首字母转换为大写（标题形式），其余字符保持不变
+// 用于替代已废弃的 strings.Title：按 rune 处理，且单词内部的撇号不会被视为单词边界
+// 例如 "don't stop élan" -> "Don't Stop Élan"
+func Title(s string) string {
+	var sb strings.Builder
+	sb.Grow(len(s))
+	var prev rune = ' '
+	for _, r := range s {
+		if isWordRune(r) && !isWordRune(prev) && prev != '\'' && prev != '’' {
+			sb.WriteRune(unicode.ToTitle(r))
+		} else {
+			sb.WriteRune(r)
+		}
+		prev = r
+	}
+	return sb.String()
+}
+
+// CommonPrefix 返回所有字符串的最长公共前缀，按 rune 比较，不会截断多字节字符
+// 例如 ["/var/log/app.log", "/var/log/sys.log"] -> "/var/log/"
+// ss 为空时返回空字符串
+func CommonPrefix(ss []string) string {
+	if len(ss) == 0 {
+		return ""
+	}
+
+	prefix := ss[0]
+	for _, s := range ss[1:] {
+		i := 0
+		for i < len(prefix) && i < len(s) {
+			_, size := utf8.DecodeRuneInString(prefix[i:])
+			if !strings.HasPrefix(s[i:], prefix[i:i+size]) {
+				break
+			}
+			i += size
+		}
+		prefix = prefix[:i]
+		if prefix == "" {
+			break
+		}
+	}
+	return prefix
+}
+
+// CommonSuffix 返回所有字符串的最长公共后缀，按 rune 比较，不会截断多字节字符
+// 例如 ["api.requests.count", "db.requests.count"] -> ".requests.count"
+// ss 为空时返回空字符串
+func CommonSuffix(ss []string) string {
+	if len(ss) == 0 {
+		return ""
+	}
+
+	suffix := ss[0]
+	for _, s := range ss[1:] {
+		n := 0
+		for n < len(suffix) && n < len(s) {
+			_, size := utf8.DecodeLastRuneInString(suffix[:len(suffix)-n])
+			if !strings.HasSuffix(s[:len(s)-n], suffix[len(suffix)-n-size:len(suffix)-n]) {
+				break
+			}
+			n += size
+		}
+		suffix = suffix[len(suffix)-n:]
+		if suffix == "" {
+			break
+		}
+	}
+	return suffix
+}
@@ -0,0 +1,1102 @@
+package strutils
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestRandomString(t *testing.T) {
+	tests := []struct {
+		name     string
+		n        int
+		charset  Charset
+		expected int
+	}{
+		{
+			name:     "字母数字",
+			n:        16,
+			charset:  Alphanumeric,
+			expected: 16,
+		},
+		{
+			name:     "十六进制",
+			n:        32,
+			charset:  Hex,
+			expected: 32,
+		},
+		{
+			name:     "多字节字符集",
+			n:        5,
+			charset:  "你好世界",
+			expected: 5,
+		},
+		{
+			name:     "长度为0",
+			n:        0,
+			charset:  Alphanumeric,
+			expected: 0,
+		},
+		{
+			name:     "空字符集",
+			n:        10,
+			charset:  "",
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := RandomString(tt.n, tt.charset)
+			if utf8.RuneCountInString(result) != tt.expected {
+				t.Errorf("RandomString() 长度 = %v, 期望 %v", utf8.RuneCountInString(result), tt.expected)
+			}
+			for _, r := range result {
+				if !strings.ContainsRune(string(tt.charset), r) {
+					t.Errorf("RandomString() 包含字符集之外的字符 %q", r)
+				}
+			}
+		})
+	}
+
+	t.Run("注入随机源结果可复现", func(t *testing.T) {
+		a := RandomStringFrom(rand.New(rand.NewSource(42)), 20, URLSafe)
+		b := RandomStringFrom(rand.New(rand.NewSource(42)), 20, URLSafe)
+		if a != b {
+			t.Errorf("RandomStringFrom() 相同种子结果不同: %v, %v", a, b)
+		}
+	})
+
+	t.Run("安全随机", func(t *testing.T) {
+		result, err := RandomStringSecure(24, URLSafe)
+		if err != nil {
+			t.Fatalf("RandomStringSecure() 返回错误: %v", err)
+		}
+		if len(result) != 24 {
+			t.Errorf("RandomStringSecure() 长度 = %v, 期望 %v", len(result), 24)
+		}
+		for _, r := range result {
+			if !strings.ContainsRune(string(URLSafe), r) {
+				t.Errorf("RandomStringSecure() 包含字符集之外的字符 %q", r)
+			}
+		}
+	})
+}
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     []SlugOption
+		expected string
+	}{
+		{
+			name:     "基本转换",
+			input:    "Hello World",
+			expected: "hello-world",
+		},
+		{
+			name:     "重音字符转写",
+			input:    "Héllo, Wörld! Straße",
+			expected: "hello-world-strasse",
+		},
+		{
+			name:     "分解形式的附加符号",
+			input:    "Cafe\u0301 Cre\u0300me",
+			expected: "cafe-creme",
+		},
+		{
+			name:     "合并连续分隔符并去掉首尾",
+			input:    "  --Go   is__fun!!  ",
+			expected: "go-is-fun",
+		},
+		{
+			name:     "& 转写为 and",
+			input:    "Tom & Jerry",
+			expected: "tom-and-jerry",
+		},
+		{
+			name:     "非拉丁字符被丢弃",
+			input:    "你好 go",
+			expected: "go",
+		},
+		{
+			name:     "自定义分隔符",
+			input:    "Hello World",
+			opts:     []SlugOption{WithSeparator("_")},
+			expected: "hello_world",
+		},
+		{
+			name:     "限制最大长度",
+			input:    "The quick brown fox",
+			opts:     []SlugOption{WithMaxLength(10)},
+			expected: "the-quick",
+		},
+		{
+			name:     "截断时不会删除与分隔符相同的字母",
+			input:    "hello world",
+			opts:     []SlugOption{WithSeparator("_o_"), WithMaxLength(7)},
+			expected: "hello",
+		},
+		{
+			name:     "截断时去掉不完整的分隔符",
+			input:    "too long",
+			opts:     []SlugOption{WithSeparator("ng"), WithMaxLength(4)},
+			expected: "too",
+		},
+		{
+			name:     "截断在分隔符之后",
+			input:    "too long",
+			opts:     []SlugOption{WithSeparator("ng"), WithMaxLength(6)},
+			expected: "toongl",
+		},
+		{
+			name:     "保留额外字符",
+			input:    "Release v1.2.3",
+			opts:     []SlugOption{WithAllowedChars(".")},
+			expected: "release-v1.2.3",
+		},
+		{
+			name:     "空字符串",
+			input:    "",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Slugify(tt.input, tt.opts...)
+			if result != tt.expected {
+				t.Errorf("Slugify() = %q, 期望 %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWordWrap(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		width    int
+		expected string
+	}{
+		{
+			name:     "基本折行",
+			input:    "the quick brown fox jumps over the lazy dog",
+			width:    10,
+			expected: "the quick\nbrown fox\njumps over\nthe lazy\ndog",
+		},
+		{
+			name:     "保留原有换行",
+			input:    "aaa bbb\nccc ddd eee",
+			width:    7,
+			expected: "aaa bbb\nccc ddd\neee",
+		},
+		{
+			name:     "超长单词独占一行",
+			input:    "a supercalifragilistic word",
+			width:    5,
+			expected: "a\nsupercalifragilistic\nword",
+		},
+		{
+			name:     "多字节字符按 rune 计算宽度",
+			input:    "你好 世界 再见",
+			width:    5,
+			expected: "你好 世界\n再见",
+		},
+		{
+			name:     "合并多余空白",
+			input:    "a    b   c",
+			width:    80,
+			expected: "a b c",
+		},
+		{
+			name:     "宽度非法",
+			input:    "a b c",
+			width:    0,
+			expected: "a b c",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := WordWrap(tt.input, tt.width)
+			if result != tt.expected {
+				t.Errorf("WordWrap() = %q, 期望 %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIndent(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		prefix   string
+		expected string
+	}{
+		{
+			name:     "多行缩进",
+			input:    "line1\nline2",
+			prefix:   "  ",
+			expected: "  line1\n  line2",
+		},
+		{
+			name:     "空行不缩进",
+			input:    "line1\n\nline3\n",
+			prefix:   "> ",
+			expected: "> line1\n\n> line3\n",
+		},
+		{
+			name:     "空字符串",
+			input:    "",
+			prefix:   "  ",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Indent(tt.input, tt.prefix)
+			if result != tt.expected {
+				t.Errorf("Indent() = %q, 期望 %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSubstitute(t *testing.T) {
+	vars := map[string]string{"name": "Alice", "count": "3", "user.id": "42"}
+	tests := []struct {
+		name      string
+		template  string
+		opts      []SubstituteOption
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:     "美元符号占位符",
+			template: "Hello, ${name}!",
+			expected: "Hello, Alice!",
+		},
+		{
+			name:     "花括号占位符",
+			template: "{name} has {count} messages",
+			expected: "Alice has 3 messages",
+		},
+		{
+			name:     "带点的名称",
+			template: "id={user.id}",
+			expected: "id=42",
+		},
+		{
+			name:     "非法花括号原样输出",
+			template: `{"key": "value"} { name } {`,
+			expected: `{"key": "value"} { name } {`,
+		},
+		{
+			name:     "未闭合的美元占位符",
+			template: "cost ${name",
+			expected: "cost ${name",
+		},
+		{
+			name:      "缺少值默认报错",
+			template:  "Hi {missing}",
+			expectErr: true,
+		},
+		{
+			name:     "缺少值保留占位符",
+			template: "Hi ${missing} and {missing}",
+			opts:     []SubstituteOption{WithMissingKey(MissingKeyKeep)},
+			expected: "Hi ${missing} and {missing}",
+		},
+		{
+			name:     "缺少值替换为空",
+			template: "Hi [{missing}]",
+			opts:     []SubstituteOption{WithMissingKey(MissingKeyEmpty)},
+			expected: "Hi []",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Substitute(tt.template, vars, tt.opts...)
+			if tt.expectErr {
+				if !errors.Is(err, ErrMissingKey) {
+					t.Errorf("Substitute() 错误 = %v, 期望 ErrMissingKey", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Substitute() 返回错误: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("Substitute() = %q, 期望 %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestContainsAnyAll(t *testing.T) {
+	tests := []struct {
+		name        string
+		s           string
+		subs        []string
+		expectedAny bool
+		expectedAll bool
+	}{
+		{
+			name:        "全部包含",
+			s:           "/api/v1/users",
+			subs:        []string{"api", "users"},
+			expectedAny: true,
+			expectedAll: true,
+		},
+		{
+			name:        "部分包含",
+			s:           "/api/v1/users",
+			subs:        []string{"v2", "users"},
+			expectedAny: true,
+			expectedAll: false,
+		},
+		{
+			name:        "都不包含",
+			s:           "/api/v1/users",
+			subs:        []string{"v2", "orders"},
+			expectedAny: false,
+			expectedAll: false,
+		},
+		{
+			name:        "没有子串",
+			s:           "abc",
+			subs:        nil,
+			expectedAny: false,
+			expectedAll: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := ContainsAny(tt.s, tt.subs...); result != tt.expectedAny {
+				t.Errorf("ContainsAny() = %v, 期望 %v", result, tt.expectedAny)
+			}
+			if result := ContainsAll(tt.s, tt.subs...); result != tt.expectedAll {
+				t.Errorf("ContainsAll() = %v, 期望 %v", result, tt.expectedAll)
+			}
+		})
+	}
+}
+
+func TestHasPrefixSuffixAny(t *testing.T) {
+	tests := []struct {
+		name     string
+		fn       func(string, ...string) bool
+		s        string
+		affixes  []string
+		expected bool
+	}{
+		{
+			name:     "匹配前缀",
+			fn:       HasPrefixAny,
+			s:        "https://example.com",
+			affixes:  []string{"http://", "https://"},
+			expected: true,
+		},
+		{
+			name:     "不匹配前缀",
+			fn:       HasPrefixAny,
+			s:        "ftp://example.com",
+			affixes:  []string{"http://", "https://"},
+			expected: false,
+		},
+		{
+			name:     "匹配后缀",
+			fn:       HasSuffixAny,
+			s:        "photo.JPG",
+			affixes:  []string{".png", ".JPG"},
+			expected: true,
+		},
+		{
+			name:     "不匹配后缀",
+			fn:       HasSuffixAny,
+			s:        "photo.gif",
+			affixes:  []string{".png", ".jpg"},
+			expected: false,
+		},
+		{
+			name:     "没有候选",
+			fn:       HasSuffixAny,
+			s:        "photo.gif",
+			affixes:  nil,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.fn(tt.s, tt.affixes...); result != tt.expected {
+				t.Errorf("结果 = %v, 期望 %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMask(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		keepStart int
+		keepEnd   int
+		maskChar  rune
+		expected  string
+	}{
+		{
+			name:      "保留首尾",
+			input:     "1234567890",
+			keepStart: 2,
+			keepEnd:   2,
+			maskChar:  '*',
+			expected:  "12******90",
+		},
+		{
+			name:      "多字节字符",
+			input:     "张三丰",
+			keepStart: 1,
+			keepEnd:   0,
+			maskChar:  '*',
+			expected:  "张**",
+		},
+		{
+			name:      "保留长度超过总长度时全部遮盖",
+			input:     "abc",
+			keepStart: 2,
+			keepEnd:   2,
+			maskChar:  '#',
+			expected:  "###",
+		},
+		{
+			name:      "负数按0处理",
+			input:     "abc",
+			keepStart: -1,
+			keepEnd:   1,
+			maskChar:  '*',
+			expected:  "**c",
+		},
+		{
+			name:      "空字符串",
+			input:     "",
+			keepStart: 1,
+			keepEnd:   1,
+			maskChar:  '*',
+			expected:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Mask(tt.input, tt.keepStart, tt.keepEnd, tt.maskChar)
+			if result != tt.expected {
+				t.Errorf("Mask() = %q, 期望 %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMaskEmail(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "普通邮箱",
+			input:    "john.doe@example.com",
+			expected: "jo******@e******.com",
+		},
+		{
+			name:     "短用户名",
+			input:    "jo@mail.co.uk",
+			expected: "j*@m******.uk",
+		},
+		{
+			name:     "不含@",
+			input:    "johndoe",
+			expected: "jo*****",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := MaskEmail(tt.input)
+			if result != tt.expected {
+				t.Errorf("MaskEmail() = %q, 期望 %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMaskPhone(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "手机号",
+			input:    "13812345678",
+			expected: "138****5678",
+		},
+		{
+			name:     "保留分隔符",
+			input:    "+1 (555) 123-4567",
+			expected: "+1 (55*) ***-4567",
+		},
+		{
+			name:     "短号码",
+			input:    "123456",
+			expected: "**3456",
+		},
+		{
+			name:     "极短号码",
+			input:    "123",
+			expected: "***",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := MaskPhone(tt.input)
+			if result != tt.expected {
+				t.Errorf("MaskPhone() = %q, 期望 %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSplitAndTrim(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		sep      string
+		expected []string
+	}{
+		{
+			name:     "去空白和空项",
+			input:    " a, b ,, c ",
+			sep:      ",",
+			expected: []string{"a", "b", "c"},
+		},
+		{
+			name:     "多字符分隔符",
+			input:    "x :: y ::z",
+			sep:      "::",
+			expected: []string{"x", "y", "z"},
+		},
+		{
+			name:     "只有空白",
+			input:    " , ,  ",
+			sep:      ",",
+			expected: []string{},
+		},
+		{
+			name:     "空字符串",
+			input:    "",
+			sep:      ",",
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := SplitAndTrim(tt.input, tt.sep)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("SplitAndTrim() = %q, 期望 %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSplitNonEmpty(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		sep      string
+		expected []string
+	}{
+		{
+			name:     "丢弃空项",
+			input:    "a,,b,",
+			sep:      ",",
+			expected: []string{"a", "b"},
+		},
+		{
+			name:     "保留空白",
+			input:    " a , b",
+			sep:      ",",
+			expected: []string{" a ", " b"},
+		},
+		{
+			name:     "空字符串",
+			input:    "",
+			sep:      ",",
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := SplitNonEmpty(tt.input, tt.sep)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("SplitNonEmpty() = %q, 期望 %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsBlank(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected bool
+	}{
+		{name: "空字符串", input: "", expected: true},
+		{name: "只有空白", input: " \t\n", expected: true},
+		{name: "全角空格", input: "　", expected: true},
+		{name: "非空", input: " a ", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := IsBlank(tt.input); result != tt.expected {
+				t.Errorf("IsBlank() = %v, 期望 %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDefaultIfBlank(t *testing.T) {
+	if result := DefaultIfBlank("  ", "def"); result != "def" {
+		t.Errorf("DefaultIfBlank() = %q, 期望 %q", result, "def")
+	}
+	if result := DefaultIfBlank(" v ", "def"); result != " v " {
+		t.Errorf("DefaultIfBlank() = %q, 期望 %q", result, " v ")
+	}
+}
+
+func TestCoalesce(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []string
+		expected string
+	}{
+		{name: "跳过空白值", values: []string{"", "  ", "env", "default"}, expected: "env"},
+		{name: "第一个即非空", values: []string{"flag", "env"}, expected: "flag"},
+		{name: "全部为空白", values: []string{"", " "}, expected: ""},
+		{name: "没有参数", values: nil, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := Coalesce(tt.values...); result != tt.expected {
+				t.Errorf("Coalesce() = %q, 期望 %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEllipsisMiddle(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		maxLen   int
+		expected string
+	}{
+		{
+			name:     "路径",
+			input:    "very/long/path/to/some/file.go",
+			maxLen:   15,
+			expected: "very/lo…file.go",
+		},
+		{
+			name:     "奇数剩余长度分给开头",
+			input:    "abcdefghij",
+			maxLen:   6,
+			expected: "abc…ij",
+		},
+		{
+			name:     "不超过长度原样返回",
+			input:    "short",
+			maxLen:   5,
+			expected: "short",
+		},
+		{
+			name:     "多字节字符",
+			input:    "一二三四五六七八九十",
+			maxLen:   5,
+			expected: "一二…九十",
+		},
+		{
+			name:     "最大长度为1",
+			input:    "abc",
+			maxLen:   1,
+			expected: "…",
+		},
+		{
+			name:     "最大长度非法",
+			input:    "abc",
+			maxLen:   0,
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := EllipsisMiddle(tt.input, tt.maxLen)
+			if result != tt.expected {
+				t.Errorf("EllipsisMiddle() = %q, 期望 %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPluralize(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "file", expected: "files"},
+		{input: "box", expected: "boxes"},
+		{input: "match", expected: "matches"},
+		{input: "city", expected: "cities"},
+		{input: "day", expected: "days"},
+		{input: "person", expected: "people"},
+		{input: "knife", expected: "knives"},
+		{input: "sheep", expected: "sheep"},
+		{input: "Child", expected: "Children"},
+		{input: "BOX", expected: "BOXES"},
+		{input: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if result := Pluralize(tt.input); result != tt.expected {
+				t.Errorf("Pluralize() = %q, 期望 %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPlural(t *testing.T) {
+	tests := []struct {
+		name     string
+		count    int
+		singular string
+		plural   string
+		expected string
+	}{
+		{name: "单数", count: 1, singular: "error", expected: "1 error"},
+		{name: "复数", count: 3, singular: "file", expected: "3 files"},
+		{name: "零", count: 0, singular: "match", expected: "0 matches"},
+		{name: "指定复数形式", count: 2, singular: "octopus", plural: "octopi", expected: "2 octopi"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := Plural(tt.count, tt.singular, tt.plural); result != tt.expected {
+				t.Errorf("Plural() = %q, 期望 %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestStripANSI(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		expected      string
+		visibleLength int
+	}{
+		{
+			name:          "颜色代码",
+			input:         "\x1b[31mred\x1b[0m text",
+			expected:      "red text",
+			visibleLength: 8,
+		},
+		{
+			name:          "复合样式",
+			input:         "\x1b[1;38;5;196mbold\x1b[22m",
+			expected:      "bold",
+			visibleLength: 4,
+		},
+		{
+			name:          "OSC 超链接",
+			input:         "\x1b]8;;https://example.com\x07link\x1b]8;;\x07",
+			expected:      "link",
+			visibleLength: 4,
+		},
+		{
+			name:          "光标控制",
+			input:         "\x1b[2K\x1b[1Gprogress",
+			expected:      "progress",
+			visibleLength: 8,
+		},
+		{
+			name:          "多字节字符",
+			input:         "\x1b[32m成功\x1b[0m",
+			expected:      "成功",
+			visibleLength: 2,
+		},
+		{
+			name:          "无转义序列",
+			input:         "plain",
+			expected:      "plain",
+			visibleLength: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := StripANSI(tt.input); result != tt.expected {
+				t.Errorf("StripANSI() = %q, 期望 %q", result, tt.expected)
+			}
+			if result := VisibleLength(tt.input); result != tt.visibleLength {
+				t.Errorf("VisibleLength() = %v, 期望 %v", result, tt.visibleLength)
+			}
+		})
+	}
+}
+
+func TestWords(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "标点和空白分隔",
+			input:    "Hello, world! How are-you?",
+			expected: []string{"Hello", "world", "How", "are", "you"},
+		},
+		{
+			name:     "保留单词内的撇号",
+			input:    "don't stop 'quoted'",
+			expected: []string{"don't", "stop", "quoted"},
+		},
+		{
+			name:     "数字和重音字符",
+			input:    "café 2024",
+			expected: []string{"café", "2024"},
+		},
+		{
+			name:     "空字符串",
+			input:    "  ",
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Words(tt.input)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Words() = %q, 期望 %q", result, tt.expected)
+			}
+			if count := CountWords(tt.input); count != len(tt.expected) {
+				t.Errorf("CountWords() = %v, 期望 %v", count, len(tt.expected))
+			}
+		})
+	}
+}
+
+func TestLenGraphemes(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		runes    int
+		expected int
+	}{
+		{name: "ASCII", input: "hello", runes: 5, expected: 5},
+		{name: "中文", input: "你好", runes: 2, expected: 2},
+		{name: "组合附加符号", input: "e\u0301", runes: 2, expected: 1},
+		{name: "肤色修饰符", input: "👍🏽", runes: 2, expected: 1},
+		{name: "ZWJ 家庭 emoji", input: "👨‍👩‍👧", runes: 5, expected: 1},
+		{name: "国旗", input: "🇨🇳🇺🇸", runes: 4, expected: 2},
+		{name: "变体选择符", input: "❤️!", runes: 3, expected: 2},
+		{name: "CRLF", input: "a\r\nb", runes: 4, expected: 3},
+		{name: "空字符串", input: "", runes: 0, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := CountRunes(tt.input); result != tt.runes {
+				t.Errorf("CountRunes() = %v, 期望 %v", result, tt.runes)
+			}
+			if result := LenGraphemes(tt.input); result != tt.expected {
+				t.Errorf("LenGraphemes() = %v, 期望 %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSubstr(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		start    int
+		length   int
+		expected string
+	}{
+		{name: "正常截取", input: "hello world", start: 0, length: 5, expected: "hello"},
+		{name: "负数起点", input: "hello world", start: -5, length: 3, expected: "wor"},
+		{name: "长度越界", input: "hello", start: 3, length: 10, expected: "lo"},
+		{name: "起点越界", input: "hello", start: 10, length: 2, expected: ""},
+		{name: "负数起点越界", input: "hello", start: -10, length: 2, expected: "he"},
+		{name: "负数长度", input: "hello", start: 1, length: -1, expected: "ell"},
+		{name: "负数长度越界", input: "hello", start: 1, length: -10, expected: ""},
+		{name: "多字节字符", input: "你好世界", start: 1, length: 2, expected: "好世"},
+		{name: "长度为0", input: "hello", start: 1, length: 0, expected: ""},
+		{name: "空字符串", input: "", start: 0, length: 1, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := Substr(tt.input, tt.start, tt.length); result != tt.expected {
+				t.Errorf("Substr() = %q, 期望 %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCapitalize(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "hello world", expected: "Hello world"},
+		{input: "élan", expected: "Élan"},
+		{input: "ǆemal", expected: "ǅemal"},
+		{input: "Already", expected: "Already"},
+		{input: "你好", expected: "你好"},
+		{input: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if result := Capitalize(tt.input); result != tt.expected {
+				t.Errorf("Capitalize() = %q, 期望 %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTitle(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "hello world", expected: "Hello World"},
+		{input: "don't stop élan", expected: "Don't Stop Élan"},
+		{input: "snake_case-and.dots", expected: "Snake_Case-And.Dots"},
+		{input: "mIxEd cAsE", expected: "MIxEd CAsE"},
+		{input: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if result := Title(tt.input); result != tt.expected {
+				t.Errorf("Title() = %q, 期望 %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCommonPrefix(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []string
+		expected string
+	}{
+		{
+			name:     "文件路径",
+			input:    []string{"/var/log/app.log", "/var/log/sys.log", "/var/lib/x"},
+			expected: "/var/l",
+		},
+		{
+			name:     "不截断多字节字符",
+			input:    []string{"中文", "中国"},
+			expected: "中",
+		},
+		{
+			name:     "首字节相同的不同字符",
+			input:    []string{"一", "丁"},
+			expected: "",
+		},
+		{
+			name:     "单个字符串",
+			input:    []string{"abc"},
+			expected: "abc",
+		},
+		{
+			name:     "没有公共前缀",
+			input:    []string{"abc", "xyz"},
+			expected: "",
+		},
+		{
+			name:     "空切片",
+			input:    []string{},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := CommonPrefix(tt.input); result != tt.expected {
+				t.Errorf("CommonPrefix() = %q, 期望 %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCommonSuffix(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []string
+		expected string
+	}{
+		{
+			name:     "指标名称",
+			input:    []string{"api.requests.count", "db.requests.count"},
+			expected: ".requests.count",
+		},
+		{
+			name:     "不截断多字节字符",
+			input:    []string{"北京市", "上海市"},
+			expected: "市",
+		},
+		{
+			name:     "其中一个是另一个的后缀",
+			input:    []string{"file.go", ".go"},
+			expected: ".go",
+		},
+		{
+			name:     "没有公共后缀",
+			input:    []string{"abc", "xyz"},
+			expected: "",
+		},
+		{
+			name:     "空切片",
+			input:    nil,
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := CommonSuffix(tt.input); result != tt.expected {
+				t.Errorf("CommonSuffix() = %q, 期望 %q", result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package testutils
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// maxDiffs 失败信息中最多列出的差异数量
+const maxDiffs = 10
+
+// AssertEqual 断言 got == want，失败时报告两者的值，返回是否相等
+func AssertEqual[T comparable](t testing.TB, got, want T) bool {
+	t.Helper()
+	if got != want {
+		t.Errorf("值不相等: 实际 %s, 期望 %s", format(got), format(want))
+		return false
+	}
+	return true
+}
+
+// AssertSliceEqual 断言 got 和 want 的长度和每个位置上的元素都相等，nil 和空切片视为相等
+// 失败时逐个列出不同的下标，以及多出或缺少的元素
+func AssertSliceEqual[T comparable](t testing.TB, got, want []T) bool {
+	t.Helper()
+	var diffs []string
+	for i := 0; i < max(len(got), len(want)); i++ {
+		switch {
+		case i >= len(got):
+			diffs = append(diffs, fmt.Sprintf("[%d] 缺少 %s", i, format(want[i])))
+		case i >= len(want):
+			diffs = append(diffs, fmt.Sprintf("[%d] 多余 %s", i, format(got[i])))
+		case got[i] != want[i]:
+			diffs = append(diffs, fmt.Sprintf("[%d] 实际 %s, 期望 %s", i, format(got[i]), format(want[i])))
+		}
+	}
+	if len(diffs) == 0 {
+		return true
+	}
+	t.Errorf("切片不相等（长度 实际 %d, 期望 %d）:%s", len(got), len(want), joinDiffs(diffs))
+	return false
+}
+
+// AssertElementsMatch 断言 got 和 want 包含相同的元素且每个元素出现的次数相同，忽略顺序
+// 失败时列出多余和缺少的元素
+func AssertElementsMatch[T comparable](t testing.TB, got, want []T) bool {
+	t.Helper()
+	counts := make(map[T]int, len(want))
+	for _, v := range want {
+		counts[v]++
+	}
+	var extra []T
+	for _, v := range got {
+		if counts[v] > 0 {
+			counts[v]--
+		} else {
+			extra = append(extra, v)
+		}
+	}
+	var missing []T
+	for _, v := range want {
+		if counts[v] > 0 {
+			counts[v]--
+			missing = append(missing, v)
+		}
+	}
+	if len(extra) == 0 && len(missing) == 0 {
+		return true
+	}
+	var diffs []string
+	for _, v := range extra {
+		diffs = append(diffs, "多余 "+format(v))
+	}
+	for _, v := range missing {
+		diffs = append(diffs, "缺少 "+format(v))
+	}
+	t.Errorf("元素不匹配:%s", joinDiffs(diffs))
+	return false
+}
+
+// AssertMapEqual 断言 got 和 want 包含相同的键且对应的值相等，nil 和空 map 视为相等
+// 失败时按键排序列出多余、缺少和值不同的键
+func AssertMapEqual[K, V comparable](t testing.TB, got, want map[K]V) bool {
+	t.Helper()
+	type keyDiff struct {
+		key  string
+		desc string
+	}
+	var diffs []keyDiff
+	for k, g := range got {
+		w, ok := want[k]
+		switch {
+		case !ok:
+			diffs = append(diffs, keyDiff{format(k), fmt.Sprintf("[%s] 多余 %s", format(k), format(g))})
+		case g != w:
+			diffs = append(diffs, keyDiff{format(k), fmt.Sprintf("[%s] 实际 %s, 期望 %s", format(k), format(g), format(w))})
+		}
+	}
+	for k, w := range want {
+		if _, ok := got[k]; !ok {
+			diffs = append(diffs, keyDiff{format(k), fmt.Sprintf("[%s] 缺少 %s", format(k), format(w))})
+		}
+	}
+	if len(diffs) == 0 {
+		return true
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].key < diffs[j].key })
+	lines := make([]string, len(diffs))
+	for i, d := range diffs {
+		lines[i] = d.desc
+	}
+	t.Errorf("map 不相等（长度 实际 %d, 期望 %d）:%s", len(got), len(want), joinDiffs(lines))
+	return false
+}
+
+// AssertPanics 断言 fn 会 panic，返回 recover 得到的值
+func AssertPanics(t testing.TB, fn func()) (recovered any) {
+	t.Helper()
+	panicked := true
+	func() {
+		defer func() {
+			recovered = recover()
+		}()
+		fn()
+		panicked = false
+	}()
+	if !panicked {
+		t.Errorf("期望 panic, 实际没有 panic")
+	}
+	return recovered
+}
+
+// AssertErrorIs 断言 errors.Is(err, target) 为 true
+func AssertErrorIs(t testing.TB, err, target error) bool {
+	t.Helper()
+	if !errors.Is(err, target) {
+		t.Errorf("错误不匹配: 实际 %v, 期望包含 %v", err, target)
+		return false
+	}
+	return true
+}
+
+// AssertNoError 断言 err 为 nil
+func AssertNoError(t testing.TB, err error) bool {
+	t.Helper()
+	if err != nil {
+		t.Errorf("期望没有错误, 实际 %v", err)
+		return false
+	}
+	return true
+}
+
+// format 格式化失败信息中的值，字符串加上引号以区分空白
+func format(v any) string {
+	if s, ok := v.(string); ok {
+		return strconv.Quote(s)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// joinDiffs 将差异逐行缩进排列，超过 maxDiffs 的部分只报告数量
+func joinDiffs(diffs []string) string {
+	var sb strings.Builder
+	for i, d := range diffs {
+		if i == maxDiffs {
+			fmt.Fprintf(&sb, "\n  ... 另有 %d 处差异", len(diffs)-maxDiffs)
+			break
+		}
+		sb.WriteString("\n  ")
+		sb.WriteString(d)
+	}
+	return sb.String()
+}
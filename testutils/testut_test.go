@@ -0,0 +1,149 @@
+package testutils
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// fakeT 记录断言失败信息的 testing.TB
+type fakeT struct {
+	testing.TB
+	msgs []string
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Errorf(format string, args ...any) {
+	f.msgs = append(f.msgs, fmt.Sprintf(format, args...))
+}
+
+// check 检查断言的返回值和失败信息，wantMsg 中的每一项都必须出现在失败信息中
+func check(t *testing.T, ft *fakeT, ok, wantOK bool, wantMsg ...string) {
+	t.Helper()
+	if ok != wantOK {
+		t.Errorf("返回值 = %v, 期望 %v", ok, wantOK)
+	}
+	if wantOK {
+		if len(ft.msgs) != 0 {
+			t.Errorf("失败信息 = %q, 期望没有失败", ft.msgs)
+		}
+		return
+	}
+	if len(ft.msgs) != 1 {
+		t.Fatalf("失败信息 = %q, 期望 1 条", ft.msgs)
+	}
+	for _, m := range wantMsg {
+		if !strings.Contains(ft.msgs[0], m) {
+			t.Errorf("失败信息 = %q, 期望包含 %q", ft.msgs[0], m)
+		}
+	}
+}
+
+func TestAssertEqual(t *testing.T) {
+	ft := &fakeT{}
+	check(t, ft, AssertEqual(ft, 1, 1), true)
+
+	ft = &fakeT{}
+	check(t, ft, AssertEqual(ft, "a ", "a"), false, `实际 "a ", 期望 "a"`)
+}
+
+func TestAssertSliceEqual(t *testing.T) {
+	tests := []struct {
+		name    string
+		got     []int
+		want    []int
+		ok      bool
+		wantMsg []string
+	}{
+		{"相等", []int{1, 2}, []int{1, 2}, true, nil},
+		{"nil 和空切片", nil, []int{}, true, nil},
+		{"元素不同", []int{1, 5, 3}, []int{1, 2, 3}, false, []string{"长度 实际 3, 期望 3", "[1] 实际 5, 期望 2"}},
+		{"缺少元素", []int{1}, []int{1, 2}, false, []string{"[1] 缺少 2"}},
+		{"多余元素", []int{1, 2, 3}, []int{1}, false, []string{"[1] 多余 2", "[2] 多余 3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ft := &fakeT{}
+			check(t, ft, AssertSliceEqual(ft, tt.got, tt.want), tt.ok, tt.wantMsg...)
+		})
+	}
+
+	t.Run("差异过多时截断", func(t *testing.T) {
+		ft := &fakeT{}
+		AssertSliceEqual(ft, make([]int, 15), []int{})
+		check(t, ft, false, false, "[9] 多余 0", "另有 5 处差异")
+		if strings.Contains(ft.msgs[0], "[10]") {
+			t.Errorf("失败信息 = %q, 期望只列出前 %d 处差异", ft.msgs[0], maxDiffs)
+		}
+	})
+}
+
+func TestAssertElementsMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		got     []string
+		want    []string
+		ok      bool
+		wantMsg []string
+	}{
+		{"顺序不同", []string{"b", "a", "b"}, []string{"b", "b", "a"}, true, nil},
+		{"次数不同", []string{"a", "a"}, []string{"a", "b"}, false, []string{`多余 "a"`, `缺少 "b"`}},
+		{"缺少元素", []string{"a"}, []string{"a", "c"}, false, []string{`缺少 "c"`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ft := &fakeT{}
+			check(t, ft, AssertElementsMatch(ft, tt.got, tt.want), tt.ok, tt.wantMsg...)
+		})
+	}
+}
+
+func TestAssertMapEqual(t *testing.T) {
+	tests := []struct {
+		name    string
+		got     map[string]int
+		want    map[string]int
+		ok      bool
+		wantMsg []string
+	}{
+		{"相等", map[string]int{"a": 1}, map[string]int{"a": 1}, true, nil},
+		{"nil 和空 map", nil, map[string]int{}, true, nil},
+		{"值不同、多余和缺少", map[string]int{"a": 1, "b": 2}, map[string]int{"a": 3, "c": 4}, false,
+			[]string{`["a"] 实际 1, 期望 3` + "\n  " + `["b"] 多余 2` + "\n  " + `["c"] 缺少 4`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ft := &fakeT{}
+			check(t, ft, AssertMapEqual(ft, tt.got, tt.want), tt.ok, tt.wantMsg...)
+		})
+	}
+}
+
+func TestAssertPanics(t *testing.T) {
+	ft := &fakeT{}
+	if v := AssertPanics(ft, func() { panic("boom") }); v != "boom" {
+		t.Errorf("AssertPanics() = %v, 期望 %v", v, "boom")
+	}
+	check(t, ft, true, true)
+
+	ft = &fakeT{}
+	AssertPanics(ft, func() {})
+	check(t, ft, false, false, "期望 panic")
+}
+
+func TestAssertErrorIs(t *testing.T) {
+	errBase := errors.New("base")
+	ft := &fakeT{}
+	check(t, ft, AssertErrorIs(ft, fmt.Errorf("wrap: %w", errBase), errBase), true)
+
+	ft = &fakeT{}
+	check(t, ft, AssertErrorIs(ft, errors.New("other"), errBase), false, "实际 other, 期望包含 base")
+
+	ft = &fakeT{}
+	check(t, ft, AssertNoError(ft, nil), true)
+
+	ft = &fakeT{}
+	check(t, ft, AssertNoError(ft, errBase), false, "实际 base")
+}
@@ -0,0 +1,281 @@
+package timeutils
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// Clock 时钟接口，抽象了 time 包中与当前时间相关的函数，便于在测试中用 FakeClock 控制时间
+type Clock interface {
+	// Now 返回当前时间
+	Now() time.Time
+	// Since 返回从 t 到现在经过的时间
+	Since(t time.Time) time.Duration
+	// After 返回一个在 d 之后收到当前时间的 channel
+	After(d time.Duration) <-chan time.Time
+	// NewTimer 创建一个在 d 之后触发的 Timer
+	NewTimer(d time.Duration) Timer
+	// NewTicker 创建一个每隔 d 触发一次的 Ticker，d 必须大于 0
+	NewTicker(d time.Duration) Ticker
+	// AfterFunc 在 d 之后执行 f，返回的 Timer 可用于取消，其 C 返回 nil
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer 对应 time.Timer
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+// Ticker 对应 time.Ticker
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+	Reset(d time.Duration)
+}
+
+// RealClock 返回使用系统时间的 Clock
+func RealClock() Clock {
+	return realClock{}
+}
+
+// OrRealClock 在 c 为 nil 时返回 RealClock，用于处理可选的 Clock 配置
+func OrRealClock(c Clock) Clock {
+	if c == nil {
+		return realClock{}
+	}
+	return c
+}
+
+// realClock 基于 time 包的 Clock
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) Since(t time.Time) time.Duration        { return time.Since(t) }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (realClock) NewTimer(d time.Duration) Timer         { return realTimer{time.NewTimer(d)} }
+func (realClock) NewTicker(d time.Duration) Ticker       { return realTicker{time.NewTicker(d)} }
+func (realClock) AfterFunc(d time.Duration, f func()) Timer {
+	return realTimer{time.AfterFunc(d, f)}
+}
+
+// realTimer 包装 time.Timer
+type realTimer struct {
+	t *time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time        { return t.t.C }
+func (t realTimer) Stop() bool                 { return t.t.Stop() }
+func (t realTimer) Reset(d time.Duration) bool { return t.t.Reset(d) }
+
+// realTicker 包装 time.Ticker
+type realTicker struct {
+	t *time.Ticker
+}
+
+func (t realTicker) C() <-chan time.Time   { return t.t.C }
+func (t realTicker) Stop()                 { t.t.Stop() }
+func (t realTicker) Reset(d time.Duration) { t.t.Reset(d) }
+
+// FakeClock 可控的 Clock，时间只在调用 Advance 或 Set 时前进，用于编写确定性的测试
+// Timer、Ticker 和 After 在时间推进到触发时刻时同步触发，AfterFunc 的函数在 Advance 的 goroutine 中执行
+type FakeClock struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+// fakeWaiter 一个等待触发的 Timer、Ticker 或 AfterFunc
+type fakeWaiter struct {
+	clock  *FakeClock
+	at     time.Time
+	period time.Duration // 大于 0 时为 Ticker
+	ch     chan time.Time
+	fn     func()
+}
+
+// NewFakeClock 创建一个当前时间为 now 的 FakeClock
+func NewFakeClock(now time.Time) *FakeClock {
+	c := &FakeClock{now: now}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Now 实现 Clock
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Since 实现 Clock
+func (c *FakeClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// After 实现 Clock
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C()
+}
+
+// NewTimer 实现 Clock
+func (c *FakeClock) NewTimer(d time.Duration) Timer {
+	w := &fakeWaiter{clock: c, ch: make(chan time.Time, 1)}
+	c.schedule(w, d)
+	return w
+}
+
+// NewTicker 实现 Clock，d <= 0 时 panic
+func (c *FakeClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("timeutils: non-positive interval for NewTicker")
+	}
+	w := &fakeWaiter{clock: c, period: d, ch: make(chan time.Time, 1)}
+	c.schedule(w, d)
+	return fakeTicker{w}
+}
+
+// AfterFunc 实现 Clock
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) Timer {
+	w := &fakeWaiter{clock: c, fn: f}
+	c.schedule(w, d)
+	return w
+}
+
+// Advance 将时间推进 d，并按触发时刻的先后依次触发到期的 Timer、Ticker 和 AfterFunc
+func (c *FakeClock) Advance(d time.Duration) {
+	c.Set(c.Now().Add(d))
+}
+
+// Set 将时间设置为 t 并触发到期的等待者，t 早于当前时间时只修改时间
+func (c *FakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	for {
+		w := c.nextDueLocked(t)
+		if w == nil {
+			break
+		}
+		if w.at.After(c.now) {
+			c.now = w.at
+		}
+		if w.period > 0 {
+			w.at = w.at.Add(w.period)
+		} else {
+			c.removeLocked(w)
+		}
+		if w.fn != nil {
+			// 释放锁后执行，允许 f 再次使用时钟
+			c.mu.Unlock()
+			w.fn()
+			c.mu.Lock()
+			continue
+		}
+		select {
+		case w.ch <- c.now:
+		default:
+		}
+	}
+	c.now = t
+	c.mu.Unlock()
+}
+
+// BlockUntil 阻塞直到至少有 n 个未触发的 Timer、Ticker 或 AfterFunc
+// 用于在 Advance 之前等待被测代码进入等待状态，避免推进时间时对方还没有创建定时器
+func (c *FakeClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.waiters) < n {
+		c.cond.Wait()
+	}
+}
+
+// Waiters 返回未触发的 Timer、Ticker 和 AfterFunc 的数量
+func (c *FakeClock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
+
+// schedule 让 w 在 d 之后触发，d <= 0 时立即触发（AfterFunc 的函数在新的 goroutine 中执行）
+func (c *FakeClock) schedule(w *fakeWaiter, d time.Duration) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	active := c.removeLocked(w)
+	if d <= 0 {
+		if w.fn != nil {
+			go w.fn()
+		} else {
+			select {
+			case w.ch <- c.now:
+			default:
+			}
+		}
+		return active
+	}
+	w.at = c.now.Add(d)
+	c.waiters = append(c.waiters, w)
+	c.cond.Broadcast()
+	return active
+}
+
+// nextDueLocked 返回触发时刻不晚于 t 的最早的等待者
+func (c *FakeClock) nextDueLocked(t time.Time) *fakeWaiter {
+	var next *fakeWaiter
+	for _, w := range c.waiters {
+		if !w.at.After(t) && (next == nil || w.at.Before(next.at)) {
+			next = w
+		}
+	}
+	return next
+}
+
+// removeLocked 移除 w，返回 w 是否仍在等待
+func (c *FakeClock) removeLocked(w *fakeWaiter) bool {
+	i := slices.Index(c.waiters, w)
+	if i < 0 {
+		return false
+	}
+	c.waiters = slices.Delete(c.waiters, i, i+1)
+	return true
+}
+
+// C 实现 Timer
+func (w *fakeWaiter) C() <-chan time.Time {
+	return w.ch
+}
+
+// Stop 实现 Timer，返回 w 是否在触发前被停止
+func (w *fakeWaiter) Stop() bool {
+	w.clock.mu.Lock()
+	defer w.clock.mu.Unlock()
+	return w.clock.removeLocked(w)
+}
+
+// Reset 实现 Timer
+func (w *fakeWaiter) Reset(d time.Duration) bool {
+	return w.clock.schedule(w, d)
+}
+
+// fakeTicker FakeClock 的 Ticker
+type fakeTicker struct {
+	*fakeWaiter
+}
+
+// Stop 实现 Ticker
+func (t fakeTicker) Stop() {
+	t.fakeWaiter.Stop()
+}
+
+// Reset 实现 Ticker，同时将间隔修改为 d，d <= 0 时 panic
+func (t fakeTicker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("timeutils: non-positive interval for Ticker.Reset")
+	}
+	t.clock.mu.Lock()
+	t.period = d
+	t.clock.mu.Unlock()
+	t.clock.schedule(t.fakeWaiter, d)
+}
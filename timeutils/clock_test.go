@@ -0,0 +1,167 @@
+package timeutils
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// received 报告 ch 中是否有可以立即接收的值
+func received(ch <-chan time.Time) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestRealClock(t *testing.T) {
+	c := RealClock()
+	start := c.Now()
+	<-c.After(time.Millisecond)
+	if c.Since(start) < time.Millisecond {
+		t.Errorf("Since() = %v, 期望至少 %v", c.Since(start), time.Millisecond)
+	}
+	timer := c.NewTimer(time.Hour)
+	if !timer.Stop() {
+		t.Errorf("Timer.Stop() = false, 期望 true")
+	}
+	ticker := c.NewTicker(time.Millisecond)
+	<-ticker.C()
+	ticker.Stop()
+	if OrRealClock(nil) == nil {
+		t.Errorf("OrRealClock(nil) = nil, 期望 RealClock")
+	}
+}
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("推进时间", func(t *testing.T) {
+		c := NewFakeClock(start)
+		c.Advance(time.Hour)
+		if got := c.Now(); !got.Equal(start.Add(time.Hour)) {
+			t.Errorf("Now() = %v, 期望 %v", got, start.Add(time.Hour))
+		}
+		if got := c.Since(start); got != time.Hour {
+			t.Errorf("Since() = %v, 期望 %v", got, time.Hour)
+		}
+	})
+
+	t.Run("Timer", func(t *testing.T) {
+		c := NewFakeClock(start)
+		timer := c.NewTimer(10 * time.Second)
+		c.Advance(9 * time.Second)
+		if received(timer.C()) {
+			t.Fatalf("Timer 提前触发")
+		}
+		c.Advance(time.Second)
+		select {
+		case got := <-timer.C():
+			if !got.Equal(start.Add(10 * time.Second)) {
+				t.Errorf("触发时间 = %v, 期望 %v", got, start.Add(10*time.Second))
+			}
+		default:
+			t.Fatalf("Timer 未触发")
+		}
+		if timer.Stop() {
+			t.Errorf("已触发的 Timer.Stop() = true, 期望 false")
+		}
+		if timer.Reset(time.Second) {
+			t.Errorf("已触发的 Timer.Reset() = true, 期望 false")
+		}
+		c.Advance(time.Second)
+		if !received(timer.C()) {
+			t.Errorf("Reset 后 Timer 未触发")
+		}
+	})
+
+	t.Run("停止的 Timer 不触发", func(t *testing.T) {
+		c := NewFakeClock(start)
+		timer := c.NewTimer(time.Second)
+		if !timer.Stop() {
+			t.Errorf("Timer.Stop() = false, 期望 true")
+		}
+		c.Advance(time.Minute)
+		if received(timer.C()) || c.Waiters() != 0 {
+			t.Errorf("停止的 Timer 被触发或仍在等待")
+		}
+	})
+
+	t.Run("After 与零时长", func(t *testing.T) {
+		c := NewFakeClock(start)
+		ch := c.After(time.Second)
+		c.Advance(time.Second)
+		if !received(ch) {
+			t.Errorf("After() 未触发")
+		}
+		if !received(c.After(0)) {
+			t.Errorf("After(0) 未立即触发")
+		}
+	})
+
+	t.Run("Ticker", func(t *testing.T) {
+		c := NewFakeClock(start)
+		ticker := c.NewTicker(time.Second)
+		var ticks int
+		for range 3 {
+			c.Advance(time.Second)
+			if received(ticker.C()) {
+				ticks++
+			}
+		}
+		if ticks != 3 {
+			t.Errorf("触发次数 = %v, 期望 %v", ticks, 3)
+		}
+		// 一次推进多个周期时与 time.Ticker 一样丢弃来不及接收的触发
+		c.Advance(5 * time.Second)
+		if !received(ticker.C()) || received(ticker.C()) {
+			t.Errorf("一次推进多个周期应只保留一次触发")
+		}
+		ticker.Reset(time.Minute)
+		c.Advance(time.Second)
+		if received(ticker.C()) {
+			t.Errorf("Reset 后按旧间隔触发")
+		}
+		ticker.Stop()
+		c.Advance(time.Hour)
+		if received(ticker.C()) {
+			t.Errorf("停止的 Ticker 被触发")
+		}
+	})
+
+	t.Run("AfterFunc 按时间顺序执行", func(t *testing.T) {
+		c := NewFakeClock(start)
+		var order []int
+		c.AfterFunc(2*time.Second, func() { order = append(order, 2) })
+		c.AfterFunc(time.Second, func() {
+			order = append(order, 1)
+			// 回调中可以再次使用时钟
+			c.AfterFunc(500*time.Millisecond, func() { order = append(order, 15) })
+		})
+		cancelled := c.AfterFunc(time.Second, func() { order = append(order, -1) })
+		cancelled.Stop()
+		c.Advance(3 * time.Second)
+		if len(order) != 3 || order[0] != 1 || order[1] != 15 || order[2] != 2 {
+			t.Errorf("执行顺序 = %v, 期望 [1 15 2]", order)
+		}
+	})
+
+	t.Run("BlockUntil", func(t *testing.T) {
+		c := NewFakeClock(start)
+		var fired atomic.Bool
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			<-c.After(time.Minute)
+			fired.Store(true)
+		}()
+		c.BlockUntil(1)
+		c.Advance(time.Minute)
+		<-done
+		if !fired.Load() {
+			t.Errorf("BlockUntil 之后推进时间未唤醒等待者")
+		}
+	})
+}
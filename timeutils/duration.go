@@ -0,0 +1,231 @@
+package timeutils
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// Day 一天，按 24 小时计算，不考虑夏令时
+	Day = 24 * time.Hour
+	// Week 一周，按 7 天计算
+	Week = 7 * Day
+)
+
+// ErrInvalidDuration 无法解析的时长字符串
+var ErrInvalidDuration = errors.New("timeutils: invalid duration")
+
+// durationUnit 格式化时使用的时间单位
+type durationUnit struct {
+	d        time.Duration
+	short    string
+	singular string
+	plural   string
+}
+
+// formatUnits 格式化使用的单位，从大到小排列
+var formatUnits = []durationUnit{
+	{Day, "d", "day", "days"},
+	{time.Hour, "h", "hour", "hours"},
+	{time.Minute, "m", "minute", "minutes"},
+	{time.Second, "s", "second", "seconds"},
+	{time.Millisecond, "ms", "millisecond", "milliseconds"},
+}
+
+// formatConfig FormatDuration 的配置
+type formatConfig struct {
+	maxUnits    int
+	approximate bool
+}
+
+// FormatOption FormatDuration 的可选配置项
+type FormatOption func(*formatConfig)
+
+// WithMaxUnits 最多输出 n 个单位，其余部分被舍去，例如 n 为 2 时 "2h 3m 10s" 输出为 "2h 3m"
+// 默认为 0，表示不限制
+func WithMaxUnits(n int) FormatOption {
+	return func(c *formatConfig) {
+		c.maxUnits = n
+	}
+}
+
+// WithApproximate 输出约数形式，只保留最大的单位并四舍五入，例如 "about 2 hours"
+// 恰好为整数个单位时不带 "about"，例如 "2 hours"
+func WithApproximate() FormatOption {
+	return func(c *formatConfig) {
+		c.approximate = true
+	}
+}
+
+// FormatDuration 将时长格式化为便于阅读的形式，例如 "2h 3m 10s"、"1d 4h"
+// 天按 24 小时计算；不足一秒的部分只在整个时长小于一秒时以毫秒输出；负数带 "-" 前缀，0 输出为 "0s"
+func FormatDuration(d time.Duration, opts ...FormatOption) string {
+	var cfg formatConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		if d == math.MinInt64 {
+			d = math.MaxInt64
+		} else {
+			d = -d
+		}
+	}
+	if cfg.approximate {
+		return sign + approximateDuration(d)
+	}
+
+	units := formatUnits
+	if d >= time.Second {
+		units = units[:len(units)-1]
+	}
+	var parts []string
+	for _, u := range units {
+		if cfg.maxUnits > 0 && len(parts) >= cfg.maxUnits {
+			break
+		}
+		if n := d / u.d; n > 0 {
+			parts = append(parts, strconv.FormatInt(int64(n), 10)+u.short)
+			d -= n * u.d
+		}
+	}
+	if len(parts) == 0 {
+		return "0s"
+	}
+	return sign + strings.Join(parts, " ")
+}
+
+// approximateDuration 用最大的单位四舍五入表示 d
+func approximateDuration(d time.Duration) string {
+	if d < time.Second {
+		return "less than a second"
+	}
+	for _, u := range formatUnits {
+		if d < u.d {
+			continue
+		}
+		n := int64(math.Round(float64(d) / float64(u.d)))
+		name := u.plural
+		if n == 1 {
+			name = u.singular
+		}
+		s := strconv.FormatInt(n, 10) + " " + name
+		if d%u.d != 0 {
+			s = "about " + s
+		}
+		return s
+	}
+	return "less than a second"
+}
+
+// parseUnits ParseHumanDuration 支持的单位
+var parseUnits = map[string]time.Duration{
+	"ns": time.Nanosecond,
+	"us": time.Microsecond, "µs": time.Microsecond, "μs": time.Microsecond,
+	"ms": time.Millisecond,
+	"s":  time.Second, "sec": time.Second, "secs": time.Second, "second": time.Second, "seconds": time.Second,
+	"m": time.Minute, "min": time.Minute, "mins": time.Minute, "minute": time.Minute, "minutes": time.Minute,
+	"h": time.Hour, "hr": time.Hour, "hrs": time.Hour, "hour": time.Hour, "hours": time.Hour,
+	"d": Day, "day": Day, "days": Day,
+	"w": Week, "week": Week, "weeks": Week,
+}
+
+// ParseHumanDuration 解析便于阅读的时长字符串，在 time.ParseDuration 的基础上支持天（d）和周（w）
+// 以及单位的完整写法和空格，例如 "1d2h30m"、"1w 2d"、"1.5h"、"3 days 4 hours"
+// 天按 24 小时、周按 7 天计算；与 time.ParseDuration 一样，不带单位的 "0" 表示 0
+// 无法解析或溢出时返回 ErrInvalidDuration
+func ParseHumanDuration(s string) (time.Duration, error) {
+	orig := s
+	s = strings.TrimSpace(s)
+	neg := false
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	if s == "" {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidDuration, orig)
+	}
+	if s == "0" {
+		return 0, nil
+	}
+
+	// 按纳秒以 uint64 累加，上限为 1<<63 以便表示 math.MinInt64
+	const limit = 1 << 63
+	var total uint64
+	for s != "" {
+		s = strings.TrimLeftFunc(s, unicode.IsSpace)
+		i := 0
+		for i < len(s) && (s[i] == '.' || ('0' <= s[i] && s[i] <= '9')) {
+			i++
+		}
+		intPart, fracPart, _ := strings.Cut(s[:i], ".")
+		if (intPart == "" && fracPart == "") || strings.Contains(fracPart, ".") {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidDuration, orig)
+		}
+		s = strings.TrimLeftFunc(s[i:], unicode.IsSpace)
+
+		j := 0
+		for j < len(s) && !unicode.IsSpace(rune(s[j])) && (s[j] < '0' || s[j] > '9') && s[j] != '.' {
+			j++
+		}
+		unit, ok := parseUnits[strings.ToLower(s[:j])]
+		if !ok {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidDuration, orig)
+		}
+		s = strings.TrimLeftFunc(s[j:], unicode.IsSpace)
+
+		v, ok := scaleDuration(intPart, fracPart, uint64(unit))
+		if !ok || v > limit-total {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidDuration, orig)
+		}
+		total += v
+	}
+	if total == limit && !neg || total > limit {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidDuration, orig)
+	}
+	d := time.Duration(total) // total == 1<<63 时为 math.MinInt64，此时 neg 必为 true
+	if neg {
+		d = -d
+	}
+	return d, nil
+}
+
+// scaleDuration 计算十进制数 intPart.fracPart 乘以 unit 纳秒的结果，整数部分精确计算，
+// 小数部分四舍五入到纳秒；结果超过 1<<63 时返回 false
+func scaleDuration(intPart, fracPart string, unit uint64) (uint64, bool) {
+	const limit = 1 << 63
+	var v uint64
+	if intPart != "" {
+		n, err := strconv.ParseUint(intPart, 10, 64)
+		if err != nil || n > limit/unit {
+			return 0, false
+		}
+		v = n * unit
+	}
+	// 小数部分只取不会溢出的前若干位，更低的位对纳秒结果没有影响
+	var frac uint64
+	scale := 1.0
+	for _, c := range fracPart {
+		if frac > (math.MaxUint64-9)/10 {
+			break
+		}
+		frac = frac*10 + uint64(c-'0')
+		scale *= 10
+	}
+	if frac > 0 {
+		extra := uint64(math.Round(float64(frac) * (float64(unit) / scale)))
+		if extra > limit-v {
+			return 0, false
+		}
+		v += extra
+	}
+	return v, true
+}
@@ -0,0 +1,88 @@
+package timeutils
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		name string
+		d    time.Duration
+		opts []FormatOption
+		want string
+	}{
+		{"时分秒", 2*time.Hour + 3*time.Minute + 10*time.Second, nil, "2h 3m 10s"},
+		{"天", Day + 4*time.Hour, nil, "1d 4h"},
+		{"跳过为零的单位", time.Hour + 5*time.Second, nil, "1h 5s"},
+		{"舍去毫秒", 1500 * time.Millisecond, nil, "1s"},
+		{"不足一秒", 250 * time.Millisecond, nil, "250ms"},
+		{"零", 0, nil, "0s"},
+		{"负数", -90 * time.Second, nil, "-1m 30s"},
+		{"限制单位数", 2*time.Hour + 3*time.Minute + 10*time.Second, []FormatOption{WithMaxUnits(2)}, "2h 3m"},
+		{"约数", 2*time.Hour + 3*time.Minute, []FormatOption{WithApproximate()}, "about 2 hours"},
+		{"约数进位", 90 * time.Minute, []FormatOption{WithApproximate()}, "about 2 hours"},
+		{"整数不带 about", 3 * Day, []FormatOption{WithApproximate()}, "3 days"},
+		{"单数", time.Minute, []FormatOption{WithApproximate()}, "1 minute"},
+		{"约数不足一秒", time.Millisecond, []FormatOption{WithApproximate()}, "less than a second"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatDuration(tt.d, tt.opts...); got != tt.want {
+				t.Errorf("FormatDuration(%v) = %q, 期望 %q", tt.d, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseHumanDuration(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"天时分", "1d2h30m", Day + 2*time.Hour + 30*time.Minute, false},
+		{"周和天", "1w 2d", 9 * Day, false},
+		{"小数", "1.5h", 90 * time.Minute, false},
+		{"完整单位", "3 days 4 hours", 3*Day + 4*time.Hour, false},
+		{"大小写", "2H", 2 * time.Hour, false},
+		{"毫秒", "1s500ms", 1500 * time.Millisecond, false},
+		{"负数", "-1d", -Day, false},
+		{"与 FormatDuration 互逆", "2h 3m 10s", 2*time.Hour + 3*time.Minute + 10*time.Second, false},
+		{"空字符串", "", 0, true},
+		{"不带单位的 0", "0", 0, false},
+		{"带符号的 0", "-0", 0, false},
+		{"缺少单位", "10", 0, true},
+		{"不带单位的 0.0", "0.0", 0, true},
+		{"未知单位", "3 fortnights", 0, true},
+		{"缺少数字", "h", 0, true},
+		{"溢出", "1000000w", 0, true},
+		{"大数值不丢失精度", "200d1ns", 200*Day + 1, false},
+		{"小数秒", ".25s", 250 * time.Millisecond, false},
+		{"最大值", "106751d23h47m16s854775807ns", math.MaxInt64, false},
+		{"最大值的小数写法", "106751d23h47m16.854775807s", math.MaxInt64, false},
+		{"最小值", "-106751d23h47m16s854775808ns", math.MinInt64, false},
+		{"超过最大值 1ns", "106751d23h47m16s854775808ns", 0, true},
+		{"超过最大值的小数写法", "106751d23h47m16.854775808s", 0, true},
+		{"低于最小值", "-106751d23h47m16s854775809ns", 0, true},
+		{"多个小数点", "1.2.3s", 0, true},
+		{"只有小数点", ".s", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseHumanDuration(tt.s)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidDuration) {
+					t.Errorf("ParseHumanDuration(%q) 错误 = %v, 期望 %v", tt.s, err, ErrInvalidDuration)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ParseHumanDuration(%q) = %v, %v, 期望 %v", tt.s, got, err, tt.want)
+			}
+		})
+	}
+}
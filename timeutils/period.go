@@ -0,0 +1,95 @@
+package timeutils
+
+import "time"
+
+// 以下函数先将 t 转换到 loc 时区再计算，loc 为 nil 时使用 t 自身的时区
+// 边界通过 time.Date 按日历计算而不是加减固定时长，夏令时切换当天（23 或 25 小时）也能得到正确结果
+// 若某天的零点因夏令时不存在，以当天最早的有效时刻作为该天的开始
+// EndOf 系列返回周期内最后一纳秒，需要半开区间时使用下一个周期的 StartOf
+
+// inLocation 将 t 转换到 loc 时区
+func inLocation(t time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		return t
+	}
+	return t.In(loc)
+}
+
+// midnight 返回 loc 时区中 y-m-d 当天最早的有效时刻，通常为零点
+// 零点因夏令时不存在时 time.Date 可能归一化到前一天，此时取夏令时切换的时刻
+func midnight(y int, m time.Month, d int, loc *time.Location) time.Time {
+	t := time.Date(y, m, d, 0, 0, 0, 0, loc)
+	// 落在前一天的晚上说明被归一化到了切换之前
+	if t.Hour() >= 12 {
+		if _, end := t.ZoneBounds(); !end.IsZero() {
+			t = end
+		}
+	}
+	return t
+}
+
+// StartOfDay 返回 t 所在日的零点
+func StartOfDay(t time.Time, loc *time.Location) time.Time {
+	t = inLocation(t, loc)
+	y, m, d := t.Date()
+	return midnight(y, m, d, t.Location())
+}
+
+// EndOfDay 返回 t 所在日的最后一纳秒
+func EndOfDay(t time.Time, loc *time.Location) time.Time {
+	t = inLocation(t, loc)
+	y, m, d := t.Date()
+	return midnight(y, m, d+1, t.Location()).Add(-time.Nanosecond)
+}
+
+// StartOfWeek 返回 t 所在周的第一天零点，weekStart 指定一周从星期几开始，例如 time.Monday
+func StartOfWeek(t time.Time, loc *time.Location, weekStart time.Weekday) time.Time {
+	t = inLocation(t, loc)
+	y, m, d := t.Date()
+	offset := (int(t.Weekday()) - int(weekStart) + 7) % 7
+	return midnight(y, m, d-offset, t.Location())
+}
+
+// EndOfWeek 返回 t 所在周的最后一纳秒，weekStart 的含义同 StartOfWeek
+func EndOfWeek(t time.Time, loc *time.Location, weekStart time.Weekday) time.Time {
+	start := StartOfWeek(t, loc, weekStart)
+	y, m, d := start.Date()
+	return midnight(y, m, d+7, start.Location()).Add(-time.Nanosecond)
+}
+
+// StartOfMonth 返回 t 所在月的第一天零点
+func StartOfMonth(t time.Time, loc *time.Location) time.Time {
+	t = inLocation(t, loc)
+	return midnight(t.Year(), t.Month(), 1, t.Location())
+}
+
+// EndOfMonth 返回 t 所在月的最后一纳秒
+func EndOfMonth(t time.Time, loc *time.Location) time.Time {
+	t = inLocation(t, loc)
+	return midnight(t.Year(), t.Month()+1, 1, t.Location()).Add(-time.Nanosecond)
+}
+
+// StartOfQuarter 返回 t 所在季度的第一天零点
+func StartOfQuarter(t time.Time, loc *time.Location) time.Time {
+	t = inLocation(t, loc)
+	m := (t.Month()-1)/3*3 + 1
+	return midnight(t.Year(), m, 1, t.Location())
+}
+
+// EndOfQuarter 返回 t 所在季度的最后一纳秒
+func EndOfQuarter(t time.Time, loc *time.Location) time.Time {
+	start := StartOfQuarter(t, loc)
+	return midnight(start.Year(), start.Month()+3, 1, start.Location()).Add(-time.Nanosecond)
+}
+
+// StartOfYear 返回 t 所在年的第一天零点
+func StartOfYear(t time.Time, loc *time.Location) time.Time {
+	t = inLocation(t, loc)
+	return midnight(t.Year(), time.January, 1, t.Location())
+}
+
+// EndOfYear 返回 t 所在年的最后一纳秒
+func EndOfYear(t time.Time, loc *time.Location) time.Time {
+	t = inLocation(t, loc)
+	return midnight(t.Year()+1, time.January, 1, t.Location()).Add(-time.Nanosecond)
+}
@@ -0,0 +1,99 @@
+package timeutils
+
+import (
+	"testing"
+	"time"
+)
+
+// loadLocation 加载时区，系统缺少时区数据时跳过测试
+func loadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("缺少时区数据 %s: %v", name, err)
+	}
+	return loc
+}
+
+func TestStartEndOf(t *testing.T) {
+	// 2024-05-15 是星期三
+	ts := time.Date(2024, 5, 15, 13, 45, 30, 123, time.UTC)
+	date := func(y int, m time.Month, d, h, min, s, ns int) time.Time {
+		return time.Date(y, m, d, h, min, s, ns, time.UTC)
+	}
+	tests := []struct {
+		name string
+		got  time.Time
+		want time.Time
+	}{
+		{"日开始", StartOfDay(ts, nil), date(2024, 5, 15, 0, 0, 0, 0)},
+		{"日结束", EndOfDay(ts, nil), date(2024, 5, 15, 23, 59, 59, 999999999)},
+		{"周一开始的周", StartOfWeek(ts, nil, time.Monday), date(2024, 5, 13, 0, 0, 0, 0)},
+		{"周一开始的周结束", EndOfWeek(ts, nil, time.Monday), date(2024, 5, 19, 23, 59, 59, 999999999)},
+		{"周日开始的周", StartOfWeek(ts, nil, time.Sunday), date(2024, 5, 12, 0, 0, 0, 0)},
+		{"周起始日当天", StartOfWeek(ts, nil, time.Wednesday), date(2024, 5, 15, 0, 0, 0, 0)},
+		{"跨月的周", StartOfWeek(date(2024, 6, 1, 8, 0, 0, 0), nil, time.Monday), date(2024, 5, 27, 0, 0, 0, 0)},
+		{"月开始", StartOfMonth(ts, nil), date(2024, 5, 1, 0, 0, 0, 0)},
+		{"月结束", EndOfMonth(ts, nil), date(2024, 5, 31, 23, 59, 59, 999999999)},
+		{"闰年二月结束", EndOfMonth(date(2024, 2, 10, 0, 0, 0, 0), nil), date(2024, 2, 29, 23, 59, 59, 999999999)},
+		{"季度开始", StartOfQuarter(ts, nil), date(2024, 4, 1, 0, 0, 0, 0)},
+		{"季度结束", EndOfQuarter(ts, nil), date(2024, 6, 30, 23, 59, 59, 999999999)},
+		{"第四季度结束", EndOfQuarter(date(2024, 11, 1, 0, 0, 0, 0), nil), date(2024, 12, 31, 23, 59, 59, 999999999)},
+		{"年开始", StartOfYear(ts, nil), date(2024, 1, 1, 0, 0, 0, 0)},
+		{"年结束", EndOfYear(ts, nil), date(2024, 12, 31, 23, 59, 59, 999999999)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.got.Equal(tt.want) {
+				t.Errorf("结果 = %v, 期望 %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStartOfDayTimezone(t *testing.T) {
+	shanghai := loadLocation(t, "Asia/Shanghai")
+	// UTC 的 5 月 15 日 20:00 已是上海的 5 月 16 日 04:00
+	ts := time.Date(2024, 5, 15, 20, 0, 0, 0, time.UTC)
+	got := StartOfDay(ts, shanghai)
+	want := time.Date(2024, 5, 16, 0, 0, 0, 0, shanghai)
+	if !got.Equal(want) || got.Location() != shanghai {
+		t.Errorf("StartOfDay() = %v, 期望 %v", got, want)
+	}
+}
+
+func TestStartEndOfDST(t *testing.T) {
+	ny := loadLocation(t, "America/New_York")
+	tests := []struct {
+		name string
+		day  time.Time
+		want time.Duration
+	}{
+		// 2024-03-10 夏令时开始，当天只有 23 小时
+		{"夏令时开始", time.Date(2024, 3, 10, 12, 0, 0, 0, ny), 23 * time.Hour},
+		// 2024-11-03 夏令时结束，当天有 25 小时
+		{"夏令时结束", time.Date(2024, 11, 3, 12, 0, 0, 0, ny), 25 * time.Hour},
+		{"普通日", time.Date(2024, 5, 15, 12, 0, 0, 0, ny), 24 * time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := StartOfDay(tt.day, nil), EndOfDay(tt.day, nil)
+			if start.Hour() != 0 || start.Day() != tt.day.Day() {
+				t.Errorf("StartOfDay() = %v, 期望当天零点", start)
+			}
+			if got := end.Sub(start) + time.Nanosecond; got != tt.want {
+				t.Errorf("当天时长 = %v, 期望 %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStartOfDayMissingMidnight(t *testing.T) {
+	saoPaulo := loadLocation(t, "America/Sao_Paulo")
+	// 2018-11-04 圣保罗零点直接跳到 01:00
+	ts := time.Date(2018, 11, 4, 12, 0, 0, 0, saoPaulo)
+	got := StartOfDay(ts, nil)
+	if got.Day() != 4 || got.Hour() != 1 {
+		t.Errorf("StartOfDay() = %v, 期望 2018-11-04 01:00", got)
+	}
+}
@@ -0,0 +1,291 @@
+package timeutils
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidSchedule 无法解析的调度表达式
+var ErrInvalidSchedule = errors.New("timeutils: invalid schedule")
+
+// Schedule 调度计划，Next 返回严格晚于 after 的下一次执行时间，不再执行时返回零值
+type Schedule interface {
+	Next(after time.Time) time.Time
+}
+
+// ScheduleFunc 函数形式的 Schedule
+type ScheduleFunc func(after time.Time) time.Time
+
+// Next 实现 Schedule
+func (f ScheduleFunc) Next(after time.Time) time.Time {
+	return f(after)
+}
+
+// CronSchedule 由 cron 表达式描述的调度计划，精度为分钟
+type CronSchedule struct {
+	expr   string
+	minute uint64 // 第 i 位表示第 i 分钟，下同
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+	// 日和星期都被限制时，满足其一即可，否则两者都要满足
+	// 与 cron 一致，以 * 开头的字段（包括 */n）视为不限制
+	domStar, dowStar bool
+	loc              *time.Location
+}
+
+// cronField cron 表达式中一个字段的取值范围
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = [5]cronField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// cronDescriptors 预定义的 cron 表达式
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// weekdayNames 星期的名称和缩写
+var weekdayNames = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+// ParseSchedule 解析调度表达式，支持以下形式（不区分大小写）：
+//   - 5 个字段的 cron 表达式 "分 时 日 月 星期"，每个字段支持 *、数字、范围 a-b、列表 a,b 和步长 */n、a-b/n
+//   - 预定义表达式 @yearly、@monthly、@weekly、@daily、@midnight、@hourly
+//   - 简单的英文描述，如 "every Monday 09:00"、"daily at 02:30"、"every day at 8:00"、
+//     "every weekday at 9:00"、"hourly"、"every 15 minutes"、"every 2 hours"；
+//     "every N minutes" 和 "every N hours" 要求 N 能整除 60 或 24，以保证间隔固定
+//
+// loc 为计算执行时间使用的时区，为 nil 时使用 Next 参数自身的时区
+func ParseSchedule(expr string, loc *time.Location) (*CronSchedule, error) {
+	spec, err := normalizeSchedule(expr)
+	if err != nil {
+		return nil, err
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("%w: %q: expected %d fields", ErrInvalidSchedule, expr, len(cronFields))
+	}
+
+	var bits [5]uint64
+	for i, f := range fields {
+		b, err := parseCronField(f, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %v", ErrInvalidSchedule, expr, err)
+		}
+		bits[i] = b
+	}
+	// 星期中的 7 与 0 都表示星期日
+	if bits[4]&(1<<7) != 0 {
+		bits[4] = bits[4]&^(1<<7) | 1
+	}
+	return &CronSchedule{
+		expr:    expr,
+		minute:  bits[0],
+		hour:    bits[1],
+		dom:     bits[2],
+		month:   bits[3],
+		dow:     bits[4],
+		domStar: strings.HasPrefix(fields[2], "*"),
+		dowStar: strings.HasPrefix(fields[4], "*"),
+		loc:     loc,
+	}, nil
+}
+
+// MustParseSchedule 与 ParseSchedule 相同，解析失败时 panic，用于初始化包级变量
+func MustParseSchedule(expr string, loc *time.Location) *CronSchedule {
+	s, err := ParseSchedule(expr, loc)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// normalizeSchedule 将预定义表达式和英文描述转换为 cron 表达式
+func normalizeSchedule(expr string) (string, error) {
+	s := strings.ToLower(strings.TrimSpace(expr))
+	if spec, ok := cronDescriptors[s]; ok {
+		return spec, nil
+	}
+	var words []string
+	for _, w := range strings.Fields(s) {
+		if w != "at" {
+			words = append(words, w)
+		}
+	}
+	if len(words) == 0 || (words[0] != "every" && words[0] != "daily" && words[0] != "hourly") {
+		return s, nil
+	}
+
+	invalid := fmt.Errorf("%w: %q", ErrInvalidSchedule, expr)
+	// clock 解析可选的 "HH:MM"，缺省为 00:00
+	clock := func(rest []string) (string, error) {
+		switch len(rest) {
+		case 0:
+			return "0 0", nil
+		case 1:
+			h, m, ok := strings.Cut(rest[0], ":")
+			hour, err1 := strconv.Atoi(h)
+			minute, err2 := strconv.Atoi(m)
+			if !ok || err1 != nil || err2 != nil || hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+				return "", invalid
+			}
+			return fmt.Sprintf("%d %d", minute, hour), nil
+		}
+		return "", invalid
+	}
+
+	switch {
+	case words[0] == "hourly" && len(words) == 1:
+		return "0 * * * *", nil
+	case words[0] == "daily":
+		hm, err := clock(words[1:])
+		return hm + " * * *", err
+	case len(words) < 2:
+		return "", invalid
+	}
+
+	// every ...
+	switch w := words[1]; {
+	case w == "minute" && len(words) == 2:
+		return "* * * * *", nil
+	case w == "hour" && len(words) == 2:
+		return "0 * * * *", nil
+	case w == "day":
+		hm, err := clock(words[2:])
+		return hm + " * * *", err
+	case w == "weekday" || w == "weekdays":
+		hm, err := clock(words[2:])
+		return hm + " * * 1-5", err
+	case len(words) == 3 && (words[2] == "minutes" || words[2] == "hours"):
+		// 转换为 */n，n 不能整除 60（或 24）时跨小时（或跨天）的间隔会变短，因此拒绝
+		n, err := strconv.Atoi(w)
+		period := 60
+		if words[2] == "hours" {
+			period = 24
+		}
+		if err != nil || n <= 0 || period%n != 0 {
+			return "", invalid
+		}
+		if words[2] == "minutes" {
+			return fmt.Sprintf("*/%d * * * *", n), nil
+		}
+		return fmt.Sprintf("0 */%d * * *", n), nil
+	}
+	day, ok := weekdayNames[strings.TrimSuffix(words[1], "s")]
+	if !ok {
+		return "", invalid
+	}
+	hm, err := clock(words[2:])
+	return fmt.Sprintf("%s * * %d", hm, day), err
+}
+
+// parseCronField 解析 cron 表达式的一个字段，返回取值的位集合
+func parseCronField(field string, f cronField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+		lo, hi := f.min, f.max
+		if rng != "*" {
+			a, b, isRange := strings.Cut(rng, "-")
+			var err error
+			if lo, err = strconv.Atoi(a); err != nil {
+				return 0, fmt.Errorf("%s: bad value %q", f.name, part)
+			}
+			hi = lo
+			if isRange {
+				if hi, err = strconv.Atoi(b); err != nil {
+					return 0, fmt.Errorf("%s: bad value %q", f.name, part)
+				}
+			} else if hasStep {
+				hi = f.max
+			}
+		}
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepStr); err != nil || step <= 0 {
+				return 0, fmt.Errorf("%s: bad step %q", f.name, part)
+			}
+		}
+		if lo < f.min || hi > f.max || lo > hi {
+			return 0, fmt.Errorf("%s: %q out of range [%d, %d]", f.name, part, f.min, f.max)
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+// Next 实现 Schedule，返回严格晚于 after 的下一次执行时间，五年内没有匹配的时间（如 2 月 30 日）时返回零值
+// 夏令时切换导致不存在的时刻会被跳过，重复出现的时刻两次都会匹配
+func (s *CronSchedule) Next(after time.Time) time.Time {
+	loc := s.loc
+	if loc == nil {
+		loc = after.Location()
+	}
+	t := after.In(loc).Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = midnight(t.Year(), t.Month()+1, 1, loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = midnight(t.Year(), t.Month(), t.Day()+1, loc)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = t.Add(time.Duration(60-t.Minute()) * time.Minute)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches 报告 t 所在日是否满足日和星期字段
+func (s *CronSchedule) dayMatches(t time.Time) bool {
+	domOK := s.dom&(1<<uint(t.Day())) != 0
+	dowOK := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domOK && dowOK
+	}
+	return domOK || dowOK
+}
+
+// String 返回原始的调度表达式
+func (s *CronSchedule) String() string {
+	return s.expr
+}
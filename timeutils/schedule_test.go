@@ -0,0 +1,133 @@
+package timeutils
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestScheduleNext(t *testing.T) {
+	// 2024-05-15 10:20:30 是星期三
+	after := time.Date(2024, 5, 15, 10, 20, 30, 0, time.UTC)
+	date := func(m time.Month, d, h, min int) time.Time {
+		return time.Date(2024, m, d, h, min, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name string
+		expr string
+		want time.Time
+	}{
+		{"每分钟", "* * * * *", date(5, 15, 10, 21)},
+		{"每小时", "@hourly", date(5, 15, 11, 0)},
+		{"每天", "@daily", date(5, 16, 0, 0)},
+		{"每周", "@weekly", date(5, 19, 0, 0)},
+		{"每月", "@monthly", date(6, 1, 0, 0)},
+		{"每年", "@yearly", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"步长", "*/15 * * * *", date(5, 15, 10, 30)},
+		{"范围和步长", "0 9-17/4 * * *", date(5, 15, 13, 0)},
+		{"列表", "5,25,45 * * * *", date(5, 15, 10, 25)},
+		{"当天稍后", "30 14 * * *", date(5, 15, 14, 30)},
+		{"当天已过", "0 9 * * *", date(5, 16, 9, 0)},
+		{"指定星期", "0 9 * * 1", date(5, 20, 9, 0)},
+		{"星期 7 表示星期日", "0 9 * * 7", date(5, 19, 9, 0)},
+		{"日和星期满足其一", "0 0 1 * 5", date(5, 17, 0, 0)},
+		{"日为 */n 时视为不限制", "0 0 */2 * 1", date(5, 27, 0, 0)},
+		{"指定月份", "0 0 1 8 *", date(8, 1, 0, 0)},
+		{"跨年", "0 0 1 2 *", time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"闰日", "0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"英文每周", "every Monday 09:00", date(5, 20, 9, 0)},
+		{"英文每周复数", "every fridays at 18:30", date(5, 17, 18, 30)},
+		{"英文每天", "daily at 02:30", date(5, 16, 2, 30)},
+		{"英文每天省略时间", "daily", date(5, 16, 0, 0)},
+		{"英文每天 every day", "every day at 8:00", date(5, 16, 8, 0)},
+		{"英文工作日", "every weekday at 9:00", date(5, 16, 9, 0)},
+		{"英文每小时", "hourly", date(5, 15, 11, 0)},
+		{"英文每隔分钟", "every 15 minutes", date(5, 15, 10, 30)},
+		{"英文每隔小时", "every 6 hours", date(5, 15, 12, 0)},
+		{"英文每 60 分钟", "every 60 minutes", date(5, 15, 11, 0)},
+		{"不存在的日期", "0 0 30 2 *", time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ParseSchedule(tt.expr, nil)
+			if err != nil {
+				t.Fatalf("ParseSchedule(%q) 错误 = %v", tt.expr, err)
+			}
+			if got := s.Next(after); !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, 期望 %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScheduleNextLocation(t *testing.T) {
+	shanghai := loadLocation(t, "Asia/Shanghai")
+	s := MustParseSchedule("daily at 09:00", shanghai)
+	// UTC 5 月 15 日 02:00 是上海 10:00，下一次为上海 5 月 16 日 09:00
+	got := s.Next(time.Date(2024, 5, 15, 2, 0, 0, 0, time.UTC))
+	want := time.Date(2024, 5, 16, 9, 0, 0, 0, shanghai)
+	if !got.Equal(want) {
+		t.Errorf("Next() = %v, 期望 %v", got, want)
+	}
+}
+
+func TestScheduleNextDST(t *testing.T) {
+	ny := loadLocation(t, "America/New_York")
+	// 2024-03-10 02:00 到 03:00 不存在，当天 02:30 的执行被跳过
+	s := MustParseSchedule("30 2 * * *", ny)
+	got := s.Next(time.Date(2024, 3, 9, 12, 0, 0, 0, ny))
+	want := time.Date(2024, 3, 11, 2, 30, 0, 0, ny)
+	if !got.Equal(want) {
+		t.Errorf("Next() = %v, 期望 %v", got, want)
+	}
+
+	// 夏令时结束当天按小时调度不会死循环，且间隔为绝对时间的一小时
+	s = MustParseSchedule("0 * * * *", ny)
+	first := s.Next(time.Date(2024, 11, 3, 0, 30, 0, 0, ny))
+	second := s.Next(first)
+	if second.Sub(first) != time.Hour {
+		t.Errorf("Next() = %v, %v, 期望相隔一小时", first, second)
+	}
+}
+
+func TestParseScheduleInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"every",
+		"every someday",
+		"daily at 25:00",
+		"every monday at noon",
+		"every 0 minutes",
+		"every 90 minutes",
+		"every 7 minutes",
+		"every 25 hours",
+		"every 5 hours",
+	}
+	for _, expr := range tests {
+		t.Run(expr, func(t *testing.T) {
+			if _, err := ParseSchedule(expr, nil); !errors.Is(err, ErrInvalidSchedule) {
+				t.Errorf("ParseSchedule(%q) 错误 = %v, 期望 %v", expr, err, ErrInvalidSchedule)
+			}
+		})
+	}
+}
+
+func TestScheduleFunc(t *testing.T) {
+	var s Schedule = ScheduleFunc(func(after time.Time) time.Time {
+		return after.Add(time.Hour)
+	})
+	if got := s.Next(at(1)); !got.Equal(at(2)) {
+		t.Errorf("Next() = %v, 期望 %v", got, at(2))
+	}
+	if got := MustParseSchedule("@daily", nil).String(); got != "@daily" {
+		t.Errorf("String() = %q, 期望 %q", got, "@daily")
+	}
+}
@@ -0,0 +1,155 @@
+package timeutils
+
+import (
+	"sync"
+	"time"
+)
+
+// Stopwatch 秒表，用于测量代码的执行耗时，可以暂停、继续并记录分段（lap）
+// 零值是一个未启动的秒表，可以直接使用；并发安全
+type Stopwatch struct {
+	mu      sync.Mutex
+	clock   Clock // 为 nil 时使用 RealClock
+	running bool
+	start   time.Time       // 本次运行的开始时刻
+	lapFrom time.Time       // 当前分段的开始时刻
+	elapsed time.Duration   // 之前各次运行累计的耗时
+	lapAcc  time.Duration   // 当前分段在暂停前累计的耗时
+	laps    []time.Duration // 已记录的分段耗时
+}
+
+// stopwatchConfig Stopwatch 的配置
+type stopwatchConfig struct {
+	clock Clock
+}
+
+// StopwatchOption NewStopwatch 和 StartStopwatch 的可选配置项
+type StopwatchOption func(*stopwatchConfig)
+
+// WithStopwatchClock 设置计时使用的时钟，默认为 RealClock，测试中可以使用 FakeClock
+func WithStopwatchClock(clock Clock) StopwatchOption {
+	return func(c *stopwatchConfig) {
+		c.clock = clock
+	}
+}
+
+// NewStopwatch 创建一个未启动的秒表
+func NewStopwatch(opts ...StopwatchOption) *Stopwatch {
+	var cfg stopwatchConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &Stopwatch{clock: cfg.clock}
+}
+
+// StartStopwatch 创建并启动一个秒表
+func StartStopwatch(opts ...StopwatchOption) *Stopwatch {
+	sw := NewStopwatch(opts...)
+	sw.Start()
+	return sw
+}
+
+// nowLocked 返回当前时间
+func (sw *Stopwatch) nowLocked() time.Time {
+	return OrRealClock(sw.clock).Now()
+}
+
+// Start 启动或继续计时，已在计时中时不做任何操作
+func (sw *Stopwatch) Start() {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	if sw.running {
+		return
+	}
+	sw.running = true
+	sw.start = sw.nowLocked()
+	sw.lapFrom = sw.start
+}
+
+// Stop 暂停计时并返回累计耗时，暂停期间的时间不计入 Elapsed 和当前分段
+func (sw *Stopwatch) Stop() time.Duration {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	if sw.running {
+		now := sw.nowLocked()
+		sw.elapsed += now.Sub(sw.start)
+		sw.lapAcc += now.Sub(sw.lapFrom)
+		sw.running = false
+	}
+	return sw.elapsed
+}
+
+// Lap 结束当前分段并开始新的分段，返回当前分段的耗时
+func (sw *Stopwatch) Lap() time.Duration {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	lap := sw.lapAcc
+	if sw.running {
+		now := sw.nowLocked()
+		lap += now.Sub(sw.lapFrom)
+		sw.lapFrom = now
+	}
+	sw.lapAcc = 0
+	sw.laps = append(sw.laps, lap)
+	return lap
+}
+
+// Laps 返回已记录的各分段耗时
+func (sw *Stopwatch) Laps() []time.Duration {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	laps := make([]time.Duration, len(sw.laps))
+	copy(laps, sw.laps)
+	return laps
+}
+
+// Elapsed 返回累计耗时，计时中时包含到当前为止的时间
+func (sw *Stopwatch) Elapsed() time.Duration {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	if sw.running {
+		return sw.elapsed + sw.nowLocked().Sub(sw.start)
+	}
+	return sw.elapsed
+}
+
+// Running 报告秒表是否在计时中
+func (sw *Stopwatch) Running() bool {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	return sw.running
+}
+
+// Reset 停止计时并清空累计耗时和分段
+func (sw *Stopwatch) Reset() {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	sw.running = false
+	sw.elapsed = 0
+	sw.lapAcc = 0
+	sw.laps = nil
+}
+
+// Timed 执行 fn 并返回其耗时
+func Timed(fn func()) time.Duration {
+	start := time.Now()
+	fn()
+	return time.Since(start)
+}
+
+// TimedValue 执行 fn 并返回其结果和耗时
+func TimedValue[T any](fn func() T) (T, time.Duration) {
+	start := time.Now()
+	v := fn()
+	return v, time.Since(start)
+}
+
+// Track 开始计时并返回一个结束函数，调用时将耗时传给 report，便于配合 defer 使用：
+//
+//	defer timeutils.Track(func(d time.Duration) { log.Printf("query took %v", d) })()
+func Track(report func(time.Duration)) func() {
+	start := time.Now()
+	return func() {
+		report(time.Since(start))
+	}
+}
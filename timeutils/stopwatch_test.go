@@ -0,0 +1,122 @@
+package timeutils
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+// newTestStopwatch 创建一个使用可控时间的秒表，返回秒表和推进时间的函数
+func newTestStopwatch() (*Stopwatch, func(time.Duration)) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	return NewStopwatch(WithStopwatchClock(clock)), clock.Advance
+}
+
+func TestStopwatch(t *testing.T) {
+	t.Run("启动与累计", func(t *testing.T) {
+		sw, advance := newTestStopwatch()
+		if sw.Elapsed() != 0 || sw.Running() {
+			t.Fatalf("未启动的秒表 Elapsed() = %v, Running() = %v", sw.Elapsed(), sw.Running())
+		}
+		sw.Start()
+		advance(3 * time.Second)
+		if got := sw.Elapsed(); got != 3*time.Second {
+			t.Errorf("Elapsed() = %v, 期望 %v", got, 3*time.Second)
+		}
+	})
+
+	t.Run("暂停期间不计时", func(t *testing.T) {
+		sw, advance := newTestStopwatch()
+		sw.Start()
+		advance(time.Second)
+		if got := sw.Stop(); got != time.Second {
+			t.Errorf("Stop() = %v, 期望 %v", got, time.Second)
+		}
+		advance(time.Hour)
+		sw.Start()
+		advance(2 * time.Second)
+		if got := sw.Elapsed(); got != 3*time.Second {
+			t.Errorf("Elapsed() = %v, 期望 %v", got, 3*time.Second)
+		}
+	})
+
+	t.Run("分段", func(t *testing.T) {
+		sw, advance := newTestStopwatch()
+		sw.Start()
+		advance(time.Second)
+		if got := sw.Lap(); got != time.Second {
+			t.Errorf("Lap() = %v, 期望 %v", got, time.Second)
+		}
+		advance(2 * time.Second)
+		sw.Stop()
+		advance(time.Minute)
+		sw.Start()
+		advance(time.Second)
+		if got := sw.Lap(); got != 3*time.Second {
+			t.Errorf("跨越暂停的 Lap() = %v, 期望 %v", got, 3*time.Second)
+		}
+		want := []time.Duration{time.Second, 3 * time.Second}
+		if got := sw.Laps(); !slices.Equal(got, want) {
+			t.Errorf("Laps() = %v, 期望 %v", got, want)
+		}
+		if got := sw.Elapsed(); got != 4*time.Second {
+			t.Errorf("Elapsed() = %v, 期望 %v", got, 4*time.Second)
+		}
+	})
+
+	t.Run("重置", func(t *testing.T) {
+		sw, advance := newTestStopwatch()
+		sw.Start()
+		advance(time.Second)
+		sw.Lap()
+		sw.Reset()
+		if sw.Running() || sw.Elapsed() != 0 || len(sw.Laps()) != 0 {
+			t.Errorf("Reset() 后 Running() = %v, Elapsed() = %v, Laps() = %v", sw.Running(), sw.Elapsed(), sw.Laps())
+		}
+	})
+
+	t.Run("零值与真实时间", func(t *testing.T) {
+		var sw Stopwatch
+		sw.Start()
+		time.Sleep(5 * time.Millisecond)
+		if got := sw.Stop(); got < 5*time.Millisecond {
+			t.Errorf("Stop() = %v, 期望至少 %v", got, 5*time.Millisecond)
+		}
+		if !StartStopwatch().Running() {
+			t.Errorf("StartStopwatch().Running() = false, 期望 true")
+		}
+	})
+
+	t.Run("StartStopwatch 使用指定的时钟", func(t *testing.T) {
+		clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		sw := StartStopwatch(WithStopwatchClock(clock))
+		clock.Advance(time.Minute)
+		if got := sw.Elapsed(); got != time.Minute {
+			t.Errorf("Elapsed() = %v, 期望 %v", got, time.Minute)
+		}
+	})
+}
+
+func TestTimed(t *testing.T) {
+	d := Timed(func() { time.Sleep(5 * time.Millisecond) })
+	if d < 5*time.Millisecond {
+		t.Errorf("Timed() = %v, 期望至少 %v", d, 5*time.Millisecond)
+	}
+
+	v, d := TimedValue(func() int {
+		time.Sleep(5 * time.Millisecond)
+		return 42
+	})
+	if v != 42 || d < 5*time.Millisecond {
+		t.Errorf("TimedValue() = %v, %v, 期望 42 且至少 %v", v, d, 5*time.Millisecond)
+	}
+
+	var got time.Duration
+	func() {
+		defer Track(func(d time.Duration) { got = d })()
+		time.Sleep(5 * time.Millisecond)
+	}()
+	if got < 5*time.Millisecond {
+		t.Errorf("Track() 报告 %v, 期望至少 %v", got, 5*time.Millisecond)
+	}
+}
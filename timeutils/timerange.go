@@ -0,0 +1,109 @@
+package timeutils
+
+import "time"
+
+// TimeRange 左闭右开的时间区间 [Start, End)，End 不晚于 Start 时为空区间
+// 使用左闭右开区间，相邻的两个区间（如 9:00-10:00 和 10:00-11:00）不会被视为重叠
+type TimeRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// NewTimeRange 创建一个时间区间，start 晚于 end 时会交换两者
+func NewTimeRange(start, end time.Time) TimeRange {
+	if end.Before(start) {
+		start, end = end, start
+	}
+	return TimeRange{Start: start, End: end}
+}
+
+// RangeFrom 创建一个从 start 开始、持续 d 的时间区间
+func RangeFrom(start time.Time, d time.Duration) TimeRange {
+	return NewTimeRange(start, start.Add(d))
+}
+
+// Empty 判断区间是否为空
+func (r TimeRange) Empty() bool {
+	return !r.End.After(r.Start)
+}
+
+// Duration 返回区间的时长，空区间返回 0
+func (r TimeRange) Duration() time.Duration {
+	if r.Empty() {
+		return 0
+	}
+	return r.End.Sub(r.Start)
+}
+
+// Contains 判断 t 是否在区间内
+func (r TimeRange) Contains(t time.Time) bool {
+	return !t.Before(r.Start) && t.Before(r.End)
+}
+
+// ContainsRange 判断 other 是否完全在区间内，空区间被任意区间包含
+func (r TimeRange) ContainsRange(other TimeRange) bool {
+	if other.Empty() {
+		return true
+	}
+	return !other.Start.Before(r.Start) && !other.End.After(r.End)
+}
+
+// Overlaps 判断两个区间是否有交集，仅端点相接不算重叠
+func (r TimeRange) Overlaps(other TimeRange) bool {
+	return !r.Empty() && !other.Empty() && r.Start.Before(other.End) && other.Start.Before(r.End)
+}
+
+// Intersect 返回两个区间的交集，没有交集时返回 false
+func (r TimeRange) Intersect(other TimeRange) (TimeRange, bool) {
+	if !r.Overlaps(other) {
+		return TimeRange{}, false
+	}
+	return TimeRange{Start: latest(r.Start, other.Start), End: earliest(r.End, other.End)}, true
+}
+
+// Union 返回覆盖两个区间的区间，两者既不重叠也不相邻时无法合并，返回 false
+func (r TimeRange) Union(other TimeRange) (TimeRange, bool) {
+	switch {
+	case r.Empty():
+		return other, true
+	case other.Empty():
+		return r, true
+	case r.Start.After(other.End) || other.Start.After(r.End):
+		return TimeRange{}, false
+	}
+	return TimeRange{Start: earliest(r.Start, other.Start), End: latest(r.End, other.End)}, true
+}
+
+// Split 将区间按 interval 切分为连续的子区间，最后一段可能短于 interval
+// interval <= 0 或区间为空时返回 nil
+func (r TimeRange) Split(interval time.Duration) []TimeRange {
+	if interval <= 0 || r.Empty() {
+		return nil
+	}
+	var parts []TimeRange
+	for start := r.Start; start.Before(r.End); start = start.Add(interval) {
+		parts = append(parts, TimeRange{Start: start, End: earliest(start.Add(interval), r.End)})
+	}
+	return parts
+}
+
+// String 实现 fmt.Stringer，使用 RFC 3339 格式
+func (r TimeRange) String() string {
+	return "[" + r.Start.Format(time.RFC3339) + ", " + r.End.Format(time.RFC3339) + ")"
+}
+
+// earliest 返回较早的时间
+func earliest(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+// latest 返回较晚的时间
+func latest(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,131 @@
+package timeutils
+
+import (
+	"testing"
+	"time"
+)
+
+// at 返回 2024-01-01 当天 hour 点的时间
+func at(hour int) time.Time {
+	return time.Date(2024, 1, 1, hour, 0, 0, 0, time.UTC)
+}
+
+// hours 返回 [from, to) 点的时间区间
+func hours(from, to int) TimeRange {
+	return TimeRange{Start: at(from), End: at(to)}
+}
+
+func TestTimeRangeBasics(t *testing.T) {
+	if r := NewTimeRange(at(10), at(9)); r != hours(9, 10) {
+		t.Errorf("NewTimeRange() = %v, 期望交换为 %v", r, hours(9, 10))
+	}
+	if r := RangeFrom(at(9), 2*time.Hour); r != hours(9, 11) {
+		t.Errorf("RangeFrom() = %v, 期望 %v", r, hours(9, 11))
+	}
+
+	tests := []struct {
+		name         string
+		r            TimeRange
+		wantEmpty    bool
+		wantDuration time.Duration
+	}{
+		{"普通区间", hours(9, 12), false, 3 * time.Hour},
+		{"空区间", hours(9, 9), true, 0},
+		{"反向区间", hours(12, 9), true, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.Empty(); got != tt.wantEmpty {
+				t.Errorf("Empty() = %v, 期望 %v", got, tt.wantEmpty)
+			}
+			if got := tt.r.Duration(); got != tt.wantDuration {
+				t.Errorf("Duration() = %v, 期望 %v", got, tt.wantDuration)
+			}
+		})
+	}
+
+	r := hours(9, 12)
+	contains := []struct {
+		t    time.Time
+		want bool
+	}{
+		{at(9), true},
+		{at(11), true},
+		{at(12), false},
+		{at(8), false},
+	}
+	for _, tt := range contains {
+		if got := r.Contains(tt.t); got != tt.want {
+			t.Errorf("Contains(%v) = %v, 期望 %v", tt.t, got, tt.want)
+		}
+	}
+	if !r.ContainsRange(hours(10, 12)) || r.ContainsRange(hours(10, 13)) {
+		t.Error("ContainsRange() 结果错误")
+	}
+}
+
+func TestTimeRangeSetOperations(t *testing.T) {
+	tests := []struct {
+		name          string
+		a, b          TimeRange
+		wantOverlap   bool
+		wantIntersect TimeRange
+		wantUnionOK   bool
+		wantUnion     TimeRange
+	}{
+		{"部分重叠", hours(9, 12), hours(11, 14), true, hours(11, 12), true, hours(9, 14)},
+		{"包含", hours(9, 17), hours(10, 11), true, hours(10, 11), true, hours(9, 17)},
+		{"相邻", hours(9, 10), hours(10, 11), false, TimeRange{}, true, hours(9, 11)},
+		{"分离", hours(9, 10), hours(11, 12), false, TimeRange{}, false, TimeRange{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Overlaps(tt.b); got != tt.wantOverlap {
+				t.Errorf("Overlaps() = %v, 期望 %v", got, tt.wantOverlap)
+			}
+			if got := tt.b.Overlaps(tt.a); got != tt.wantOverlap {
+				t.Errorf("反向 Overlaps() = %v, 期望 %v", got, tt.wantOverlap)
+			}
+			got, ok := tt.a.Intersect(tt.b)
+			if ok != tt.wantOverlap || got != tt.wantIntersect {
+				t.Errorf("Intersect() = %v, %v, 期望 %v, %v", got, ok, tt.wantIntersect, tt.wantOverlap)
+			}
+			got, ok = tt.a.Union(tt.b)
+			if ok != tt.wantUnionOK || got != tt.wantUnion {
+				t.Errorf("Union() = %v, %v, 期望 %v, %v", got, ok, tt.wantUnion, tt.wantUnionOK)
+			}
+		})
+	}
+}
+
+func TestTimeRangeSplit(t *testing.T) {
+	tests := []struct {
+		name     string
+		r        TimeRange
+		interval time.Duration
+		want     []TimeRange
+	}{
+		{"整除", hours(9, 12), time.Hour, []TimeRange{hours(9, 10), hours(10, 11), hours(11, 12)}},
+		{"最后一段较短", hours(9, 12), 2 * time.Hour, []TimeRange{hours(9, 11), hours(11, 12)}},
+		{"间隔大于区间", hours(9, 10), 5 * time.Hour, []TimeRange{hours(9, 10)}},
+		{"非法间隔", hours(9, 10), 0, nil},
+		{"空区间", hours(9, 9), time.Hour, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.r.Split(tt.interval)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Split() = %v, 期望 %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Split()[%d] = %v, 期望 %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+
+	if got, want := hours(9, 10).String(), "[2024-01-01T09:00:00Z, 2024-01-01T10:00:00Z)"; got != want {
+		t.Errorf("String() = %q, 期望 %q", got, want)
+	}
+}
@@ -0,0 +1,68 @@
+package types
+
+// Either 保存两种类型之一的值，用于合法地返回两种不同形态结果的 API，例如缓存命中的值或新计算的值
+// 零值为 L 零值的 Left
+type Either[L, R any] struct {
+	left    L
+	right   R
+	isRight bool
+}
+
+// Left 创建一个保存 L 值的 Either
+func Left[L, R any](v L) Either[L, R] {
+	return Either[L, R]{left: v}
+}
+
+// Right 创建一个保存 R 值的 Either
+func Right[L, R any](v R) Either[L, R] {
+	return Either[L, R]{right: v, isRight: true}
+}
+
+// IsLeft 判断是否保存 L 值
+func (e Either[L, R]) IsLeft() bool {
+	return !e.isRight
+}
+
+// IsRight 判断是否保存 R 值
+func (e Either[L, R]) IsRight() bool {
+	return e.isRight
+}
+
+// LeftValue 返回 L 值，保存的是 R 值时返回零值和 false
+func (e Either[L, R]) LeftValue() (L, bool) {
+	return e.left, !e.isRight
+}
+
+// RightValue 返回 R 值，保存的是 L 值时返回零值和 false
+func (e Either[L, R]) RightValue() (R, bool) {
+	return e.right, e.isRight
+}
+
+// Swap 交换左右两侧
+func (e Either[L, R]) Swap() Either[R, L] {
+	return Either[R, L]{left: e.right, right: e.left, isRight: !e.isRight}
+}
+
+// MapLeft 对 L 值应用 fn，R 值保持不变
+func MapLeft[L, R, T any](e Either[L, R], fn func(L) T) Either[T, R] {
+	if e.isRight {
+		return Right[T](e.right)
+	}
+	return Left[T, R](fn(e.left))
+}
+
+// MapRight 对 R 值应用 fn，L 值保持不变
+func MapRight[L, R, T any](e Either[L, R], fn func(R) T) Either[L, T] {
+	if e.isRight {
+		return Right[L](fn(e.right))
+	}
+	return Left[L, T](e.left)
+}
+
+// Fold 根据保存的值调用 onLeft 或 onRight，将两种情况归约为同一类型
+func Fold[L, R, T any](e Either[L, R], onLeft func(L) T, onRight func(R) T) T {
+	if e.isRight {
+		return onRight(e.right)
+	}
+	return onLeft(e.left)
+}
@@ -0,0 +1,63 @@
+package types
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestEither(t *testing.T) {
+	tests := []struct {
+		name      string
+		e         Either[int, string]
+		wantRight bool
+		wantLeft  int
+		wantR     string
+		wantFold  string
+	}{
+		{"Left", Left[int, string](42), false, 42, "", "left:42"},
+		{"Right", Right[int]("hello"), true, 0, "hello", "right:hello"},
+		{"零值", Either[int, string]{}, false, 0, "", "left:0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.e.IsRight() != tt.wantRight || tt.e.IsLeft() == tt.wantRight {
+				t.Errorf("IsRight() = %v, 期望 %v", tt.e.IsRight(), tt.wantRight)
+			}
+			if l, ok := tt.e.LeftValue(); l != tt.wantLeft || ok == tt.wantRight {
+				t.Errorf("LeftValue() = %v, %v, 期望 %v, %v", l, ok, tt.wantLeft, !tt.wantRight)
+			}
+			if r, ok := tt.e.RightValue(); r != tt.wantR || ok != tt.wantRight {
+				t.Errorf("RightValue() = %v, %v, 期望 %v, %v", r, ok, tt.wantR, tt.wantRight)
+			}
+			got := Fold(tt.e,
+				func(n int) string { return "left:" + strconv.Itoa(n) },
+				func(s string) string { return "right:" + s },
+			)
+			if got != tt.wantFold {
+				t.Errorf("Fold() = %v, 期望 %v", got, tt.wantFold)
+			}
+			if swapped := tt.e.Swap(); swapped.IsLeft() != tt.wantRight {
+				t.Errorf("Swap().IsLeft() = %v, 期望 %v", swapped.IsLeft(), tt.wantRight)
+			}
+		})
+	}
+}
+
+func TestEitherMap(t *testing.T) {
+	left := Left[int, string](2)
+	right := Right[int]("ab")
+
+	if v, _ := MapLeft(left, strconv.Itoa).LeftValue(); v != "2" {
+		t.Errorf("MapLeft(Left) = %v, 期望 %v", v, "2")
+	}
+	if v, _ := MapLeft(right, strconv.Itoa).RightValue(); v != "ab" {
+		t.Errorf("MapLeft(Right) = %v, 期望 %v", v, "ab")
+	}
+	length := func(s string) int { return len(s) }
+	if v, _ := MapRight(right, length).RightValue(); v != 2 {
+		t.Errorf("MapRight(Right) = %v, 期望 %v", v, 2)
+	}
+	if v, ok := MapRight(left, length).LeftValue(); !ok || v != 2 {
+		t.Errorf("MapRight(Left) = %v, %v, 期望 2, true", v, ok)
+	}
+}
@@ -0,0 +1,86 @@
+package types
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Lazy 延迟计算的值，首次调用 Value 时才执行计算，之后返回缓存的结果
+// 适用于计算代价高但很少用到的派生值；并发安全，计算期间其他调用者会等待
+type Lazy[T any] struct {
+	fn  func() T
+	mu  sync.Mutex
+	val atomic.Pointer[T] // 为 nil 表示尚未计算
+}
+
+// NewLazy 创建一个由 fn 计算的延迟值
+func NewLazy[T any](fn func() T) *Lazy[T] {
+	return &Lazy[T]{fn: fn}
+}
+
+// Value 返回计算结果，首次调用时执行计算；fn panic 时不缓存，下次调用会重新计算
+func (l *Lazy[T]) Value() T {
+	if p := l.val.Load(); p != nil {
+		return *p
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if p := l.val.Load(); p != nil {
+		return *p
+	}
+	v := l.fn()
+	l.val.Store(&v)
+	return v
+}
+
+// Evaluated 判断是否已经计算过
+func (l *Lazy[T]) Evaluated() bool {
+	return l.val.Load() != nil
+}
+
+// Reset 丢弃缓存的结果，下次调用 Value 时重新计算
+func (l *Lazy[T]) Reset() {
+	l.val.Store(nil)
+}
+
+// LazyErr 与 Lazy 相同，但计算可能失败
+// 只有成功的结果会被缓存，失败时下次调用 Value 会重试
+type LazyErr[T any] struct {
+	fn  func() (T, error)
+	mu  sync.Mutex
+	val atomic.Pointer[T]
+}
+
+// NewLazyErr 创建一个由可能失败的 fn 计算的延迟值
+func NewLazyErr[T any](fn func() (T, error)) *LazyErr[T] {
+	return &LazyErr[T]{fn: fn}
+}
+
+// Value 返回计算结果，尚未成功计算过时执行计算
+func (l *LazyErr[T]) Value() (T, error) {
+	if p := l.val.Load(); p != nil {
+		return *p, nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if p := l.val.Load(); p != nil {
+		return *p, nil
+	}
+	v, err := l.fn()
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	l.val.Store(&v)
+	return v, nil
+}
+
+// Evaluated 判断是否已经成功计算过
+func (l *LazyErr[T]) Evaluated() bool {
+	return l.val.Load() != nil
+}
+
+// Reset 丢弃缓存的结果，下次调用 Value 时重新计算
+func (l *LazyErr[T]) Reset() {
+	l.val.Store(nil)
+}
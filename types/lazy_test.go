@@ -0,0 +1,84 @@
+package types
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestLazy(t *testing.T) {
+	var calls atomic.Int32
+	l := NewLazy(func() int {
+		return int(calls.Add(1)) * 10
+	})
+	if l.Evaluated() {
+		t.Error("访问前 Evaluated() = true, 期望 false")
+	}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v := l.Value(); v != 10 {
+				t.Errorf("Value() = %v, 期望 %v", v, 10)
+			}
+		}()
+	}
+	wg.Wait()
+	if calls.Load() != 1 || !l.Evaluated() {
+		t.Errorf("计算次数 = %v, 期望 %v", calls.Load(), 1)
+	}
+
+	l.Reset()
+	if l.Evaluated() {
+		t.Error("Reset() 后 Evaluated() = true, 期望 false")
+	}
+	if v := l.Value(); v != 20 {
+		t.Errorf("Reset() 后 Value() = %v, 期望 %v", v, 20)
+	}
+}
+
+func TestLazyErr(t *testing.T) {
+	errTemp := errors.New("temporary")
+	results := []struct {
+		v   string
+		err error
+	}{
+		{"", errTemp},
+		{"ok", nil},
+		{"unexpected", nil},
+	}
+	var calls int
+	l := NewLazyErr(func() (string, error) {
+		r := results[calls]
+		calls++
+		return r.v, r.err
+	})
+
+	tests := []struct {
+		name    string
+		want    string
+		wantErr error
+	}{
+		{"首次失败", "", errTemp},
+		{"重试成功", "ok", nil},
+		{"使用缓存", "ok", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := l.Value()
+			if v != tt.want || !errors.Is(err, tt.wantErr) {
+				t.Errorf("Value() = %q, %v, 期望 %q, %v", v, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+	if calls != 2 || !l.Evaluated() {
+		t.Errorf("计算次数 = %v, 期望 %v", calls, 2)
+	}
+	l.Reset()
+	if v, _ := l.Value(); v != "unexpected" {
+		t.Errorf("Reset() 后 Value() = %q, 期望 %q", v, "unexpected")
+	}
+}
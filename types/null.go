@@ -0,0 +1,98 @@
+package types
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+)
+
+// Null 可为空的值，可以区分"缺失"、"null"和"零值"三种状态，并同时支持 JSON 和 database/sql
+//   - Present 为 false：缺失，例如 JSON 中没有该字段
+//   - Present 为 true 且 Valid 为 false：显式的 null
+//   - Valid 为 true：有值，V 可以是零值
+//
+// 零值表示缺失；序列化为 JSON 时缺失和 null 都输出 null
+type Null[T any] struct {
+	V       T
+	Valid   bool
+	Present bool
+}
+
+// NullFrom 创建一个有值的 Null
+func NullFrom[T any](v T) Null[T] {
+	return Null[T]{V: v, Valid: true, Present: true}
+}
+
+// NullFromPtr 由指针创建 Null，p 为 nil 时为显式的 null
+func NullFromPtr[T any](p *T) Null[T] {
+	if p == nil {
+		return Null[T]{Present: true}
+	}
+	return NullFrom(*p)
+}
+
+// Ptr 有值时返回指向值副本的指针，否则返回 nil
+func (n Null[T]) Ptr() *T {
+	if !n.Valid {
+		return nil
+	}
+	v := n.V
+	return &v
+}
+
+// ValueOr 有值时返回该值，否则返回 def
+func (n Null[T]) ValueOr(def T) T {
+	if !n.Valid {
+		return def
+	}
+	return n.V
+}
+
+// IsNull 判断是否为显式的 null
+func (n Null[T]) IsNull() bool {
+	return n.Present && !n.Valid
+}
+
+// IsZero 判断是否缺失，使支持 IsZero 的编码器可以省略缺失的字段
+func (n Null[T]) IsZero() bool {
+	return !n.Present
+}
+
+// MarshalJSON 实现 json.Marshaler，没有值时输出 null
+func (n Null[T]) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.V)
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler
+// 只有 JSON 中存在该字段时才会被调用，因此调用后 Present 总是为 true
+func (n *Null[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*n = Null[T]{Present: true}
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*n = NullFrom(v)
+	return nil
+}
+
+// Scan 实现 sql.Scanner，数据库中的 NULL 对应显式的 null
+func (n *Null[T]) Scan(src any) error {
+	var sn sql.Null[T]
+	if err := sn.Scan(src); err != nil {
+		return err
+	}
+	*n = Null[T]{V: sn.V, Valid: sn.Valid, Present: true}
+	return nil
+}
+
+// Value 实现 driver.Valuer，没有值时写入 NULL
+func (n Null[T]) Value() (driver.Value, error) {
+	return sql.Null[T]{V: n.V, Valid: n.Valid}.Value()
+}
@@ -0,0 +1,117 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNullJSON(t *testing.T) {
+	type payload struct {
+		Name Null[string] `json:"name"`
+		Age  Null[int]    `json:"age"`
+	}
+	tests := []struct {
+		name        string
+		input       string
+		wantPresent bool
+		wantValid   bool
+		wantAge     int
+		wantOutput  string
+	}{
+		{"缺失", `{"name":"a"}`, false, false, 0, `{"name":"a","age":null}`},
+		{"null", `{"name":"a","age":null}`, true, false, 0, `{"name":"a","age":null}`},
+		{"零值", `{"name":"a","age":0}`, true, true, 0, `{"name":"a","age":0}`},
+		{"有值", `{"name":"a","age":18}`, true, true, 18, `{"name":"a","age":18}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p payload
+			if err := json.Unmarshal([]byte(tt.input), &p); err != nil {
+				t.Fatalf("Unmarshal() 错误 = %v", err)
+			}
+			if p.Age.Present != tt.wantPresent || p.Age.Valid != tt.wantValid || p.Age.V != tt.wantAge {
+				t.Errorf("Age = %+v, 期望 Present=%v Valid=%v V=%v", p.Age, tt.wantPresent, tt.wantValid, tt.wantAge)
+			}
+			if p.Age.IsZero() == tt.wantPresent {
+				t.Errorf("IsZero() = %v, 期望 %v", p.Age.IsZero(), !tt.wantPresent)
+			}
+			out, err := json.Marshal(p)
+			if err != nil {
+				t.Fatalf("Marshal() 错误 = %v", err)
+			}
+			if string(out) != tt.wantOutput {
+				t.Errorf("Marshal() = %s, 期望 %s", out, tt.wantOutput)
+			}
+		})
+	}
+
+	var n Null[int]
+	if err := json.Unmarshal([]byte(`"x"`), &n); err == nil {
+		t.Error("类型不匹配时 Unmarshal() 应返回错误")
+	}
+}
+
+func TestNullSQL(t *testing.T) {
+	tests := []struct {
+		name      string
+		src       any
+		want      int64
+		wantValid bool
+	}{
+		{"NULL", nil, 0, false},
+		{"整数", int64(42), 42, true},
+		{"字节", []byte("7"), 7, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var n Null[int64]
+			if err := n.Scan(tt.src); err != nil {
+				t.Fatalf("Scan() 错误 = %v", err)
+			}
+			if n.V != tt.want || n.Valid != tt.wantValid || !n.Present {
+				t.Errorf("Scan() = %+v, 期望 V=%v Valid=%v", n, tt.want, tt.wantValid)
+			}
+			v, err := n.Value()
+			if err != nil {
+				t.Fatalf("Value() 错误 = %v", err)
+			}
+			if tt.wantValid && v != tt.want || !tt.wantValid && v != nil {
+				t.Errorf("Value() = %v, 期望 %v", v, tt.want)
+			}
+		})
+	}
+
+	var n Null[int64]
+	if err := n.Scan("abc"); err == nil {
+		t.Error("无法转换时 Scan() 应返回错误")
+	}
+}
+
+func TestNullHelpers(t *testing.T) {
+	v := 5
+	tests := []struct {
+		name     string
+		n        Null[int]
+		wantOr   int
+		wantNil  bool
+		wantNull bool
+	}{
+		{"有值", NullFrom(3), 3, false, false},
+		{"由指针创建", NullFromPtr(&v), 5, false, false},
+		{"由 nil 指针创建", NullFromPtr[int](nil), -1, true, true},
+		{"缺失", Null[int]{}, -1, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.n.ValueOr(-1); got != tt.wantOr {
+				t.Errorf("ValueOr() = %v, 期望 %v", got, tt.wantOr)
+			}
+			if got := tt.n.Ptr(); (got == nil) != tt.wantNil {
+				t.Errorf("Ptr() = %v, 期望为 nil: %v", got, tt.wantNil)
+			}
+			if got := tt.n.IsNull(); got != tt.wantNull {
+				t.Errorf("IsNull() = %v, 期望 %v", got, tt.wantNull)
+			}
+		})
+	}
+}
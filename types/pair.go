@@ -0,0 +1,74 @@
+package types
+
+import "fmt"
+
+// Pair 二元组，Zip2、Enumerate、Entries 等函数统一返回该类型
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// NewPair 创建一个二元组
+func NewPair[A, B any](first A, second B) Pair[A, B] {
+	return Pair[A, B]{First: first, Second: second}
+}
+
+// Swap 交换两个元素
+func (p Pair[A, B]) Swap() Pair[B, A] {
+	return Pair[B, A]{First: p.Second, Second: p.First}
+}
+
+// Unpack 返回两个元素，便于多重赋值
+func (p Pair[A, B]) Unpack() (A, B) {
+	return p.First, p.Second
+}
+
+// String 实现 fmt.Stringer
+func (p Pair[A, B]) String() string {
+	return fmt.Sprintf("(%v, %v)", p.First, p.Second)
+}
+
+// Tuple3 三元组
+type Tuple3[A, B, C any] struct {
+	First  A
+	Second B
+	Third  C
+}
+
+// NewTuple3 创建一个三元组
+func NewTuple3[A, B, C any](first A, second B, third C) Tuple3[A, B, C] {
+	return Tuple3[A, B, C]{First: first, Second: second, Third: third}
+}
+
+// Unpack 返回三个元素，便于多重赋值
+func (t Tuple3[A, B, C]) Unpack() (A, B, C) {
+	return t.First, t.Second, t.Third
+}
+
+// String 实现 fmt.Stringer
+func (t Tuple3[A, B, C]) String() string {
+	return fmt.Sprintf("(%v, %v, %v)", t.First, t.Second, t.Third)
+}
+
+// Tuple4 四元组
+type Tuple4[A, B, C, D any] struct {
+	First  A
+	Second B
+	Third  C
+	Fourth D
+}
+
+// NewTuple4 创建一个四元组
+func NewTuple4[A, B, C, D any](first A, second B, third C, fourth D) Tuple4[A, B, C, D] {
+	return Tuple4[A, B, C, D]{First: first, Second: second, Third: third, Fourth: fourth}
+}
+
+// Unpack 返回四个元素，便于多重赋值
+func (t Tuple4[A, B, C, D]) Unpack() (A, B, C, D) {
+	return t.First, t.Second, t.Third, t.Fourth
+}
+
+// String 实现 fmt.Stringer
+func (t Tuple4[A, B, C, D]) String() string {
+	return fmt.Sprintf("(%v, %v, %v, %v)", t.First, t.Second, t.Third, t.Fourth)
+}
@@ -0,0 +1,47 @@
+package types
+
+import "testing"
+
+func TestPair(t *testing.T) {
+	tests := []struct {
+		name    string
+		p       Pair[string, int]
+		wantStr string
+	}{
+		{"普通值", NewPair("a", 1), "(a, 1)"},
+		{"零值", Pair[string, int]{}, "(, 0)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := tt.p.Unpack()
+			if a != tt.p.First || b != tt.p.Second {
+				t.Errorf("Unpack() = %v, %v, 期望 %v, %v", a, b, tt.p.First, tt.p.Second)
+			}
+			swapped := tt.p.Swap()
+			if swapped.First != tt.p.Second || swapped.Second != tt.p.First {
+				t.Errorf("Swap() = %v, 期望交换后的 %v", swapped, tt.p)
+			}
+			if got := tt.p.String(); got != tt.wantStr {
+				t.Errorf("String() = %q, 期望 %q", got, tt.wantStr)
+			}
+		})
+	}
+}
+
+func TestTuple(t *testing.T) {
+	t3 := NewTuple3("a", 1, true)
+	if a, b, c := t3.Unpack(); a != "a" || b != 1 || !c {
+		t.Errorf("Tuple3.Unpack() = %v, %v, %v", a, b, c)
+	}
+	if got := t3.String(); got != "(a, 1, true)" {
+		t.Errorf("Tuple3.String() = %q, 期望 %q", got, "(a, 1, true)")
+	}
+
+	t4 := NewTuple4(1, 2.5, "x", 'y')
+	if a, b, c, d := t4.Unpack(); a != 1 || b != 2.5 || c != "x" || d != 'y' {
+		t.Errorf("Tuple4.Unpack() = %v, %v, %v, %v", a, b, c, d)
+	}
+	if got := t4.String(); got != "(1, 2.5, x, 121)" {
+		t.Errorf("Tuple4.String() = %q, 期望 %q", got, "(1, 2.5, x, 121)")
+	}
+}
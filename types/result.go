@@ -0,0 +1,115 @@
+package types
+
+import "fmt"
+
+// Result 保存一个值或一个错误，用于让可能失败的步骤在 Map、ParallelMap 等管道中流转
+// 零值是值为零值的成功结果
+type Result[T any] struct {
+	val T
+	err error
+}
+
+// Ok 创建一个成功的结果
+func Ok[T any](v T) Result[T] {
+	return Result[T]{val: v}
+}
+
+// Err 创建一个失败的结果
+func Err[T any](err error) Result[T] {
+	return Result[T]{err: err}
+}
+
+// Of 将 (T, error) 形式的返回值转换为 Result，err 不为 nil 时为失败结果
+func Of[T any](v T, err error) Result[T] {
+	if err != nil {
+		return Err[T](err)
+	}
+	return Ok(v)
+}
+
+// Lift 将返回 (R, error) 的函数转换为返回 Result 的函数，便于直接传给 Map 等函数
+func Lift[T, R any](fn func(T) (R, error)) func(T) Result[R] {
+	return func(v T) Result[R] {
+		return Of(fn(v))
+	}
+}
+
+// IsOk 判断是否为成功结果
+func (r Result[T]) IsOk() bool {
+	return r.err == nil
+}
+
+// IsErr 判断是否为失败结果
+func (r Result[T]) IsErr() bool {
+	return r.err != nil
+}
+
+// Error 返回失败结果的错误，成功时返回 nil
+func (r Result[T]) Error() error {
+	return r.err
+}
+
+// Get 以 (T, error) 的形式返回结果
+func (r Result[T]) Get() (T, error) {
+	return r.val, r.err
+}
+
+// Unwrap 返回成功结果的值，失败时 panic
+func (r Result[T]) Unwrap() T {
+	if r.err != nil {
+		panic(fmt.Errorf("types: unwrap of error result: %w", r.err))
+	}
+	return r.val
+}
+
+// UnwrapOr 返回成功结果的值，失败时返回 def
+func (r Result[T]) UnwrapOr(def T) T {
+	if r.err != nil {
+		return def
+	}
+	return r.val
+}
+
+// UnwrapOrElse 返回成功结果的值，失败时返回 fn(err)
+func (r Result[T]) UnwrapOrElse(fn func(err error) T) T {
+	if r.err != nil {
+		return fn(r.err)
+	}
+	return r.val
+}
+
+// MapErr 对失败结果的错误应用 fn，例如为错误添加上下文；成功结果保持不变
+func (r Result[T]) MapErr(fn func(err error) error) Result[T] {
+	if r.err != nil {
+		return Err[T](fn(r.err))
+	}
+	return r
+}
+
+// MapResult 对成功结果的值应用 fn，失败结果直接传递
+func MapResult[T, R any](r Result[T], fn func(T) R) Result[R] {
+	if r.err != nil {
+		return Err[R](r.err)
+	}
+	return Ok(fn(r.val))
+}
+
+// AndThen 对成功结果的值应用可能失败的 fn，失败结果直接传递
+func AndThen[T, R any](r Result[T], fn func(T) Result[R]) Result[R] {
+	if r.err != nil {
+		return Err[R](r.err)
+	}
+	return fn(r.val)
+}
+
+// CollectResults 收集所有成功结果的值，遇到第一个失败结果时返回其错误
+func CollectResults[T any](results []Result[T]) ([]T, error) {
+	values := make([]T, 0, len(results))
+	for _, r := range results {
+		if r.err != nil {
+			return nil, r.err
+		}
+		values = append(values, r.val)
+	}
+	return values, nil
+}
@@ -0,0 +1,126 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestResult(t *testing.T) {
+	errBoom := errors.New("boom")
+	tests := []struct {
+		name     string
+		r        Result[int]
+		wantOk   bool
+		wantErr  error
+		wantOr   int
+		wantElse int
+	}{
+		{"Ok", Ok(1), true, nil, 1, 1},
+		{"Err", Err[int](errBoom), false, errBoom, -1, 99},
+		{"Of 成功", Of(2, nil), true, nil, 2, 2},
+		{"Of 失败", Of(2, errBoom), false, errBoom, -1, 99},
+		{"零值", Result[int]{}, true, nil, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.r.IsOk() != tt.wantOk || tt.r.IsErr() == tt.wantOk {
+				t.Errorf("IsOk() = %v, 期望 %v", tt.r.IsOk(), tt.wantOk)
+			}
+			if !errors.Is(tt.r.Error(), tt.wantErr) {
+				t.Errorf("Error() = %v, 期望 %v", tt.r.Error(), tt.wantErr)
+			}
+			if got := tt.r.UnwrapOr(-1); got != tt.wantOr {
+				t.Errorf("UnwrapOr() = %v, 期望 %v", got, tt.wantOr)
+			}
+			if got := tt.r.UnwrapOrElse(func(error) int { return 99 }); got != tt.wantElse {
+				t.Errorf("UnwrapOrElse() = %v, 期望 %v", got, tt.wantElse)
+			}
+		})
+	}
+}
+
+func TestResultUnwrap(t *testing.T) {
+	if v := Ok("x").Unwrap(); v != "x" {
+		t.Errorf("Unwrap() = %v, 期望 %v", v, "x")
+	}
+	errBoom := errors.New("boom")
+	defer func() {
+		r := recover()
+		err, ok := r.(error)
+		if !ok || !errors.Is(err, errBoom) {
+			t.Errorf("Unwrap() panic = %v, 期望包含 %v", r, errBoom)
+		}
+	}()
+	Err[string](errBoom).Unwrap()
+}
+
+func TestResultTransform(t *testing.T) {
+	errBoom := errors.New("boom")
+	parse := Lift(strconv.Atoi)
+	half := func(n int) Result[int] {
+		if n%2 != 0 {
+			return Err[int](fmt.Errorf("%d is odd", n))
+		}
+		return Ok(n / 2)
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{"全部成功", "8", 4, false},
+		{"解析失败", "x", 0, true},
+		{"AndThen 失败", "3", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := AndThen(parse(tt.input), half)
+			got, err := r.Get()
+			if got != tt.want || (err != nil) != tt.wantErr {
+				t.Errorf("Get() = %v, %v, 期望 %v, 错误 %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+
+	doubled := MapResult(Ok(3), func(n int) string { return strconv.Itoa(n * 2) })
+	if v, _ := doubled.Get(); v != "6" {
+		t.Errorf("MapResult() = %v, 期望 %v", v, "6")
+	}
+	if r := MapResult(Err[int](errBoom), strconv.Itoa); !errors.Is(r.Error(), errBoom) {
+		t.Errorf("MapResult() 错误 = %v, 期望 %v", r.Error(), errBoom)
+	}
+	wrapped := Err[int](errBoom).MapErr(func(err error) error { return fmt.Errorf("step: %w", err) })
+	if !errors.Is(wrapped.Error(), errBoom) || wrapped.Error().Error() != "step: boom" {
+		t.Errorf("MapErr() = %v, 期望 %v", wrapped.Error(), "step: boom")
+	}
+	if r := Ok(1).MapErr(func(error) error { return errBoom }); r.IsErr() {
+		t.Error("MapErr() 不应影响成功结果")
+	}
+}
+
+func TestCollectResults(t *testing.T) {
+	errBoom := errors.New("boom")
+	tests := []struct {
+		name    string
+		results []Result[int]
+		want    []int
+		wantErr error
+	}{
+		{"全部成功", []Result[int]{Ok(1), Ok(2)}, []int{1, 2}, nil},
+		{"包含失败", []Result[int]{Ok(1), Err[int](errBoom), Ok(3)}, nil, errBoom},
+		{"空", nil, []int{}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CollectResults(tt.results)
+			if !slices.Equal(got, tt.want) || !errors.Is(err, tt.wantErr) {
+				t.Errorf("CollectResults() = %v, %v, 期望 %v, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package valutils
+
+// Coalesce 返回第一个非零值，全部为零值时返回零值
+// 适用于 参数 -> 环境变量 -> 配置文件 -> 默认值 这样的回退链；字符串需要跳过空白时使用 strutils.Coalesce
+func Coalesce[T comparable](values ...T) T {
+	var zero T
+	for _, v := range values {
+		if v != zero {
+			return v
+		}
+	}
+	return zero
+}
+
+// CoalesceFunc 返回第一个使 isSet 返回 true 的值，都不满足时返回零值，用于不可比较的类型或自定义的"已设置"判断
+func CoalesceFunc[T any](isSet func(T) bool, values ...T) T {
+	for _, v := range values {
+		if isSet(v) {
+			return v
+		}
+	}
+	var zero T
+	return zero
+}
+
+// FirstNonNil 返回第一个非 nil 的指针，全部为 nil 时返回 nil
+// 与 Coalesce 不同，指向零值的指针也被视为已设置
+func FirstNonNil[T any](ptrs ...*T) *T {
+	for _, p := range ptrs {
+		if p != nil {
+			return p
+		}
+	}
+	return nil
+}
+
+// IsZero 判断 v 是否为其类型的零值
+func IsZero[T comparable](v T) bool {
+	var zero T
+	return v == zero
+}
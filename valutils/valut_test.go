@@ -0,0 +1,95 @@
+package valutils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCoalesce(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []int
+		want   int
+	}{
+		{"第一个非零值", []int{0, 0, 3, 4}, 3},
+		{"第一个就是", []int{1, 2}, 1},
+		{"全部为零", []int{0, 0}, 0},
+		{"没有参数", nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Coalesce(tt.values...); got != tt.want {
+				t.Errorf("Coalesce() = %v, 期望 %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := Coalesce("", "env", "default"); got != "env" {
+		t.Errorf("Coalesce() = %q, 期望 %q", got, "env")
+	}
+	if got := Coalesce(0, 30*time.Second); got != 30*time.Second {
+		t.Errorf("Coalesce() = %v, 期望 %v", got, 30*time.Second)
+	}
+}
+
+func TestCoalesceFunc(t *testing.T) {
+	nonEmpty := func(s []string) bool { return len(s) > 0 }
+	tests := []struct {
+		name   string
+		values [][]string
+		want   int
+	}{
+		{"跳过空切片", [][]string{nil, {}, {"a", "b"}}, 2},
+		{"全部为空", [][]string{nil, {}}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CoalesceFunc(nonEmpty, tt.values...); len(got) != tt.want {
+				t.Errorf("CoalesceFunc() = %v, 期望长度 %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFirstNonNil(t *testing.T) {
+	zero, one := 0, 1
+	tests := []struct {
+		name string
+		ptrs []*int
+		want *int
+	}{
+		{"指向零值也算已设置", []*int{nil, &zero, &one}, &zero},
+		{"第一个", []*int{&one}, &one},
+		{"全部为 nil", []*int{nil, nil}, nil},
+		{"没有参数", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FirstNonNil(tt.ptrs...); got != tt.want {
+				t.Errorf("FirstNonNil() = %v, 期望 %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsZero(t *testing.T) {
+	type point struct{ X, Y int }
+	tests := []struct {
+		name string
+		got  bool
+		want bool
+	}{
+		{"零整数", IsZero(0), true},
+		{"非零整数", IsZero(1), false},
+		{"空字符串", IsZero(""), true},
+		{"零结构体", IsZero(point{}), true},
+		{"非零结构体", IsZero(point{X: 1}), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("IsZero() = %v, 期望 %v", tt.got, tt.want)
+			}
+		})
+	}
+}